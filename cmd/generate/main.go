@@ -426,17 +426,17 @@ func publish(ka kadmin.Kadmin, topic string, id string, event interface{}, schem
 	record = append(record, valueBytes...)
 
 	msg := ka.PublishRecord(&kadmin.ProducerRecord{
-		Key:       id,
+		Key:       []byte(id),
 		Value:     record,
 		Topic:     topic,
 		Partition: nil,
-		Headers: map[string]string{
-			"content-type": "application/vnd.apache.avro+json",
-			"eventId":      id,
-			"eventType":    "ProductCreated",
-			"eventSource":  "ktea",
-			"eventVersion": "1.0",
-			"eventTime":    time.Now().String(),
+		Headers: []kadmin.Header{
+			{Key: "content-type", Value: kadmin.NewHeaderValue("application/vnd.apache.avro+json")},
+			{Key: "eventId", Value: kadmin.NewHeaderValue(id)},
+			{Key: "eventType", Value: kadmin.NewHeaderValue("ProductCreated")},
+			{Key: "eventSource", Value: kadmin.NewHeaderValue("ktea")},
+			{Key: "eventVersion", Value: kadmin.NewHeaderValue("1.0")},
+			{Key: "eventTime", Value: kadmin.NewHeaderValue(time.Now().String())},
 		},
 	})
 	switch msg := msg.AwaitCompletion().(type) {
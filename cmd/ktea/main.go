@@ -11,6 +11,7 @@ import (
 	"ktea/ui/components/statusbar"
 	"ktea/ui/components/tab"
 	"ktea/ui/pages/clusters_page"
+	"ktea/ui/pages/nav"
 	"ktea/ui/tabs"
 	"ktea/ui/tabs/cgroups_tab"
 	"ktea/ui/tabs/clusters_tab"
@@ -140,6 +141,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		kcadmin.ConnectorListingErrMsg:
 		return m, m.kconTabCtrl.Update(msg)
 
+	case nav.LoadSchemaDetailsPageMsg:
+		// schemas can be opened from other tabs, e.g. from the record details
+		if m.schemaRegistryTabCtrl != nil {
+			m.tabs.GoToTab(schemaRegTabLbl)
+		}
+
+	case nav.LoadSubjectsPageMsg:
+		// a subject can be selected from other tabs, e.g. from the topics list
+		if msg.Subject != "" && m.schemaRegistryTabCtrl != nil {
+			m.tabs.GoToTab(schemaRegTabLbl)
+		}
+
 	case kadmin.ConnCheckStartedMsg:
 		m.switchingCluster = true
 	case kadmin.ConnCheckErrMsg, kadmin.ConnCheckSucceededMsg:
@@ -175,7 +188,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, tea.Batch(cmds...)
 		} else {
-			m.statusbar = statusbar.New()
+			m.statusbar = statusbar.New(statusbar.WithMemoryUsage(statusbar.HeapInUse))
 			tCtrl, cmd := clusters_tab.New(m.ktx, kadmin.CheckKafkaConnectivity, sradmin.CheckSchemaRegistryConn, m.statusbar)
 			m.tabCtrl = tCtrl
 			m.tabs = tab.New(clustersTab)
@@ -268,6 +281,9 @@ func (m *Model) recreateAdminClients(cluster *config.Cluster) error {
 	if ka, err := m.kaInstantiator(cluster); err != nil {
 		return err
 	} else {
+		if m.ka != nil {
+			closeAdmin(m.ka)
+		}
 		m.ka = ka
 	}
 
@@ -279,6 +295,16 @@ func (m *Model) recreateAdminClients(cluster *config.Cluster) error {
 	return nil
 }
 
+// closeAdmin closes the connections of a replaced kadmin in the background,
+// pages that still hold it are replaced as well.
+func closeAdmin(ka kadmin.Kadmin) {
+	go func() {
+		if err := ka.Close(); err != nil {
+			log.Error("Unable to close connection", "err", err)
+		}
+	}()
+}
+
 func (m *Model) onWindowSizeUpdated(msg tea.WindowSizeMsg) {
 	m.ktx.WindowWidth = msg.Width
 	m.ktx.WindowHeight = msg.Height
@@ -287,7 +313,7 @@ func (m *Model) onWindowSizeUpdated(msg tea.WindowSizeMsg) {
 
 func (m *Model) boostrapUI(cluster *config.Cluster) tea.Cmd {
 	var cmd tea.Cmd
-	m.statusbar = statusbar.New()
+	m.statusbar = statusbar.New(statusbar.WithMemoryUsage(statusbar.HeapInUse))
 	if err := m.recreateAdminClients(cluster); err != nil {
 		m.tabs = tab.New(clustersTab)
 		m.clustersTabCtrl, cmd = clusters_tab.New(m.ktx, kadmin.CheckKafkaConnectivity, sradmin.CheckSchemaRegistryConn, m.statusbar)
@@ -301,9 +327,13 @@ func (m *Model) boostrapUI(cluster *config.Cluster) tea.Cmd {
 	} else {
 		var cmds []tea.Cmd
 		m.recreateTabs(cluster)
-		m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka, m.statusbar)
+		var sra sradmin.Client
+		if cluster.HasSchemaRegistry() {
+			sra = m.sra
+		}
+		m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka, sra, m.kaInstantiator, m.statusbar)
 		cmds = append(cmds, cmd)
-		m.cgroupsTabCtrl, cmd = cgroups_tab.New(m.ka, m.ka, m.ka, m.statusbar)
+		m.cgroupsTabCtrl, cmd = cgroups_tab.New(m.ktx, m.ka, m.ka, m.ka, m.ka, m.ka, m.ka, m.statusbar)
 		cmds = append(cmds, cmd)
 		m.clustersTabCtrl, cmd = clusters_tab.New(m.ktx, kadmin.CheckKafkaConnectivity, sradmin.CheckSchemaRegistryConn, m.statusbar)
 		cmds = append(cmds, cmd)
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
@@ -45,6 +47,113 @@ type KafkaConnectConfig struct {
 	Password *string `yaml:"password"`
 }
 
+// LocalAvroSchema maps a topic to an Avro schema on disk,
+// used to decode records of which the schema is not registered in a Schema Registry.
+type LocalAvroSchema struct {
+	Topic string `yaml:"topic"`
+	// Path is either an .avsc file or a directory of schemas named by their fully qualified name.
+	Path string `yaml:"path"`
+	// Name is the fully qualified name of the schema to use when Path is a directory.
+	Name string `yaml:"name,omitempty"`
+}
+
+// LocalProtobufSchema maps a topic to Protobuf descriptors on disk.
+type LocalProtobufSchema struct {
+	Topic string `yaml:"topic"`
+	// Path is either a compiled FileDescriptorSet or a directory of .proto files.
+	Path string `yaml:"path"`
+	// MessageType is the fully qualified name of the message the records of the topic are decoded as.
+	MessageType string `yaml:"messageType"`
+}
+
+// DeserializerPlugin binds a topic to an external executable that deserializes its records.
+type DeserializerPlugin struct {
+	Topic   string   `yaml:"topic"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args,omitempty"`
+	// Timeout is the time the plugin has to deserialize a single record, e.g. 5s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// HeaderType determines how the value of a header is decoded.
+type HeaderType string
+
+const (
+	// HeaderTypeAuto shows valid UTF-8 as text and guesses integers from their size
+	HeaderTypeAuto   HeaderType = ""
+	HeaderTypeString HeaderType = "string"
+	HeaderTypeInt32  HeaderType = "int32"
+	HeaderTypeInt64  HeaderType = "int64"
+	HeaderTypeFloat  HeaderType = "float"
+	HeaderTypeUUID   HeaderType = "uuid"
+	HeaderTypeHex    HeaderType = "hex"
+	HeaderTypeBase64 HeaderType = "base64"
+	HeaderTypeJSON   HeaderType = "json"
+)
+
+// HeaderTypes lists the header types in the order they are cycled through.
+var HeaderTypes = []HeaderType{
+	HeaderTypeAuto,
+	HeaderTypeString,
+	HeaderTypeInt32,
+	HeaderTypeInt64,
+	HeaderTypeFloat,
+	HeaderTypeUUID,
+	HeaderTypeHex,
+	HeaderTypeBase64,
+	HeaderTypeJSON,
+}
+
+// HeaderDecoding configures how the values of a header of a topic are decoded.
+type HeaderDecoding struct {
+	Topic string     `yaml:"topic"`
+	Key   string     `yaml:"key"`
+	Type  HeaderType `yaml:"type"`
+}
+
+// SubjectNameStrategy determines the subjects the schemas of the records of a topic are registered under.
+type SubjectNameStrategy string
+
+const (
+	// TopicNameStrategy registers schemas as <topic>-key and <topic>-value
+	TopicNameStrategy SubjectNameStrategy = "TopicNameStrategy"
+	// TopicRecordNameStrategy registers schemas as <topic>-<fully qualified record name>
+	TopicRecordNameStrategy SubjectNameStrategy = "TopicRecordNameStrategy"
+	// RecordNameStrategy registers schemas as <fully qualified record name>
+	RecordNameStrategy SubjectNameStrategy = "RecordNameStrategy"
+)
+
+// TopicSubjects configures the subject name strategy of a topic.
+type TopicSubjects struct {
+	Topic    string              `yaml:"topic"`
+	Strategy SubjectNameStrategy `yaml:"strategy,omitempty"`
+	// KeyRecord and ValueRecord are the fully qualified names of the key and value records,
+	// required by the record name based strategies.
+	KeyRecord   string `yaml:"keyRecord,omitempty"`
+	ValueRecord string `yaml:"valueRecord,omitempty"`
+}
+
+// PublishTemplate is a record saved to be published to a topic again, its key, header values and payload
+// may contain placeholders that are expanded on every publication.
+type PublishTemplate struct {
+	Name    string                  `yaml:"name"`
+	Topic   string                  `yaml:"topic"`
+	Key     string                  `yaml:"key,omitempty"`
+	Headers []PublishTemplateHeader `yaml:"headers,omitempty"`
+	Payload string                  `yaml:"payload,omitempty"`
+	// KeyFormat and PayloadFormat are the formats the key and payload are entered in, text when empty.
+	// A null format publishes a null key or a tombstone.
+	KeyFormat     string `yaml:"keyFormat,omitempty"`
+	PayloadFormat string `yaml:"payloadFormat,omitempty"`
+}
+
+type PublishTemplateHeader struct {
+	Key string `yaml:"key"`
+	// Type is the type the value is encoded as, a string when empty
+	Type  HeaderType `yaml:"type,omitempty"`
+	Value string     `yaml:"value"`
+}
+
 type Cluster struct {
 	Name             string     `yaml:"name"`
 	Color            string     `yaml:"color"`
@@ -56,6 +165,20 @@ type Cluster struct {
 	TLSConfig      TLSConfig             `yaml:"tls"`
 	// Kafka Connect clusters are optional, hence can be empty
 	KafkaConnectClusters []KafkaConnectConfig `yaml:"kafkaConnectClusters"`
+	// Local Avro schemas are optional and only managed through the config file
+	LocalAvroSchemas []LocalAvroSchema `yaml:"localAvroSchemas,omitempty"`
+	// Local Protobuf schemas are optional and only managed through the config file
+	LocalProtobufSchemas []LocalProtobufSchema `yaml:"localProtobufSchemas,omitempty"`
+	// Deserializer plugins are optional and only managed through the config file
+	DeserializerPlugins []DeserializerPlugin `yaml:"deserializerPlugins,omitempty"`
+	// Header decodings are optional, hence can be empty
+	HeaderDecodings []HeaderDecoding `yaml:"headerDecodings,omitempty"`
+	// SubjectNameStrategy applies to all topics without TopicSubjects, defaults to TopicNameStrategy
+	SubjectNameStrategy SubjectNameStrategy `yaml:"subjectNameStrategy,omitempty"`
+	// Topic subjects are optional and only managed through the config file
+	TopicSubjects []TopicSubjects `yaml:"topicSubjects,omitempty"`
+	// Publish templates are optional, hence can be empty
+	PublishTemplates []PublishTemplate `yaml:"publishTemplates,omitempty"`
 }
 
 func (c *Cluster) HasSchemaRegistry() bool {
@@ -66,16 +189,120 @@ func (c *Cluster) HasKafkaConnect() bool {
 	return len(c.KafkaConnectClusters) > 0
 }
 
+// LocalAvroSchema returns the local Avro schema mapped to the given topic or nil when there is none.
+func (c *Cluster) LocalAvroSchema(topic string) *LocalAvroSchema {
+	for _, s := range c.LocalAvroSchemas {
+		if s.Topic == topic {
+			return &s
+		}
+	}
+	return nil
+}
+
+// LocalProtobufSchema returns the local Protobuf schema mapped to the given topic or nil when there is none.
+func (c *Cluster) LocalProtobufSchema(topic string) *LocalProtobufSchema {
+	for _, s := range c.LocalProtobufSchemas {
+		if s.Topic == topic {
+			return &s
+		}
+	}
+	return nil
+}
+
+// DeserializerPlugin returns the deserializer plugin bound to the given topic or nil when there is none.
+func (c *Cluster) DeserializerPlugin(topic string) *DeserializerPlugin {
+	for _, p := range c.DeserializerPlugins {
+		if p.Topic == topic {
+			return &p
+		}
+	}
+	return nil
+}
+
+// HeaderType returns how the given header of a topic is decoded.
+func (c *Cluster) HeaderType(topic string, key string) HeaderType {
+	for _, d := range c.HeaderDecodings {
+		if d.Topic == topic && d.Key == key {
+			return d.Type
+		}
+	}
+	return HeaderTypeAuto
+}
+
+// TopicPublishTemplates returns the publish templates of a topic in the order they were saved.
+func (c *Cluster) TopicPublishTemplates(topic string) []PublishTemplate {
+	var templates []PublishTemplate
+	for _, t := range c.PublishTemplates {
+		if t.Topic == topic {
+			templates = append(templates, t)
+		}
+	}
+	return templates
+}
+
+// Subjects returns the key and value subject of a topic according to its subject name strategy,
+// a subject is empty when the strategy requires a record name that isn't configured.
+func (c *Cluster) Subjects(topic string) (key string, value string) {
+	subjects := TopicSubjects{Topic: topic, Strategy: c.SubjectNameStrategy}
+	for _, s := range c.TopicSubjects {
+		if s.Topic == topic {
+			subjects = s
+			if subjects.Strategy == "" {
+				subjects.Strategy = c.SubjectNameStrategy
+			}
+		}
+	}
+
+	switch subjects.Strategy {
+	case TopicRecordNameStrategy:
+		if subjects.KeyRecord != "" {
+			key = topic + "-" + subjects.KeyRecord
+		}
+		if subjects.ValueRecord != "" {
+			value = topic + "-" + subjects.ValueRecord
+		}
+		return key, value
+	case RecordNameStrategy:
+		return subjects.KeyRecord, subjects.ValueRecord
+	default:
+		return topic + "-key", topic + "-value"
+	}
+}
+
+const DefaultLiveBufferSize = 500
+
+const DefaultLagSamplingInterval = 5 * time.Second
+
 type Config struct {
 	Clusters   []Cluster `yaml:"clusters"`
 	ConfigIO   IO        `yaml:"-"`
 	PlainFonts bool      `yaml:"plainFonts"`
+	// LiveBufferSize is the number of newest records retained while live consuming.
+	LiveBufferSize int `yaml:"liveBufferSize,omitempty"`
+	// LagSampling is how often the lag of an opened consumer group is sampled, e.g. 10s.
+	LagSampling time.Duration `yaml:"lagSamplingInterval,omitempty"`
 }
 
 func (c *Config) HasClusters() bool {
 	return len(c.Clusters) > 0
 }
 
+// LiveRecordsBufferSize returns the configured live buffer size or DefaultLiveBufferSize when not configured.
+func (c *Config) LiveRecordsBufferSize() int {
+	if c.LiveBufferSize <= 0 {
+		return DefaultLiveBufferSize
+	}
+	return c.LiveBufferSize
+}
+
+// LagSamplingInterval returns the configured lag sampling interval or DefaultLagSamplingInterval when not configured.
+func (c *Config) LagSamplingInterval() time.Duration {
+	if c.LagSampling <= 0 {
+		return DefaultLagSamplingInterval
+	}
+	return c.LagSampling
+}
+
 type SchemaRegistryDetails struct {
 	Url       string
 	Username  string
@@ -169,6 +396,14 @@ func (c *Config) RegisterCluster(details RegistrationDetails) tea.Msg {
 		if c.Clusters[i].Name == details.Name {
 			isActive := c.Clusters[i].Active
 			cluster.Active = isActive
+			// settings that are not part of the registration details are retained
+			cluster.LocalAvroSchemas = c.Clusters[i].LocalAvroSchemas
+			cluster.LocalProtobufSchemas = c.Clusters[i].LocalProtobufSchemas
+			cluster.DeserializerPlugins = c.Clusters[i].DeserializerPlugins
+			cluster.HeaderDecodings = c.Clusters[i].HeaderDecodings
+			cluster.SubjectNameStrategy = c.Clusters[i].SubjectNameStrategy
+			cluster.TopicSubjects = c.Clusters[i].TopicSubjects
+			cluster.PublishTemplates = c.Clusters[i].PublishTemplates
 			c.Clusters[i] = cluster
 			if details.NewName != nil {
 				c.Clusters[i].Name = *details.NewName
@@ -300,6 +535,49 @@ func (c *Config) DeleteCluster(name string) {
 	log.Debug("deleted cluster: " + name)
 }
 
+// SetHeaderType persists how the given header of a topic is decoded,
+// HeaderTypeAuto removes the mapping.
+func (c *Config) SetHeaderType(clusterName string, topic string, key string, headerType HeaderType) {
+	for i := range c.Clusters {
+		if c.Clusters[i].Name != clusterName {
+			continue
+		}
+
+		decodings := slices.DeleteFunc(c.Clusters[i].HeaderDecodings, func(d HeaderDecoding) bool {
+			return d.Topic == topic && d.Key == key
+		})
+		if headerType != HeaderTypeAuto {
+			decodings = append(decodings, HeaderDecoding{Topic: topic, Key: key, Type: headerType})
+		}
+		c.Clusters[i].HeaderDecodings = decodings
+
+		c.flush()
+		return
+	}
+}
+
+// SavePublishTemplate persists a publish template,
+// replacing the template of the same topic with the same name.
+func (c *Config) SavePublishTemplate(clusterName string, template PublishTemplate) {
+	for i := range c.Clusters {
+		if c.Clusters[i].Name != clusterName {
+			continue
+		}
+
+		idx := slices.IndexFunc(c.Clusters[i].PublishTemplates, func(t PublishTemplate) bool {
+			return t.Topic == template.Topic && t.Name == template.Name
+		})
+		if idx == -1 {
+			c.Clusters[i].PublishTemplates = append(c.Clusters[i].PublishTemplates, template)
+		} else {
+			c.Clusters[i].PublishTemplates[idx] = template
+		}
+
+		c.flush()
+		return
+	}
+}
+
 func (c *Config) FindClusterByName(name string) *Cluster {
 	for _, cluster := range c.Clusters {
 		if cluster.Name == name {
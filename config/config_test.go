@@ -2,8 +2,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -504,4 +506,309 @@ func TestConfig(t *testing.T) {
 		// then
 		assert.Nil(t, cluster)
 	})
+
+	t.Run("Local Avro schemas", func(t *testing.T) {
+		t.Run("find schema by topic", func(t *testing.T) {
+			cluster := Cluster{LocalAvroSchemas: []LocalAvroSchema{
+				{Topic: "orders", Path: "/schemas/order.avsc"},
+				{Topic: "payments", Path: "/schemas", Name: "com.acme.Payment"},
+			}}
+
+			assert.Equal(t, &LocalAvroSchema{Topic: "payments", Path: "/schemas", Name: "com.acme.Payment"}, cluster.LocalAvroSchema("payments"))
+			assert.Nil(t, cluster.LocalAvroSchema("invoices"))
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.Clusters[0].LocalAvroSchemas = []LocalAvroSchema{{Topic: "orders", Path: "/schemas/order.avsc"}}
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, []LocalAvroSchema{{Topic: "orders", Path: "/schemas/order.avsc"}}, config.Clusters[0].LocalAvroSchemas)
+		})
+	})
+
+	t.Run("Local Protobuf schemas", func(t *testing.T) {
+		t.Run("find schema by topic", func(t *testing.T) {
+			cluster := Cluster{LocalProtobufSchemas: []LocalProtobufSchema{
+				{Topic: "orders", Path: "/protos", MessageType: "com.acme.Order"},
+			}}
+
+			assert.Equal(t, &LocalProtobufSchema{Topic: "orders", Path: "/protos", MessageType: "com.acme.Order"}, cluster.LocalProtobufSchema("orders"))
+			assert.Nil(t, cluster.LocalProtobufSchema("invoices"))
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.Clusters[0].LocalProtobufSchemas = []LocalProtobufSchema{{Topic: "orders", Path: "/protos", MessageType: "com.acme.Order"}}
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, []LocalProtobufSchema{{Topic: "orders", Path: "/protos", MessageType: "com.acme.Order"}}, config.Clusters[0].LocalProtobufSchemas)
+		})
+	})
+
+	t.Run("Deserializer plugins", func(t *testing.T) {
+		t.Run("parsed from yaml", func(t *testing.T) {
+			var cluster Cluster
+			err := yaml.Unmarshal([]byte(`
+deserializerPlugins:
+  - topic: orders
+    command: /usr/local/bin/ktea-legacy
+    args: ["--strict"]
+    timeout: 2s
+`), &cluster)
+
+			assert.NoError(t, err)
+			assert.Equal(t, &DeserializerPlugin{
+				Topic:   "orders",
+				Command: "/usr/local/bin/ktea-legacy",
+				Args:    []string{"--strict"},
+				Timeout: 2 * time.Second,
+			}, cluster.DeserializerPlugin("orders"))
+			assert.Nil(t, cluster.DeserializerPlugin("payments"))
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.Clusters[0].DeserializerPlugins = []DeserializerPlugin{{Topic: "orders", Command: "ktea-legacy"}}
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, []DeserializerPlugin{{Topic: "orders", Command: "ktea-legacy"}}, config.Clusters[0].DeserializerPlugins)
+		})
+	})
+
+	t.Run("Header decodings", func(t *testing.T) {
+		t.Run("auto when not configured", func(t *testing.T) {
+			cluster := Cluster{}
+
+			assert.Equal(t, HeaderTypeAuto, cluster.HeaderType("orders", "version"))
+		})
+
+		t.Run("set header type", func(t *testing.T) {
+			configIO := &InMemoryConfigIO{}
+			config := New(configIO)
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+
+			config.SetHeaderType("prd", "orders", "version", HeaderTypeInt32)
+			config.SetHeaderType("prd", "orders", "trace-id", HeaderTypeUUID)
+			config.SetHeaderType("prd", "orders", "version", HeaderTypeInt64)
+
+			assert.Equal(t, HeaderTypeInt64, config.Clusters[0].HeaderType("orders", "version"))
+			assert.Equal(t, HeaderTypeUUID, config.Clusters[0].HeaderType("orders", "trace-id"))
+			assert.Equal(t, HeaderTypeAuto, config.Clusters[0].HeaderType("payments", "version"))
+			assert.Len(t, configIO.config.Clusters[0].HeaderDecodings, 2)
+		})
+
+		t.Run("setting auto removes the mapping", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.SetHeaderType("prd", "orders", "version", HeaderTypeInt32)
+
+			config.SetHeaderType("prd", "orders", "version", HeaderTypeAuto)
+
+			assert.Empty(t, config.Clusters[0].HeaderDecodings)
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.SetHeaderType("prd", "orders", "version", HeaderTypeInt32)
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, HeaderTypeInt32, config.Clusters[0].HeaderType("orders", "version"))
+		})
+	})
+
+	t.Run("Subject name strategies", func(t *testing.T) {
+		t.Run("topic name strategy by default", func(t *testing.T) {
+			cluster := Cluster{}
+
+			key, value := cluster.Subjects("orders")
+
+			assert.Equal(t, "orders-key", key)
+			assert.Equal(t, "orders-value", value)
+		})
+
+		t.Run("parsed from yaml", func(t *testing.T) {
+			var cluster Cluster
+			err := yaml.Unmarshal([]byte(`
+subjectNameStrategy: TopicRecordNameStrategy
+topicSubjects:
+  - topic: orders
+    valueRecord: com.acme.Order
+  - topic: payments
+    strategy: RecordNameStrategy
+    keyRecord: com.acme.PaymentKey
+    valueRecord: com.acme.Payment
+  - topic: audit
+    strategy: TopicNameStrategy
+`), &cluster)
+			assert.NoError(t, err)
+
+			key, value := cluster.Subjects("orders")
+			assert.Equal(t, "", key)
+			assert.Equal(t, "orders-com.acme.Order", value)
+
+			key, value = cluster.Subjects("payments")
+			assert.Equal(t, "com.acme.PaymentKey", key)
+			assert.Equal(t, "com.acme.Payment", value)
+
+			key, value = cluster.Subjects("audit")
+			assert.Equal(t, "audit-key", key)
+			assert.Equal(t, "audit-value", value)
+
+			key, value = cluster.Subjects("shipments")
+			assert.Equal(t, "", key)
+			assert.Equal(t, "", value)
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.Clusters[0].SubjectNameStrategy = RecordNameStrategy
+			config.Clusters[0].TopicSubjects = []TopicSubjects{{Topic: "orders", ValueRecord: "com.acme.Order"}}
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, RecordNameStrategy, config.Clusters[0].SubjectNameStrategy)
+			assert.Equal(t, []TopicSubjects{{Topic: "orders", ValueRecord: "com.acme.Order"}}, config.Clusters[0].TopicSubjects)
+		})
+	})
+
+	t.Run("Publish templates", func(t *testing.T) {
+		t.Run("save and replace by name", func(t *testing.T) {
+			configIO := &InMemoryConfigIO{}
+			config := New(configIO)
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+
+			config.SavePublishTemplate("prd", PublishTemplate{Name: "created", Topic: "orders", Payload: "v1"})
+			config.SavePublishTemplate("prd", PublishTemplate{Name: "created", Topic: "payments", Payload: "v1"})
+			config.SavePublishTemplate("prd", PublishTemplate{Name: "shipped", Topic: "orders", Payload: "v1"})
+			config.SavePublishTemplate("prd", PublishTemplate{
+				Name:    "created",
+				Topic:   "orders",
+				Key:     "{{uuid}}",
+				Headers: []PublishTemplateHeader{{Key: "seq", Value: "{{seq}}"}},
+				Payload: "v2",
+			})
+
+			assert.Equal(t, []PublishTemplate{
+				{
+					Name:    "created",
+					Topic:   "orders",
+					Key:     "{{uuid}}",
+					Headers: []PublishTemplateHeader{{Key: "seq", Value: "{{seq}}"}},
+					Payload: "v2",
+				},
+				{Name: "shipped", Topic: "orders", Payload: "v1"},
+			}, config.Clusters[0].TopicPublishTemplates("orders"))
+			assert.Len(t, configIO.config.Clusters[0].PublishTemplates, 3)
+		})
+
+		t.Run("retained when updating an existing cluster", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: AuthMethodNone,
+			})
+			config.SavePublishTemplate("prd", PublishTemplate{Name: "created", Topic: "orders"})
+
+			config.RegisterCluster(RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9093",
+				AuthMethod: AuthMethodNone,
+			})
+
+			assert.Equal(t, []PublishTemplate{{Name: "created", Topic: "orders"}}, config.Clusters[0].PublishTemplates)
+		})
+	})
+
+	t.Run("Live buffer size", func(t *testing.T) {
+		t.Run("defaults when not configured", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+
+			assert.Equal(t, DefaultLiveBufferSize, config.LiveRecordsBufferSize())
+		})
+
+		t.Run("configured", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.LiveBufferSize = 2000
+
+			assert.Equal(t, 2000, config.LiveRecordsBufferSize())
+		})
+	})
+
+	t.Run("Lag sampling interval", func(t *testing.T) {
+		t.Run("defaults when not configured", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+
+			assert.Equal(t, DefaultLagSamplingInterval, config.LagSamplingInterval())
+		})
+
+		t.Run("configured", func(t *testing.T) {
+			config := New(&InMemoryConfigIO{})
+			config.LagSampling = 30 * time.Second
+
+			assert.Equal(t, 30*time.Second, config.LagSamplingInterval())
+		})
+	})
 }
@@ -27,7 +27,7 @@ func TestCGroupDeleter(t *testing.T) {
 		// publish some data on the topic
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
+				Key:       []byte("key"),
 				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
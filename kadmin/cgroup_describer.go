@@ -0,0 +1,62 @@
+package kadmin
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type CGroupDescriber interface {
+	DescribeCGroup(group string) tea.Msg
+}
+
+type CGroupDescribingStartedMsg struct {
+	Err   chan error
+	Group chan *ConsumerGroup
+}
+
+func (msg *CGroupDescribingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case group := <-msg.Group:
+		return CGroupDescribedMsg{group}
+	case err := <-msg.Err:
+		return CGroupDescribingErrorMsg{err}
+	}
+}
+
+type CGroupDescribedMsg struct {
+	Group *ConsumerGroup
+}
+
+type CGroupDescribingErrorMsg struct {
+	Err error
+}
+
+// DescribeCGroup describes the group with its coordinator and the partitions assigned to its members
+func (ka *SaramaKafkaAdmin) DescribeCGroup(group string) tea.Msg {
+	errChan := make(chan error)
+	groupChan := make(chan *ConsumerGroup)
+
+	go ka.doDescribeCGroup(group, groupChan, errChan)
+
+	return CGroupDescribingStartedMsg{errChan, groupChan}
+}
+
+func (ka *SaramaKafkaAdmin) doDescribeCGroup(name string, groupChan chan *ConsumerGroup, errChan chan error) {
+	MaybeIntroduceLatency()
+	descriptions, err := ka.admin.DescribeConsumerGroups([]string{name})
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if len(descriptions) == 0 {
+		errChan <- fmt.Errorf("consumer group %s not found", name)
+		return
+	}
+
+	group := toConsumerGroup(descriptions[0])
+	if coordinator, err := ka.client.Coordinator(name); err == nil {
+		group.Coordinator = &Broker{ID: coordinator.ID(), Address: coordinator.Addr()}
+	}
+	groupChan <- &group
+}
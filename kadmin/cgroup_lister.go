@@ -1,14 +1,54 @@
 package kadmin
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"slices"
+	"strings"
+
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type CGroupLister interface {
 	ListCGroups() tea.Msg
 }
 
 type ConsumerGroup struct {
-	Name    string
-	Members []GroupMember
+	Name string
+	// State is the state as reported by the coordinator, e.g. Stable, PreparingRebalance or Empty
+	State string
+	// ProtocolType is consumer for consumer groups, connect for Kafka Connect workers
+	ProtocolType string
+	// Protocol is the partition assignor of a consumer group
+	Protocol string
+	// Coordinator is the broker coordinating the group, only known once the group is described
+	Coordinator *Broker
+	Members     []GroupMember
+}
+
+const (
+	StableState      = "Stable"
+	RebalancingState = "Rebalancing"
+	EmptyState       = "Empty"
+	DeadState        = "Dead"
+	UnknownState     = "Unknown"
+)
+
+// DisplayState returns Stable, Rebalancing, Empty, Dead or Unknown, both rebalancing states are shown as Rebalancing
+func (g *ConsumerGroup) DisplayState() string {
+	switch g.State {
+	case "PreparingRebalance", "CompletingRebalance", "CompletingRebalancing":
+		return RebalancingState
+	case "", "Unknown":
+		return UnknownState
+	default:
+		return g.State
+	}
+}
+
+// TopicAssignment holds the partitions of a topic assigned to a member
+type TopicAssignment struct {
+	Topic      string
+	Partitions []int32
 }
 
 type ConsumerGroupListingStartedMsg struct {
@@ -65,17 +105,52 @@ func (ka *SaramaKafkaAdmin) doListConsumerGroups(groupsChan chan []*ConsumerGrou
 		}
 
 		for _, groupDescription := range describeConsumerGroupResponse {
-			group := groupByName[groupDescription.GroupId]
-			var groupMembers []GroupMember
-			for _, m := range groupDescription.Members {
-				member := GroupMember{}
-				member.MemberId = m.MemberId
-				member.ClientId = m.ClientId
-				member.ClientHost = m.ClientHost
-				groupMembers = append(groupMembers, member)
-			}
-			group.Members = groupMembers
+			*groupByName[groupDescription.GroupId] = toConsumerGroup(groupDescription)
 		}
 		groupsChan <- consumerGroups
 	}
 }
+
+func toConsumerGroup(description *sarama.GroupDescription) ConsumerGroup {
+	group := ConsumerGroup{
+		Name:         description.GroupId,
+		State:        description.State,
+		ProtocolType: description.ProtocolType,
+		Protocol:     description.Protocol,
+	}
+	for _, m := range description.Members {
+		member := GroupMember{}
+		member.MemberId = m.MemberId
+		member.ClientId = m.ClientId
+		member.ClientHost = m.ClientHost
+		if description.ProtocolType == "consumer" {
+			member.Assignments = toTopicAssignments(m)
+		}
+		group.Members = append(group.Members, member)
+	}
+	slices.SortFunc(group.Members, func(a, b GroupMember) int {
+		return strings.Compare(a.MemberId, b.MemberId)
+	})
+	return group
+}
+
+// toTopicAssignments decodes the assignment of a consumer group member sorted by topic and partition
+func toTopicAssignments(member *sarama.GroupMemberDescription) []TopicAssignment {
+	if len(member.MemberAssignment) == 0 {
+		return nil
+	}
+	assignment, err := member.GetMemberAssignment()
+	if err != nil || assignment == nil {
+		return nil
+	}
+	var assignments []TopicAssignment
+	for topic, partitions := range assignment.Topics {
+		partitions = slices.Clone(partitions)
+		slices.Sort(partitions)
+		assignments = append(assignments, TopicAssignment{Topic: topic, Partitions: partitions})
+	}
+	slices.SortFunc(assignments, func(a, b TopicAssignment) int {
+		return strings.Compare(a.Topic, b.Topic)
+	})
+	return assignments
+}
@@ -28,7 +28,7 @@ func TestConsumerGroups(t *testing.T) {
 
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
+				Key:       []byte("key"),
 				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
@@ -77,4 +77,57 @@ func TestConsumerGroups(t *testing.T) {
 			t.Fatal("Test timed out waiting for consumer groups")
 		}
 	})
+
+	t.Run("Describe group", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg := msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		for i := 0; i < 10; i++ {
+			ka.PublishRecord(&ProducerRecord{
+				Key:   []byte(fmt.Sprintf("key-%d", i)),
+				Value: []byte("value"),
+				Topic: topic,
+			})
+		}
+
+		groupName := "describe-" + topic
+		consumerGroup, err := sarama.NewConsumerGroupFromClient(groupName, kafkaClient())
+		if err != nil {
+			t.Fatal("Unable to create Consumer Group.", err)
+		}
+		defer consumerGroup.Close()
+		handler := testConsumer{ExpectedMsgCount: 10}
+		consumerGroup.Consume(context.WithoutCancel(context.Background()), []string{topic}, &handler)
+
+		// when
+		describingStartedMsg := ka.DescribeCGroup(groupName).(CGroupDescribingStartedMsg)
+
+		// then
+		switch msg := describingStartedMsg.AwaitCompletion().(type) {
+		case CGroupDescribedMsg:
+			assert.Equal(t, groupName, msg.Group.Name)
+			assert.NotEmpty(t, msg.Group.State)
+			assert.Equal(t, "consumer", msg.Group.ProtocolType)
+			assert.Equal(t, "range", msg.Group.Protocol)
+			assert.NotNil(t, msg.Group.Coordinator)
+			assert.Len(t, msg.Group.Members, 1)
+			assert.Equal(t, []TopicAssignment{
+				{Topic: topic, Partitions: []int32{0, 1}},
+			}, msg.Group.Members[0].Assignments)
+		case CGroupDescribingErrorMsg:
+			t.Fatal("Error while describing group", msg.Err)
+		}
+	})
 }
@@ -0,0 +1,105 @@
+package kadmin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type CGroupOffsetsDeleter interface {
+	// DeleteCGroupOffsets deletes the committed offsets of the group for the given partitions of a topic,
+	// the offsets of its other topics are left intact.
+	DeleteCGroupOffsets(group string, topic string, partitions []int32) tea.Msg
+}
+
+type CGroupOffsetsDeletionStartedMsg struct {
+	Group      string
+	Topic      string
+	Partitions []int32
+	Deleted    chan bool
+	Err        chan error
+}
+
+func (c *CGroupOffsetsDeletionStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case <-c.Deleted:
+		return CGroupOffsetsDeletedMsg{Group: c.Group, Topic: c.Topic, Partitions: c.Partitions}
+	case err := <-c.Err:
+		msg := CGroupOffsetsDeletionErrMsg{Group: c.Group, Topic: c.Topic, Err: err}
+		var partial *partialDeletionError
+		if errors.As(err, &partial) {
+			msg.Deleted, msg.Err = partial.deleted, partial.err
+		}
+		return msg
+	}
+}
+
+type CGroupOffsetsDeletedMsg struct {
+	Group      string
+	Topic      string
+	Partitions []int32
+}
+
+type CGroupOffsetsDeletionErrMsg struct {
+	Group string
+	Topic string
+	// Deleted are the partitions of which the offsets were deleted before the deletion failed
+	Deleted []int32
+	Err     error
+}
+
+// partialDeletionError is the error of a deletion that failed after the offsets of some partitions were deleted
+type partialDeletionError struct {
+	deleted []int32
+	err     error
+}
+
+func (e *partialDeletionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialDeletionError) Unwrap() error {
+	return e.err
+}
+
+func (ka *SaramaKafkaAdmin) DeleteCGroupOffsets(group string, topic string, partitions []int32) tea.Msg {
+	errChan := make(chan error)
+	deletedChan := make(chan bool)
+
+	go ka.doDeleteCGroupOffsets(group, topic, partitions, deletedChan, errChan)
+
+	return CGroupOffsetsDeletionStartedMsg{
+		Group:      group,
+		Topic:      topic,
+		Partitions: partitions,
+		Deleted:    deletedChan,
+		Err:        errChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doDeleteCGroupOffsets(
+	group string,
+	topic string,
+	partitions []int32,
+	deletedChan chan bool,
+	errChan chan error,
+) {
+	MaybeIntroduceLatency()
+	var deleted []int32
+	for _, partition := range partitions {
+		err := ka.admin.DeleteConsumerGroupOffset(group, topic, partition)
+		if errors.Is(err, sarama.ErrGroupSubscribedToTopic) {
+			err = fmt.Errorf("%s is still consuming %s, stop its members first", group, topic)
+		} else if err != nil {
+			err = fmt.Errorf("unable to delete the offset of partition %d: %w", partition, err)
+		}
+		if err != nil {
+			errChan <- &partialDeletionError{deleted: deleted, err: err}
+			return
+		}
+		deleted = append(deleted, partition)
+	}
+	deletedChan <- true
+}
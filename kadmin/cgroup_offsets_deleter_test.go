@@ -0,0 +1,78 @@
+package kadmin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCGroupOffsetsDeleter(t *testing.T) {
+	t.Run("Delete the offsets of one topic and keep the others", func(t *testing.T) {
+		// given
+		stale := topicName()
+		active := topicName()
+		for _, topic := range []string{stale, active} {
+			msg := ka.CreateTopic(TopicCreationDetails{
+				Name:              topic,
+				NumPartitions:     1,
+				Properties:        nil,
+				ReplicationFactor: 1,
+			}).(TopicCreationStartedMsg)
+
+			switch msg := msg.AwaitCompletion().(type) {
+			case TopicCreatedMsg:
+			case TopicCreationErrMsg:
+				t.Fatal("Unable to create topic", msg.Err)
+			}
+
+			for i := 0; i < 10; i++ {
+				ka.PublishRecord(&ProducerRecord{
+					Key:       []byte("key"),
+					Value:     []byte("value"),
+					Topic:     topic,
+					Partition: nil,
+				})
+			}
+		}
+
+		groupName := "offsets-deletion-test-group"
+		consumerGroup, err := sarama.NewConsumerGroupFromClient(groupName, kafkaClient())
+		if err != nil {
+			t.Fatal("Unable to create Consumer Group.", err)
+		}
+		for _, topic := range []string{stale, active} {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			handler := testConsumer{ExpectedMsgCount: 10}
+			consumerGroup.Consume(ctx, []string{topic}, &handler)
+			cancel()
+		}
+		// offsets of topics the group is still subscribed to cannot be deleted
+		if err := consumerGroup.Close(); err != nil {
+			t.Fatal("Unable to close group", err)
+		}
+
+		// when
+		msg := ka.DeleteCGroupOffsets(groupName, stale, []int32{0}).(CGroupOffsetsDeletionStartedMsg)
+
+		// then
+		switch msg := msg.AwaitCompletion().(type) {
+		case CGroupOffsetsDeletedMsg:
+			assert.Equal(t, stale, msg.Topic)
+			assert.Equal(t, []int32{0}, msg.Partitions)
+		case CGroupOffsetsDeletionErrMsg:
+			t.Fatal("Unable to delete offsets", msg.Err)
+		}
+
+		offsetListingStartedMsg := ka.ListOffsets(groupName).(OffsetListingStartedMsg)
+		select {
+		case offsets := <-offsetListingStartedMsg.Offsets:
+			assert.Len(t, offsets, 1)
+			assert.Equal(t, active, offsets[0].Topic)
+		case err := <-offsetListingStartedMsg.Err:
+			t.Fatal("Unable to list offsets", err)
+		}
+	})
+}
@@ -22,12 +22,21 @@ type Kadmin interface {
 	RecordReader
 	OffsetLister
 	CGroupLister
+	CGroupDescriber
 	CGroupDeleter
+	CGroupOffsetsDeleter
+	TimeLagReader
 	ConfigUpdater
 	TopicConfigLister
 	SraSetter
 	ClusterConfigLister
 	BrokerConfigLister
+	Closer
+}
+
+type Closer interface {
+	// Close releases the connections to the cluster, the Kadmin can't be used afterwards.
+	Close() error
 }
 
 type ConnectionDetails struct {
@@ -54,6 +63,8 @@ type GroupMember struct {
 	MemberId   string
 	ClientId   string
 	ClientHost string
+	// Assignments are the partitions assigned to the member, nil when it has none or they can't be decoded
+	Assignments []TopicAssignment
 }
 
 type KAdminErrorMsg struct {
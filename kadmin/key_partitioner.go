@@ -0,0 +1,77 @@
+package kadmin
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"github.com/IBM/sarama"
+	"github.com/burdiyan/kafkautil"
+)
+
+// KeyHasher identifies the hashing algorithm a producer used
+// to map a record key onto a partition.
+type KeyHasher string
+
+const (
+	// Murmur2KeyHasher is the default of the Java client and of the ktea publisher.
+	Murmur2KeyHasher KeyHasher = "murmur2"
+	// FNV1aKeyHasher is the default of sarama based producers.
+	FNV1aKeyHasher KeyHasher = "fnv-1a"
+	// CRC32KeyHasher is the default of librdkafka based producers.
+	CRC32KeyHasher KeyHasher = "crc32"
+)
+
+// PartitionForKey determines the partition the given key hashes to
+// for a topic with partitionCount partitions.
+func PartitionForKey(key string, partitionCount int, hasher KeyHasher) (int, error) {
+	if partitionCount <= 0 {
+		return -1, fmt.Errorf("invalid partition count %d", partitionCount)
+	}
+
+	partitioner, err := newKeyPartitioner(hasher)
+	if err != nil {
+		return -1, err
+	}
+
+	partition, err := partitioner.Partition(
+		&sarama.ProducerMessage{Key: sarama.StringEncoder(key)},
+		int32(partitionCount),
+	)
+	if err != nil {
+		return -1, err
+	}
+	return int(partition), nil
+}
+
+// newKeyPartitioner creates a partitioner hashing the key of a record as the given hasher does
+func newKeyPartitioner(hasher KeyHasher) (sarama.Partitioner, error) {
+	switch hasher {
+	case Murmur2KeyHasher, "":
+		return kafkautil.NewJVMCompatiblePartitioner(""), nil
+	case FNV1aKeyHasher:
+		return sarama.NewHashPartitioner(""), nil
+	case CRC32KeyHasher:
+		return crc32Partitioner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown key hasher %q", hasher)
+	}
+}
+
+// crc32Partitioner takes the unsigned checksum modulo the partition count as librdkafka does,
+// unlike sarama's hash partitioners which work on signed values.
+type crc32Partitioner struct{}
+
+func (crc32Partitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if message.Key == nil {
+		return sarama.NewRandomPartitioner("").Partition(message, numPartitions)
+	}
+	key, err := message.Key.Encode()
+	if err != nil {
+		return -1, err
+	}
+	return int32(crc32.ChecksumIEEE(key) % uint32(numPartitions)), nil
+}
+
+func (crc32Partitioner) RequiresConsistency() bool {
+	return true
+}
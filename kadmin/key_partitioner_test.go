@@ -0,0 +1,98 @@
+package kadmin
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionForKey(t *testing.T) {
+	t.Run("murmur2 is the default", func(t *testing.T) {
+		murmur2, err := PartitionForKey("order-123", 12, Murmur2KeyHasher)
+		assert.NoError(t, err)
+
+		def, err := PartitionForKey("order-123", 12, "")
+		assert.NoError(t, err)
+
+		assert.Equal(t, murmur2, def)
+	})
+
+	t.Run("is deterministic and within range", func(t *testing.T) {
+		for _, hasher := range []KeyHasher{Murmur2KeyHasher, FNV1aKeyHasher, CRC32KeyHasher} {
+			first, err := PartitionForKey("order-123", 7, hasher)
+			assert.NoError(t, err)
+			second, _ := PartitionForKey("order-123", 7, hasher)
+
+			assert.Equal(t, first, second)
+			assert.GreaterOrEqual(t, first, 0)
+			assert.Less(t, first, 7)
+		}
+	})
+
+	t.Run("crc32 matches librdkafka", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("key-%d", i)
+			partition, err := PartitionForKey(key, 12, CRC32KeyHasher)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int(crc32.ChecksumIEEE([]byte(key))%12), partition)
+		}
+	})
+
+	t.Run("matches the partition records are published to", func(t *testing.T) {
+		topic := topicName()
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     5,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		psm := ka.PublishRecord(&ProducerRecord{
+			Topic: topic,
+			Key:   []byte("order-123"),
+			Value: []byte("{}"),
+		})
+		select {
+		case err := <-psm.Err:
+			t.Fatal("Unable to publish", err)
+		case p := <-psm.Published:
+			assert.True(t, p)
+		}
+
+		expected, err := PartitionForKey("order-123", 5, Murmur2KeyHasher)
+		assert.NoError(t, err)
+
+		rsm := ka.ReadRecords(context.Background(), ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0, 1, 2, 3, 4},
+			StartPoint:      Beginning,
+			Limit:           1,
+		}).(*ReadingStartedMsg)
+
+		r := <-rsm.ConsumerRecord
+		assert.Equal(t, int64(expected), r.Partition)
+
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("invalid partition count", func(t *testing.T) {
+		_, err := PartitionForKey("order-123", 0, Murmur2KeyHasher)
+
+		assert.EqualError(t, err, "invalid partition count 0")
+	})
+
+	t.Run("unknown hasher", func(t *testing.T) {
+		_, err := PartitionForKey("order-123", 3, "xxhash")
+
+		assert.EqualError(t, err, `unknown key hasher "xxhash"`)
+	})
+}
@@ -36,6 +36,10 @@ func (m MockKadmin) PublishRecord(p *ProducerRecord) PublicationStartedMsg {
 	return PublicationStartedMsg{}
 }
 
+func (m MockKadmin) PublishRecords(records []*ProducerRecord, settings ProducerSettings) PublicationStartedMsg {
+	return PublicationStartedMsg{}
+}
+
 func (m MockKadmin) ReadRecords(ctx context.Context, rd ReadDetails) tea.Msg {
 	return ReadingStartedMsg{}
 }
@@ -48,10 +52,22 @@ func (m MockKadmin) ListCGroups() tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) DescribeCGroup(group string) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) DeleteCGroup(name string) tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) DeleteCGroupOffsets(group string, topic string, partitions []int32) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ReadTimeLag(group string) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) UpdateConfig(t TopicConfigToUpdate) tea.Msg {
 	return nil
 }
@@ -71,6 +87,10 @@ func (m MockKadmin) GetBrokerConfig(brokerID int32) tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) Close() error {
+	return nil
+}
+
 func NewMockKadminInstantiator() Instantiator {
 	return func(cluster *config.Cluster) (Kadmin, error) {
 		return &MockKadmin{}, nil
@@ -28,7 +28,7 @@ func TestConsumerGroupOffsets(t *testing.T) {
 
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
+				Key:       []byte("key"),
 				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
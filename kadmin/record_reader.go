@@ -3,21 +3,32 @@ package kadmin
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"ktea/config"
 	"ktea/serdes"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/charmbracelet/log"
 
 	"github.com/IBM/sarama"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 )
 
 type FilterType string
@@ -28,6 +39,8 @@ func (filterDetails *Filter) Filter(value string) bool {
 		return strings.Contains(value, filterDetails.KeySearchTerm)
 	case StartsWithFilterType:
 		return strings.HasPrefix(value, filterDetails.KeySearchTerm)
+	case ExactFilterType:
+		return value == filterDetails.KeySearchTerm
 	default:
 		return true
 	}
@@ -36,9 +49,13 @@ func (filterDetails *Filter) Filter(value string) bool {
 const (
 	ContainsFilterType   FilterType = "contains"
 	StartsWithFilterType FilterType = "starts with"
+	ExactFilterType      FilterType = "exact"
 	NoFilterType         FilterType = "none"
 )
 
+// NoLimit reads all records between the start point and the end of the selected partitions.
+const NoLimit = -1
+
 type StartPoint int64
 
 const (
@@ -83,13 +100,14 @@ type ReadingStartedMsg struct {
 	NoRecordsFound chan bool
 	Err            chan error
 	CancelFunc     context.CancelFunc
+	read           *readRanges
 }
 
 func (m *ReadingStartedMsg) AwaitRecord() tea.Msg {
 	select {
 	case record, ok := <-m.ConsumerRecord:
 		if !ok {
-			return ConsumptionEndedMsg{}
+			return ConsumptionEndedMsg{ReadRanges: m.read.get()}
 		}
 
 		return ConsumerRecordReceived{
@@ -99,6 +117,7 @@ func (m *ReadingStartedMsg) AwaitRecord() tea.Msg {
 			noRecordsFound: m.NoRecordsFound,
 			err:            m.Err,
 			cancelFunc:     m.CancelFunc,
+			read:           m.read,
 		}
 	case empty := <-m.EmptyTopic:
 		if empty {
@@ -136,6 +155,7 @@ type ConsumerRecordReceived struct {
 	noRecordsFound chan bool
 	err            chan error
 	cancelFunc     context.CancelFunc
+	read           *readRanges
 }
 
 func (m *ConsumerRecordReceived) AwaitNextRecord() tea.Msg {
@@ -143,14 +163,26 @@ func (m *ConsumerRecordReceived) AwaitNextRecord() tea.Msg {
 	select {
 	case record, ok := <-m.consumerRecord:
 		if !ok {
-			return ConsumptionEndedMsg{}
+			return ConsumptionEndedMsg{ReadRanges: m.read.get()}
 		}
 
 		records := []ConsumerRecord{record}
 		timeout := time.After(50 * time.Millisecond)
 		for {
 			select {
-			case r := <-m.consumerRecord:
+			case r, ok := <-m.consumerRecord:
+				if !ok {
+					// deliver what was batched, the next await signals the end of consumption
+					return ConsumerRecordReceived{
+						Records:        records,
+						consumerRecord: m.consumerRecord,
+						emptyTopic:     m.emptyTopic,
+						noRecordsFound: m.noRecordsFound,
+						err:            m.err,
+						cancelFunc:     m.cancelFunc,
+						read:           m.read,
+					}
+				}
 				records = append(records, r)
 			case <-timeout:
 				return ConsumerRecordReceived{
@@ -160,6 +192,7 @@ func (m *ConsumerRecordReceived) AwaitNextRecord() tea.Msg {
 					noRecordsFound: m.noRecordsFound,
 					err:            m.err,
 					cancelFunc:     m.cancelFunc,
+					read:           m.read,
 				}
 			}
 		}
@@ -185,7 +218,45 @@ type NoRecordsFound struct {
 	cancelFunc     context.CancelFunc
 }
 
-type ConsumptionEndedMsg struct{}
+type ConsumptionEndedMsg struct {
+	// ReadRanges are the inclusive offset ranges that were read per partition, including the records
+	// that did not match the filter. End is Start-1 when nothing was read, Start being where reading would begin.
+	ReadRanges map[int]OffsetRange
+}
+
+// readRanges keeps track of the offsets read per partition while records are being read.
+type readRanges struct {
+	mu     sync.Mutex
+	ranges map[int]OffsetRange
+}
+
+func newReadRanges() *readRanges {
+	return &readRanges{ranges: make(map[int]OffsetRange)}
+}
+
+func (r *readRanges) start(partition int, offset int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ranges[partition] = OffsetRange{Start: offset, End: offset - 1}
+}
+
+func (r *readRanges) read(partition int, offset int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rng, ok := r.ranges[partition]; ok && offset > rng.End {
+		rng.End = offset
+		r.ranges[partition] = rng
+	}
+}
+
+func (r *readRanges) get() map[int]OffsetRange {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.ranges)
+}
 
 type Filter struct {
 	KeyFilter       FilterType
@@ -198,8 +269,18 @@ type ReadDetails struct {
 	TopicName       string
 	PartitionToRead []int
 	StartPoint      StartPoint
-	Limit           int
-	Filter          *Filter
+	// Limit is the maximum number of records to read,
+	// Live reads never stop and use it as the number of newest records to retain.
+	Limit  int
+	Filter *Filter
+	// OffsetRanges optionally restricts reading to an explicit, inclusive, offset range per partition.
+	// Ranges are clamped to the available offsets, partitions without records in range are skipped.
+	OffsetRanges map[int]OffsetRange
+}
+
+type OffsetRange struct {
+	Start int64
+	End   int64
 }
 
 type HeaderValue struct {
@@ -216,46 +297,219 @@ func NewDefaultReadDetails(topic *ListedTopic) ReadDetails {
 	}
 }
 
+// NewKeyLookupReadDetails creates ReadDetails that read the given partition,
+// the one the key hashes to, from the beginning and only retain
+// the records with exactly the given key.
+func NewKeyLookupReadDetails(topic *ListedTopic, key string, partition int) ReadDetails {
+	return ReadDetails{
+		TopicName:       topic.Name,
+		PartitionToRead: []int{partition},
+		StartPoint:      Beginning,
+		Limit:           NoLimit,
+		Filter: &Filter{
+			KeyFilter:     ExactFilterType,
+			KeySearchTerm: key,
+			ValueFilter:   NoFilterType,
+		},
+	}
+}
+
+// NewCompactedReadDetails creates ReadDetails that read all partitions
+// of the topic from the beginning until the end.
+func NewCompactedReadDetails(topic *ListedTopic) ReadDetails {
+	return ReadDetails{
+		TopicName:       topic.Name,
+		PartitionToRead: topic.Partitions(),
+		StartPoint:      Beginning,
+		Limit:           NoLimit,
+	}
+}
+
+// PreviousPage creates ReadDetails that read up to pageSize records per partition
+// right before the given lowest already read offset of each partition.
+func (rd *ReadDetails) PreviousPage(lowestOffsets map[int]int64, pageSize int64) ReadDetails {
+	ranges := make(map[int]OffsetRange, len(lowestOffsets))
+	for partition, offset := range lowestOffsets {
+		ranges[partition] = OffsetRange{
+			Start: max(offset-pageSize, 0),
+			End:   offset - 1,
+		}
+	}
+	return rd.page(ranges)
+}
+
+// NextPage creates ReadDetails that read up to pageSize records per partition
+// right after the given highest already read offset of each partition.
+func (rd *ReadDetails) NextPage(highestOffsets map[int]int64, pageSize int64) ReadDetails {
+	ranges := make(map[int]OffsetRange, len(highestOffsets))
+	for partition, offset := range highestOffsets {
+		ranges[partition] = OffsetRange{
+			Start: offset + 1,
+			End:   offset + pageSize,
+		}
+	}
+	return rd.page(ranges)
+}
+
+func (rd *ReadDetails) page(ranges map[int]OffsetRange) ReadDetails {
+	var partitions []int
+	for partition := range ranges {
+		partitions = append(partitions, partition)
+	}
+	slices.Sort(partitions)
+	return ReadDetails{
+		TopicName:       rd.TopicName,
+		PartitionToRead: partitions,
+		StartPoint:      Beginning,
+		Limit:           NoLimit,
+		Filter:          rd.Filter,
+		OffsetRanges:    ranges,
+	}
+}
+
+func (rd *ReadDetails) IsKeyLookup() bool {
+	return rd.Filter != nil && rd.Filter.KeyFilter == ExactFilterType
+}
+
 func NewHeaderValue(data string) HeaderValue {
 	return HeaderValue{[]byte(data)}
 }
 
+// String guesses how the value is encoded, printable UTF-8 is shown as text,
+// 4 and 8 bytes as big-endian integers and any other binary value as hex.
 func (v HeaderValue) String() string {
-	if utf8.Valid(v.data) {
+	if isPrintable(v.data) {
 		return string(v.data)
 	}
 
-	if len(v.data) >= 4 {
-		var int32Val int32
-		err := binary.Read(bytes.NewReader(v.data), binary.BigEndian, &int32Val)
-		if err == nil {
-			return string(int32Val)
-		}
+	switch len(v.data) {
+	case 4:
+		return strconv.FormatInt(int64(int32(binary.BigEndian.Uint32(v.data))), 10)
+	case 8:
+		return strconv.FormatInt(int64(binary.BigEndian.Uint64(v.data)), 10)
+	default:
+		return "0x" + hex.EncodeToString(v.data)
 	}
-	if len(v.data) >= 8 {
-		var int64Val int64
-		err := binary.Read(bytes.NewReader(v.data), binary.BigEndian, &int64Val)
-		if err == nil {
-			return strconv.FormatInt(int64Val, 10)
+}
+
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return false
 		}
 	}
+	return true
+}
 
-	if len(v.data) >= 4 {
-		var float32Val float32
-		err := binary.Read(bytes.NewReader(v.data), binary.BigEndian, &float32Val)
-		if err == nil {
-			return strconv.FormatFloat(float64(float32Val), 'f', -1, 32)
+// Bytes returns the raw value as read from the topic.
+func (v HeaderValue) Bytes() []byte {
+	return v.data
+}
+
+// Decode decodes the value as the given type, integers and floats are expected to be big-endian.
+func (v HeaderValue) Decode(headerType config.HeaderType) (string, error) {
+	switch headerType {
+	case config.HeaderTypeAuto:
+		return v.String(), nil
+	case config.HeaderTypeString:
+		return string(v.data), nil
+	case config.HeaderTypeInt32:
+		if len(v.data) != 4 {
+			return "", fmt.Errorf("an int32 takes 4 bytes, got %d", len(v.data))
 		}
-	}
-	if len(v.data) >= 8 {
-		var float64Val float64
-		err := binary.Read(bytes.NewReader(v.data), binary.BigEndian, &float64Val)
-		if err == nil {
-			return strconv.FormatFloat(float64Val, 'f', -1, 64)
+		return strconv.FormatInt(int64(int32(binary.BigEndian.Uint32(v.data))), 10), nil
+	case config.HeaderTypeInt64:
+		if len(v.data) != 8 {
+			return "", fmt.Errorf("an int64 takes 8 bytes, got %d", len(v.data))
+		}
+		return strconv.FormatInt(int64(binary.BigEndian.Uint64(v.data)), 10), nil
+	case config.HeaderTypeFloat:
+		switch len(v.data) {
+		case 4:
+			return strconv.FormatFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(v.data))), 'f', -1, 32), nil
+		case 8:
+			return strconv.FormatFloat(math.Float64frombits(binary.BigEndian.Uint64(v.data)), 'f', -1, 64), nil
+		default:
+			return "", fmt.Errorf("a float takes 4 or 8 bytes, got %d", len(v.data))
+		}
+	case config.HeaderTypeUUID:
+		if len(v.data) == 16 {
+			id, err := uuid.FromBytes(v.data)
+			return id.String(), err
 		}
+		id, err := uuid.ParseBytes(v.data)
+		if err != nil {
+			return "", fmt.Errorf("not a uuid: %w", err)
+		}
+		return id.String(), nil
+	case config.HeaderTypeHex:
+		return hex.EncodeToString(v.data), nil
+	case config.HeaderTypeBase64:
+		return base64.StdEncoding.EncodeToString(v.data), nil
+	case config.HeaderTypeJSON:
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, v.data, "", "  "); err != nil {
+			return "", fmt.Errorf("not valid JSON: %w", err)
+		}
+		return indented.String(), nil
+	default:
+		return "", fmt.Errorf("unknown header type %s", headerType)
 	}
+}
 
-	return string(v.data)
+// EncodeHeaderValue encodes the value as the given type, the inverse of Decode. Integers and floats are
+// encoded big-endian, floats as 8 bytes and uuids as their 16 bytes.
+func EncodeHeaderValue(value string, headerType config.HeaderType) (HeaderValue, error) {
+	switch headerType {
+	case config.HeaderTypeAuto, config.HeaderTypeString:
+		return NewHeaderValue(value), nil
+	case config.HeaderTypeInt32:
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil {
+			return HeaderValue{}, fmt.Errorf("'%s' is not a valid int32", value)
+		}
+		return HeaderValue{binary.BigEndian.AppendUint32(nil, uint32(int32(i)))}, nil
+	case config.HeaderTypeInt64:
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return HeaderValue{}, fmt.Errorf("'%s' is not a valid int64", value)
+		}
+		return HeaderValue{binary.BigEndian.AppendUint64(nil, uint64(i))}, nil
+	case config.HeaderTypeFloat:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return HeaderValue{}, fmt.Errorf("'%s' is not a valid float", value)
+		}
+		return HeaderValue{binary.BigEndian.AppendUint64(nil, math.Float64bits(f))}, nil
+	case config.HeaderTypeUUID:
+		id, err := uuid.Parse(strings.TrimSpace(value))
+		if err != nil {
+			return HeaderValue{}, fmt.Errorf("'%s' is not a valid uuid", value)
+		}
+		return HeaderValue{id[:]}, nil
+	case config.HeaderTypeHex:
+		data, err := hex.DecodeString(strings.Join(strings.Fields(value), ""))
+		if err != nil {
+			return HeaderValue{}, errors.New("not valid hex")
+		}
+		return HeaderValue{data}, nil
+	case config.HeaderTypeBase64:
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+		if err != nil {
+			return HeaderValue{}, errors.New("not valid base64")
+		}
+		return HeaderValue{data}, nil
+	case config.HeaderTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return HeaderValue{}, errors.New("not valid JSON")
+		}
+		return NewHeaderValue(value), nil
+	default:
+		return HeaderValue{}, fmt.Errorf("unknown header type %s", headerType)
+	}
 }
 
 type Header struct {
@@ -271,14 +525,33 @@ type ConsumerRecord struct {
 	Offset    int64
 	Headers   []Header
 	Timestamp time.Time
+	// Tombstone indicates the record has a null value, marking its key as deleted
+	Tombstone bool
+	// RawKey and RawValue are the bytes as read from the topic, before deserialization
+	RawKey   []byte
+	RawValue []byte
+}
+
+// IsBinary returns true when the value is not valid UTF-8 and was not decoded, hence can only be shown as bytes.
+func (record *ConsumerRecord) IsBinary() bool {
+	return record.Payload.Format == "" && record.Payload.Schema == "" && !utf8.Valid(record.RawValue)
 }
 
 func (record *ConsumerRecord) PayloadType() string {
+	// the value was decoded from a known format
+	if record.Payload.Format != "" {
+		return record.Payload.Format
+	}
+
 	// schema is not empty, so it's Avro
 	if record.Payload.Schema != "" {
 		return "Avro"
 	}
 
+	if !utf8.Valid(record.RawValue) {
+		return "Binary"
+	}
+
 	// value is empty, so it's plain text'
 	value := strings.TrimSpace(record.Payload.Value)
 	if value == "" {
@@ -322,6 +595,7 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 		EmptyTopic:     make(chan bool, 1),
 		NoRecordsFound: make(chan bool, 1),
 		CancelFunc:     cancelFunc,
+		read:           newReadRanges(),
 	}
 
 	go ka.doReadRecords(ctx, rd, startedMsg, cancelFunc)
@@ -359,7 +633,14 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 		return
 	}
 
+	if rd.OffsetRanges != nil && !ka.anyInRange(rd, offsets) {
+		cancelFunc()
+		startedMsg.NoRecordsFound <- true
+		return
+	}
+
 	emptyTopic := true
+	deserializer := ka.deserializerFor(rd.TopicName)
 
 	log.Debug("Starting to read records",
 		"partition", rd.PartitionToRead,
@@ -369,11 +650,17 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 		// if there is no data in the partition, we don't need to read it unless live consumption is requested
 		partition := p
 		if offsets[partition].end != offsets[partition].start || rd.StartPoint == Live {
+			readingOffsets := ka.determinePartitionReadingOffsets(rd, partition, offsets[partition])
+			if rd.OffsetRanges != nil && readingOffsets.start > readingOffsets.end {
+				continue
+			}
 
 			emptyTopic = false
+			if rd.StartPoint != Live {
+				startedMsg.read.start(partition, readingOffsets.start)
+			}
 
 			wg.Go(func() {
-				readingOffsets := ka.determineReadingOffsets(rd, offsets[partition])
 				log.Debug("Reading offsets determined",
 					"topic", rd.TopicName,
 					"partition", partition,
@@ -403,6 +690,11 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 					case <-ctx.Done():
 						return
 					case msg := <-msgChan:
+						if rd.OffsetRanges != nil && msg.Offset > readingOffsets.end {
+							// the end of the range no longer exists, e.g. it was compacted or is a transaction marker
+							return
+						}
+
 						var headers []Header
 						for _, h := range msg.Headers {
 							headers = append(headers, Header{
@@ -413,12 +705,15 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 
 						var desData serdes.DesData
 						key := string(msg.Key)
-						desData, err = ka.deserialize(msg)
+						desData, err = deserialize(ctx, deserializer, rd.TopicName, msg)
 
 						if rd.Filter != nil && err == nil {
 							if !ka.matchesFilter(key, desData.Value, rd.Filter) {
-								// For MostRecent + filter, check if we've reached the end
-								if rd.StartPoint == MostRecent && msg.Offset >= readingOffsets.end {
+								startedMsg.read.read(partition, msg.Offset)
+								// For MostRecent, unlimited or ranged reads + filter, check if we've reached the end
+								if (rd.StartPoint == MostRecent || rd.Limit == NoLimit || rd.OffsetRanges != nil) &&
+									rd.StartPoint != Live &&
+									msg.Offset >= readingOffsets.end {
 									return
 								}
 								continue
@@ -433,11 +728,15 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 							Offset:    msg.Offset,
 							Headers:   headers,
 							Timestamp: msg.Timestamp,
+							Tombstone: msg.Value == nil,
+							RawKey:    msg.Key,
+							RawValue:  msg.Value,
 						}
 
-						if msgCount.Add(1) >= int64(rd.Limit) {
+						if rd.Limit != NoLimit && rd.StartPoint != Live && msgCount.Add(1) >= int64(rd.Limit) {
 							select {
 							case startedMsg.ConsumerRecord <- consumerRecord:
+								startedMsg.read.read(partition, msg.Offset)
 							case <-ctx.Done():
 							}
 							// Now that the last message is sent (or we're exiting), return.
@@ -446,11 +745,12 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 
 						select {
 						case startedMsg.ConsumerRecord <- consumerRecord:
+							startedMsg.read.read(partition, msg.Offset)
 						case <-ctx.Done():
 							return
 						}
 
-						if msg.Offset == readingOffsets.end && rd.StartPoint != Live {
+						if msg.Offset >= readingOffsets.end && rd.StartPoint != Live {
 							// For MostRecent + filter, exit when we've consumed all available records
 							if rd.StartPoint == MostRecent && rd.Filter != nil {
 								// Continue only if we haven't reached the newest offset yet
@@ -463,6 +763,9 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 					}
 				}
 			})
+		} else {
+			// an empty partition is continued from its end
+			startedMsg.read.start(partition, offsets[partition].end)
 		}
 	}
 
@@ -472,11 +775,32 @@ func (ka *SaramaKafkaAdmin) doReadRecords(
 
 	go func() {
 		wg.Wait()
+		// stops the plugin that is kept running for the duration of the read
+		if closer, ok := deserializer.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Error("Unable to close deserializer", "err", err)
+			}
+		}
 		time.Sleep(50 * time.Millisecond)
 		startedMsg.shutdown()
 	}()
 }
 
+// anyInRange returns true when at least one of the requested offset ranges contains records.
+func (ka *SaramaKafkaAdmin) anyInRange(rd ReadDetails, offsets map[int]offsets) bool {
+	for _, partition := range rd.PartitionToRead {
+		o := offsets[partition]
+		if o.start == o.end {
+			continue
+		}
+		ro := ka.determinePartitionReadingOffsets(rd, partition, o)
+		if ro.start <= ro.end {
+			return true
+		}
+	}
+	return false
+}
+
 func noRecordsFound(offsets map[int]offsets) bool {
 	for _, off := range offsets {
 		// -1 indicates that no records exist for the requested offsets
@@ -503,11 +827,54 @@ func (ka *SaramaKafkaAdmin) matchesFilter(key, value string, filterDetails *Filt
 	return true
 }
 
-func (ka *SaramaKafkaAdmin) deserialize(
+// deserialize hands the whole record to deserializers that take more than the value into account
+func deserialize(
+	ctx context.Context,
+	deserializer serdes.Deserializer,
+	topic string,
 	msg *sarama.ConsumerMessage,
 ) (serdes.DesData, error) {
-	deserializer := serdes.NewAvroDeserializer(ka.sra)
-	return deserializer.Deserialize(msg.Value)
+	recordDeserializer, ok := deserializer.(serdes.RecordDeserializer)
+	if !ok {
+		return deserializer.Deserialize(msg.Value)
+	}
+
+	record := serdes.Record{
+		Topic:     topic,
+		Partition: int64(msg.Partition),
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+	}
+	for _, h := range msg.Headers {
+		record.Headers = append(record.Headers, serdes.RecordHeader{Key: string(h.Key), Value: h.Value})
+	}
+	return recordDeserializer.DeserializeRecord(ctx, record)
+}
+
+// deserializerFor creates the deserializer for the records of the given topic
+func (ka *SaramaKafkaAdmin) deserializerFor(topic string) serdes.Deserializer {
+	var options []serdes.AvroOption
+	if ka.avroCodecs != nil {
+		options = append(options, serdes.WithCodecCache(ka.avroCodecs))
+	}
+
+	if ka.cluster == nil {
+		return serdes.WithFormatDetection(serdes.NewAvroDeserializer(ka.sra, options...))
+	}
+
+	if p := ka.cluster.DeserializerPlugin(topic); p != nil {
+		return serdes.NewPluginDeserializer(p.Command, p.Args, p.Timeout)
+	}
+
+	if s := ka.cluster.LocalProtobufSchema(topic); s != nil {
+		return serdes.NewProtobufDeserializer(s.Path, s.MessageType)
+	}
+
+	if s := ka.cluster.LocalAvroSchema(topic); s != nil {
+		options = append(options, serdes.WithLocalSchemaFile(s.Path, s.Name))
+	}
+	return serdes.WithFormatDetection(serdes.NewAvroDeserializer(ka.sra, options...))
 }
 
 type readingOffsets struct {
@@ -515,6 +882,22 @@ type readingOffsets struct {
 	end   int64
 }
 
+// determinePartitionReadingOffsets determines the offsets to read for the given partition,
+// an explicitly requested offset range takes precedence.
+func (ka *SaramaKafkaAdmin) determinePartitionReadingOffsets(
+	rd ReadDetails,
+	partition int,
+	offsets offsets,
+) readingOffsets {
+	if r, ok := rd.OffsetRanges[partition]; ok {
+		return readingOffsets{
+			start: max(r.Start, offsets.start),
+			end:   min(r.End, offsets.newest()),
+		}
+	}
+	return ka.determineReadingOffsets(rd, offsets)
+}
+
 func (ka *SaramaKafkaAdmin) determineReadingOffsets(
 	rd ReadDetails,
 	offsets offsets,
@@ -527,6 +910,13 @@ func (ka *SaramaKafkaAdmin) determineReadingOffsets(
 		}
 	}
 
+	if rd.Limit == NoLimit {
+		return readingOffsets{
+			start: offsets.start,
+			end:   offsets.newest(),
+		}
+	}
+
 	var startOffset int64
 	var endOffset int64
 	numberOfRecordsPerPart := int64(float64(int64(rd.Limit)) / float64(len(rd.PartitionToRead)))
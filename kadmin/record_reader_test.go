@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/slices"
+	"ktea/config"
 	"ktea/serdes"
 	"strconv"
 	"testing"
@@ -68,7 +69,7 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
+						Key:   []byte(strconv.Itoa(i)),
 						Value: []byte("{\"id\":\"123\"}"),
 					})
 
@@ -134,7 +135,7 @@ func TestReadRecords(t *testing.T) {
 					partition := i % 4
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic:     topic,
-						Key:       strconv.Itoa(i),
+						Key:       []byte(strconv.Itoa(i)),
 						Partition: &partition,
 						Value:     []byte("{\"id\":\"123\"}"),
 					})
@@ -200,7 +201,7 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
+						Key:   []byte(strconv.Itoa(i)),
 						Value: []byte("{\"id\":\"123\"}"),
 					})
 
@@ -262,7 +263,7 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
+						Key:   []byte(strconv.Itoa(i)),
 						Value: []byte("{\"id\":\"123\"}"),
 					})
 
@@ -305,6 +306,124 @@ func TestReadRecords(t *testing.T) {
 		})
 	})
 
+	t.Run("Reports the read ranges once consumption ended", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		partition := 0
+		for i := 0; i < 10; i++ {
+			psm := ka.PublishRecord(&ProducerRecord{
+				Topic:     topic,
+				Key:       []byte(strconv.Itoa(i)),
+				Value:     []byte("{\"id\":\"123\"}"),
+				Partition: &partition,
+			})
+
+			select {
+			case err := <-psm.Err:
+				t.Fatal("Unable to publish", err)
+			case p := <-psm.Published:
+				assert.True(t, p)
+			}
+		}
+
+		// when
+		rsm := ka.ReadRecords(context.Background(), ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0, 1},
+			StartPoint:      Beginning,
+			Limit:           NoLimit,
+		}).(*ReadingStartedMsg)
+
+		awaited := rsm.AwaitRecord()
+		for {
+			received, ok := awaited.(ConsumerRecordReceived)
+			if !ok {
+				break
+			}
+			awaited = received.AwaitNextRecord()
+		}
+		ended := awaited.(ConsumptionEndedMsg)
+
+		// then
+		assert.Equal(t, map[int]OffsetRange{
+			0: {Start: 0, End: 9},
+			1: {Start: 0, End: -1},
+		}, ended.ReadRanges)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Does not read past a range that ends in a gap", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		// the commit marker of the transaction takes offset 2
+		psm := ka.PublishRecords([]*ProducerRecord{
+			{Topic: topic, Key: []byte("0"), Value: []byte("{}")},
+			{Topic: topic, Key: []byte("1"), Value: []byte("{}")},
+		}, ProducerSettings{Transactional: true})
+		assert.IsType(t, PublicationSucceeded{}, psm.AwaitCompletion())
+		published := ka.PublishRecord(&ProducerRecord{Topic: topic, Key: []byte("3"), Value: []byte("{}")})
+		select {
+		case err := <-published.Err:
+			t.Fatal("Unable to publish", err)
+		case <-published.Published:
+		}
+
+		// when
+		rsm := ka.ReadRecords(context.Background(), ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0},
+			StartPoint:      Beginning,
+			Limit:           NoLimit,
+			OffsetRanges:    map[int]OffsetRange{0: {Start: 1, End: 2}},
+		}).(*ReadingStartedMsg)
+
+		var keys []string
+		awaited := rsm.AwaitRecord()
+		for {
+			received, ok := awaited.(ConsumerRecordReceived)
+			if !ok {
+				break
+			}
+			for _, r := range received.Records {
+				keys = append(keys, r.Key)
+			}
+			awaited = received.AwaitNextRecord()
+		}
+
+		// then
+		assert.IsType(t, ConsumptionEndedMsg{}, awaited)
+		assert.Equal(t, []string{"1"}, keys)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
 	t.Run("Read today's records", func(t *testing.T) {
 		t.Run("when there are records from previous days", func(t *testing.T) {
 			topic := topicName()
@@ -337,7 +456,7 @@ func TestReadRecords(t *testing.T) {
 					)
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic:     topic,
-						Key:       strconv.Itoa(i),
+						Key:       []byte(strconv.Itoa(i)),
 						Value:     []byte("{\"id\":\"123\"}"),
 						Timestamp: endOfToday.Add(time.Duration(-55+i) * time.Hour),
 					})
@@ -401,7 +520,7 @@ func TestReadRecords(t *testing.T) {
 					twentyFourHoursAgo := time.Now().Add(-25 * time.Hour)
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic:     topic,
-						Key:       strconv.Itoa(i),
+						Key:       []byte(strconv.Itoa(i)),
 						Value:     []byte("{\"id\":\"123\"}"),
 						Timestamp: twentyFourHoursAgo.Add(time.Duration(-i) * time.Hour),
 					})
@@ -455,7 +574,7 @@ func TestReadRecords(t *testing.T) {
 	//		for i := 0; i < 10; i++ {
 	//			psm := ka.PublishRecord(&ProducerRecord{
 	//				Topic: topic,
-	//				Key:   strconv.Itoa(i),
+	//				Key:   []byte(strconv.Itoa(i)),
 	//				Value: []byte("{\"id\":\"123\"}"),
 	//			})
 	//
@@ -481,7 +600,7 @@ func TestReadRecords(t *testing.T) {
 	//			for i := 10; i < 20; i++ {
 	//				psm := ka.PublishRecord(&ProducerRecord{
 	//					Topic: topic,
-	//					Key:   strconv.Itoa(i),
+	//					Key:   []byte(strconv.Itoa(i)),
 	//					Value: []byte("{\"id\":\"123\"}"),
 	//				})
 	//
@@ -542,7 +661,7 @@ func TestReadRecords(t *testing.T) {
 					for i := 0; i < 55; i++ {
 						psm := ka.PublishRecord(&ProducerRecord{
 							Topic: topic,
-							Key:   strconv.Itoa(i),
+							Key:   []byte(strconv.Itoa(i)),
 							Value: []byte("{\"id\":\"3\"}"),
 						})
 
@@ -611,7 +730,7 @@ func TestReadRecords(t *testing.T) {
 					for i := 0; i < 30; i++ {
 						psm := ka.PublishRecord(&ProducerRecord{
 							Topic: topic,
-							Key:   strconv.Itoa(i),
+							Key:   []byte(strconv.Itoa(i)),
 							Value: []byte("{\"id\":\"test\"}"),
 						})
 
@@ -680,7 +799,7 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
+						Key:   []byte(strconv.Itoa(i)),
 						Value: []byte("{\"id\":\"3\"}"),
 					})
 
@@ -867,6 +986,78 @@ func TestDetermineStartingOffset(t *testing.T) {
 	}
 }
 
+func TestDeterminePartitionReadingOffsets(t *testing.T) {
+	rd := ReadDetails{
+		TopicName:       "test-topic",
+		PartitionToRead: []int{0, 1},
+		StartPoint:      Beginning,
+		Limit:           NoLimit,
+		OffsetRanges: map[int]OffsetRange{
+			0: {Start: 10, End: 19},
+			1: {Start: 0, End: 500},
+		},
+	}
+
+	t.Run("reads the requested range", func(t *testing.T) {
+		ro := ka.(*SaramaKafkaAdmin).determinePartitionReadingOffsets(rd, 0, offsets{start: 0, end: 100})
+
+		assert.Equal(t, readingOffsets{start: 10, end: 19}, ro)
+	})
+
+	t.Run("clamps the requested range to the available offsets", func(t *testing.T) {
+		ro := ka.(*SaramaKafkaAdmin).determinePartitionReadingOffsets(rd, 1, offsets{start: 5, end: 100})
+
+		assert.Equal(t, readingOffsets{start: 5, end: 99}, ro)
+	})
+
+	t.Run("range before the available offsets is empty", func(t *testing.T) {
+		ro := ka.(*SaramaKafkaAdmin).determinePartitionReadingOffsets(rd, 0, offsets{start: 50, end: 100})
+
+		assert.Greater(t, ro.start, ro.end)
+	})
+}
+
+func TestReadDetailsPaging(t *testing.T) {
+	rd := ReadDetails{
+		TopicName:       "test-topic",
+		PartitionToRead: []int{0, 1, 2},
+		StartPoint:      MostRecent,
+		Limit:           50,
+		Filter:          &Filter{KeyFilter: ContainsFilterType, KeySearchTerm: "abc"},
+	}
+
+	t.Run("previous page", func(t *testing.T) {
+		page := rd.PreviousPage(map[int]int64{0: 100, 2: 10}, 25)
+
+		assert.Equal(t, ReadDetails{
+			TopicName:       "test-topic",
+			PartitionToRead: []int{0, 2},
+			StartPoint:      Beginning,
+			Limit:           NoLimit,
+			Filter:          rd.Filter,
+			OffsetRanges: map[int]OffsetRange{
+				0: {Start: 75, End: 99},
+				2: {Start: 0, End: 9},
+			},
+		}, page)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		page := rd.NextPage(map[int]int64{1: 100}, 25)
+
+		assert.Equal(t, ReadDetails{
+			TopicName:       "test-topic",
+			PartitionToRead: []int{1},
+			StartPoint:      Beginning,
+			Limit:           NoLimit,
+			Filter:          rd.Filter,
+			OffsetRanges: map[int]OffsetRange{
+				1: {Start: 101, End: 125},
+			},
+		}, page)
+	})
+}
+
 func TestConsumerRecordPayloadType(t *testing.T) {
 	t.Run("returns Avro when schema is present", func(t *testing.T) {
 		record := &ConsumerRecord{
@@ -955,4 +1146,141 @@ func TestConsumerRecordPayloadType(t *testing.T) {
 
 		assert.Equal(t, "Plain Text", record.PayloadType())
 	})
+
+	t.Run("returns Binary when the raw value is not valid UTF-8", func(t *testing.T) {
+		value := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff}
+		record := &ConsumerRecord{
+			Payload:  serdes.DesData{Value: string(value)},
+			RawValue: value,
+		}
+
+		assert.Equal(t, "Binary", record.PayloadType())
+		assert.True(t, record.IsBinary())
+	})
+
+	t.Run("returns the format a binary value was decoded from", func(t *testing.T) {
+		value := []byte{0x81, 0xa4, 'n', 'a', 'm', 'e', 0xa4, 'J', 'o', 'h', 'n'}
+		record := &ConsumerRecord{
+			Payload:  serdes.DesData{Value: `{"name":"John"}`, Format: "MessagePack"},
+			RawValue: value,
+		}
+
+		assert.Equal(t, "MessagePack", record.PayloadType())
+		assert.False(t, record.IsBinary())
+	})
+}
+
+func TestHeaderValue(t *testing.T) {
+	int32Bytes := []byte{0x00, 0x00, 0x01, 0x2c}
+	int64Bytes := []byte{0x00, 0x00, 0x01, 0x8e, 0x23, 0xf1, 0x4c, 0x00}
+	uuidBytes := []byte{0x0b, 0x3c, 0x8b, 0x0a, 0x8c, 0x7e, 0x4a, 0x55, 0x9b, 0x4b, 0x0e, 0x4f, 0x6b, 0x1a, 0x2c, 0x3d}
+
+	t.Run("String", func(t *testing.T) {
+		t.Run("valid UTF-8 as text", func(t *testing.T) {
+			assert.Equal(t, "v1", NewHeaderValue("v1").String())
+		})
+
+		t.Run("4 bytes as int32", func(t *testing.T) {
+			assert.Equal(t, "-2", HeaderValue{[]byte{0xff, 0xff, 0xff, 0xfe}}.String())
+		})
+
+		t.Run("valid UTF-8 with control characters as int32", func(t *testing.T) {
+			assert.Equal(t, "300", HeaderValue{int32Bytes}.String())
+		})
+
+		t.Run("8 bytes as int64", func(t *testing.T) {
+			assert.Equal(t, "1710000000000", HeaderValue{int64Bytes}.String())
+		})
+
+		t.Run("other binary values as hex", func(t *testing.T) {
+			assert.Equal(t, "0x01ff02", HeaderValue{[]byte{0x01, 0xff, 0x02}}.String())
+		})
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		for _, tc := range []struct {
+			name       string
+			data       []byte
+			headerType config.HeaderType
+			expected   string
+		}{
+			{"string", []byte("v1"), config.HeaderTypeString, "v1"},
+			{"int32", int32Bytes, config.HeaderTypeInt32, "300"},
+			{"int64", int64Bytes, config.HeaderTypeInt64, "1710000000000"},
+			{"float32", []byte{0x40, 0x49, 0x0f, 0xdb}, config.HeaderTypeFloat, "3.1415927"},
+			{"float64", []byte{0x40, 0x09, 0x21, 0xfb, 0x54, 0x44, 0x2d, 0x18}, config.HeaderTypeFloat, "3.141592653589793"},
+			{"binary uuid", uuidBytes, config.HeaderTypeUUID, "0b3c8b0a-8c7e-4a55-9b4b-0e4f6b1a2c3d"},
+			{"textual uuid", []byte("0B3C8B0A-8C7E-4A55-9B4B-0E4F6B1A2C3D"), config.HeaderTypeUUID, "0b3c8b0a-8c7e-4a55-9b4b-0e4f6b1a2c3d"},
+			{"hex", int32Bytes, config.HeaderTypeHex, "0000012c"},
+			{"base64", int32Bytes, config.HeaderTypeBase64, "AAABLA=="},
+			{"json", []byte(`{"a":1}`), config.HeaderTypeJSON, "{\n  \"a\": 1\n}"},
+			{"auto", int32Bytes, config.HeaderTypeAuto, "300"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				decoded, err := HeaderValue{tc.data}.Decode(tc.headerType)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, decoded)
+			})
+		}
+
+		t.Run("fails on values that do not match the type", func(t *testing.T) {
+			_, err := HeaderValue{[]byte{0x01, 0x02}}.Decode(config.HeaderTypeInt32)
+			assert.EqualError(t, err, "an int32 takes 4 bytes, got 2")
+
+			_, err = HeaderValue{int32Bytes}.Decode(config.HeaderTypeInt64)
+			assert.EqualError(t, err, "an int64 takes 8 bytes, got 4")
+
+			_, err = HeaderValue{[]byte{0x01}}.Decode(config.HeaderTypeFloat)
+			assert.EqualError(t, err, "a float takes 4 or 8 bytes, got 1")
+
+			_, err = NewHeaderValue("not-a-uuid").Decode(config.HeaderTypeUUID)
+			assert.ErrorContains(t, err, "not a uuid")
+
+			_, err = NewHeaderValue("{").Decode(config.HeaderTypeJSON)
+			assert.ErrorContains(t, err, "not valid JSON")
+		})
+	})
+
+	t.Run("EncodeHeaderValue", func(t *testing.T) {
+		for _, tc := range []struct {
+			name       string
+			value      string
+			headerType config.HeaderType
+			expected   []byte
+		}{
+			{"string", "v1", config.HeaderTypeString, []byte("v1")},
+			{"int32", "300", config.HeaderTypeInt32, int32Bytes},
+			{"int64", "1710000000000", config.HeaderTypeInt64, int64Bytes},
+			{"float", "3.141592653589793", config.HeaderTypeFloat, []byte{0x40, 0x09, 0x21, 0xfb, 0x54, 0x44, 0x2d, 0x18}},
+			{"uuid", "0B3C8B0A-8C7E-4A55-9B4B-0E4F6B1A2C3D", config.HeaderTypeUUID, uuidBytes},
+			{"hex", "0000 012c", config.HeaderTypeHex, int32Bytes},
+			{"base64", "AAABLA==", config.HeaderTypeBase64, int32Bytes},
+			{"json", `{"a":1}`, config.HeaderTypeJSON, []byte(`{"a":1}`)},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				encoded, err := EncodeHeaderValue(tc.value, tc.headerType)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, encoded.Bytes())
+			})
+		}
+
+		t.Run("fails on values that do not match the type", func(t *testing.T) {
+			_, err := EncodeHeaderValue("3000000000", config.HeaderTypeInt32)
+			assert.EqualError(t, err, "'3000000000' is not a valid int32")
+
+			_, err = EncodeHeaderValue("abc", config.HeaderTypeInt64)
+			assert.EqualError(t, err, "'abc' is not a valid int64")
+
+			_, err = EncodeHeaderValue("not-a-uuid", config.HeaderTypeUUID)
+			assert.EqualError(t, err, "'not-a-uuid' is not a valid uuid")
+
+			_, err = EncodeHeaderValue("xyz", config.HeaderTypeHex)
+			assert.EqualError(t, err, "not valid hex")
+
+			_, err = EncodeHeaderValue("{", config.HeaderTypeJSON)
+			assert.EqualError(t, err, "not valid JSON")
+		})
+	})
 }
@@ -5,14 +5,16 @@ import (
 	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"ktea/config"
+	"ktea/serdes"
 	"ktea/sradmin"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
-	"github.com/burdiyan/kafkautil"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 	"github.com/xdg-go/scram"
@@ -44,7 +46,14 @@ type SaramaKafkaAdmin struct {
 	addrs    []string
 	config   *sarama.Config
 	producer sarama.SyncProducer
-	sra      sradmin.Client
+	// producers holds the dedicated producers of non-default settings
+	producers   map[ProducerSettings]sarama.SyncProducer
+	producersMu sync.Mutex
+	sra         sradmin.Client
+	// cluster configures the local schemas used to deserialize records
+	cluster *config.Cluster
+	// avroCodecs caches the Avro schemas of sra for as long as the connection lives
+	avroCodecs *serdes.AvroCodecCache
 }
 
 type ConnCheckStartedMsg struct {
@@ -72,7 +81,7 @@ func ToSaramaCfg(cluster *config.Cluster) *sarama.Config {
 	cfg := sarama.NewConfig()
 	cfg.Producer.Return.Successes = true
 	cfg.Producer.RequiredAcks = sarama.WaitForAll
-	cfg.Producer.Partitioner = kafkautil.NewJVMCompatiblePartitioner
+	cfg.Producer.Partitioner = newRecordPartitioner(Murmur2KeyHasher)
 	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
 
 	if cluster.TLSConfig.Enable {
@@ -158,9 +167,14 @@ func NewSaramaKadmin(cluster *config.Cluster) (Kadmin, error) {
 		addrs:    cluster.BootstrapServers,
 		producer: producer,
 		config:   cfg,
+		cluster:  cluster,
 	}, nil
 }
 
+func (ka *SaramaKafkaAdmin) Close() error {
+	return errors.Join(ka.closeProducers(), ka.producer.Close(), ka.admin.Close(), ka.client.Close())
+}
+
 func CheckKafkaConnectivity(cluster *config.Cluster) tea.Msg {
 	connectedChan := make(chan bool)
 	errChan := make(chan error)
@@ -1,6 +1,7 @@
 package kadmin
 
 import (
+	"ktea/serdes"
 	"ktea/sradmin"
 )
 
@@ -10,4 +11,5 @@ type SraSetter interface {
 
 func (ka *SaramaKafkaAdmin) SetSra(sra sradmin.Client) {
 	ka.sra = sra
+	ka.avroCodecs = serdes.NewAvroCodecCache(sra)
 }
@@ -0,0 +1,166 @@
+package kadmin
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timestampReadTimeout is how long to wait for a record when reading its timestamp,
+// the last record before the high watermark might be a transaction marker that is never returned.
+const timestampReadTimeout = 5 * time.Second
+
+// timeLagReadConcurrency is the number of partitions of which the time lag is read at once
+const timeLagReadConcurrency = 8
+
+type TimeLagReader interface {
+	// ReadTimeLag reads, for every partition the group committed an offset on, the timestamps of the record at
+	// the committed offset and of the last record before the high watermark.
+	ReadTimeLag(group string) tea.Msg
+}
+
+// PartitionTimeLag is how far a group is behind on a partition in time rather than in offsets
+type PartitionTimeLag struct {
+	Topic     string
+	Partition int32
+	// Committed is the timestamp of the record at the committed offset, the next one the group consumes.
+	Committed time.Time
+	// Latest is the timestamp of the last record before the high watermark.
+	Latest time.Time
+	// Lag is the time between both records, zero when caught up and ErrorValue when either could not be read.
+	Lag time.Duration
+}
+
+func (l PartitionTimeLag) Known() bool {
+	return l.Lag != time.Duration(ErrorValue)
+}
+
+type TimeLagReadingStartedMsg struct {
+	Group    string
+	TimeLags chan []PartitionTimeLag
+	Err      chan error
+}
+
+func (msg *TimeLagReadingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case timeLags := <-msg.TimeLags:
+		return TimeLagReadMsg{Group: msg.Group, TimeLags: timeLags}
+	case err := <-msg.Err:
+		return TimeLagReadingErrMsg{Group: msg.Group, Err: err}
+	}
+}
+
+type TimeLagReadMsg struct {
+	Group    string
+	TimeLags []PartitionTimeLag
+}
+
+type TimeLagReadingErrMsg struct {
+	Group string
+	Err   error
+}
+
+func (ka *SaramaKafkaAdmin) ReadTimeLag(group string) tea.Msg {
+	errChan := make(chan error)
+	timeLagsChan := make(chan []PartitionTimeLag)
+
+	go ka.doReadTimeLag(group, timeLagsChan, errChan)
+
+	return TimeLagReadingStartedMsg{
+		Group:    group,
+		TimeLags: timeLagsChan,
+		Err:      errChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doReadTimeLag(group string, timeLagsChan chan []PartitionTimeLag, errChan chan error) {
+	MaybeIntroduceLatency()
+	listResult, err := ka.admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(ka.client)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	defer consumer.Close()
+
+	var (
+		timeLags []PartitionTimeLag
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		limit    = make(chan struct{}, timeLagReadConcurrency)
+	)
+	for t, m := range listResult.Blocks {
+		for p, block := range m {
+			limit <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-limit }()
+				timeLag := ka.readPartitionTimeLag(consumer, t, p, block.Offset)
+				mu.Lock()
+				timeLags = append(timeLags, timeLag)
+				mu.Unlock()
+			})
+		}
+	}
+	wg.Wait()
+
+	timeLagsChan <- timeLags
+}
+
+func (ka *SaramaKafkaAdmin) readPartitionTimeLag(
+	consumer sarama.Consumer,
+	topic string,
+	partition int32,
+	offset int64,
+) PartitionTimeLag {
+	timeLag := PartitionTimeLag{Topic: topic, Partition: partition, Lag: time.Duration(ErrorValue)}
+
+	hwm, err := ka.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	// without a committed offset there is nothing to compare with
+	if err != nil || offset < 0 {
+		return timeLag
+	}
+	if offset >= hwm {
+		timeLag.Lag = 0
+		return timeLag
+	}
+
+	if timeLag.Committed, err = readTimestamp(consumer, topic, partition, offset); err != nil {
+		return timeLag
+	}
+	if timeLag.Latest, err = readTimestamp(consumer, topic, partition, hwm-1); err != nil {
+		return timeLag
+	}
+	timeLag.Lag = max(0, timeLag.Latest.Sub(timeLag.Committed))
+	return timeLag
+}
+
+// readTimestamp reads the timestamp of the first record at or after the offset,
+// or of the oldest record when the offset has been removed by retention.
+func readTimestamp(consumer sarama.Consumer, topic string, partition int32, offset int64) (time.Time, error) {
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+	if errors.Is(err, sarama.ErrOffsetOutOfRange) {
+		partitionConsumer, err = consumer.ConsumePartition(topic, partition, sarama.OffsetOldest)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer partitionConsumer.Close()
+
+	select {
+	case msg := <-partitionConsumer.Messages():
+		return msg.Timestamp, nil
+	case err := <-partitionConsumer.Errors():
+		return time.Time{}, err
+	case <-time.After(timestampReadTimeout):
+		return time.Time{}, fmt.Errorf("no record found at offset %d of %s-%d", offset, topic, partition)
+	}
+}
@@ -0,0 +1,67 @@
+package kadmin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeLagReader(t *testing.T) {
+	t.Run("Read the time between the committed offset and the high watermark", func(t *testing.T) {
+		// given
+		topic := topicName()
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     1,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg := msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		produced := time.Now().Truncate(time.Millisecond).Add(-time.Hour)
+		for i := 0; i < 10; i++ {
+			ka.PublishRecord(&ProducerRecord{
+				Key:       []byte("key"),
+				Value:     []byte("value"),
+				Topic:     topic,
+				Partition: nil,
+				Timestamp: produced.Add(time.Duration(i) * time.Minute),
+			})
+		}
+
+		groupName := "time-lag-test-group"
+		offsetManager, err := sarama.NewOffsetManagerFromClient(groupName, kafkaClient())
+		if err != nil {
+			t.Fatal("Unable to create offset manager", err)
+		}
+		partitionOffsetManager, err := offsetManager.ManagePartition(topic, 0)
+		if err != nil {
+			t.Fatal("Unable to manage partition", err)
+		}
+		partitionOffsetManager.MarkOffset(3, "")
+		offsetManager.Commit()
+		partitionOffsetManager.Close()
+		offsetManager.Close()
+
+		// when
+		startedMsg := ka.ReadTimeLag(groupName).(TimeLagReadingStartedMsg)
+
+		// then
+		switch msg := startedMsg.AwaitCompletion().(type) {
+		case TimeLagReadMsg:
+			assert.Len(t, msg.TimeLags, 1)
+			assert.WithinDuration(t, produced.Add(3*time.Minute), msg.TimeLags[0].Committed, time.Millisecond)
+			assert.WithinDuration(t, produced.Add(9*time.Minute), msg.TimeLags[0].Latest, time.Millisecond)
+			assert.Equal(t, 6*time.Minute, msg.TimeLags[0].Lag)
+		case TimeLagReadingErrMsg:
+			t.Fatal("Unable to read time lag", msg.Err)
+		}
+	})
+}
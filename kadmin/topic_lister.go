@@ -1,6 +1,7 @@
 package kadmin
 
 import (
+	"strings"
 	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -48,6 +49,11 @@ type ListedTopic struct {
 	Cleanup        string
 }
 
+// IsCompacted returns true when the cleanup policy of the topic includes compaction.
+func (t *ListedTopic) IsCompacted() bool {
+	return strings.Contains(t.Cleanup, "compact")
+}
+
 func (t *ListedTopic) Partitions() []int {
 	partToConsume := make([]int, t.PartitionCount)
 	for i := range t.PartitionCount {
@@ -1,25 +1,182 @@
 package kadmin
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/IBM/sarama"
-	"github.com/burdiyan/kafkautil"
 	tea "github.com/charmbracelet/bubbletea"
-	"time"
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
 )
 
 type Publisher interface {
 	PublishRecord(p *ProducerRecord) PublicationStartedMsg
+	// PublishRecords publishes the records in order with a producer of the given settings, stopping at the first failure.
+	// All records or none are published when the settings are transactional.
+	PublishRecords(records []*ProducerRecord, settings ProducerSettings) PublicationStartedMsg
 }
 
 type ProducerRecord struct {
-	Key       string
+	// Key is nil for a null key, as opposed to an empty one
+	Key []byte
+	// Value is nil for a tombstone, as opposed to an empty one
 	Value     []byte
 	Topic     string
 	Partition *int
-	Headers   map[string]string
+	// Headers are published in order, a key may occur more than once
+	Headers []Header
+	// Timestamp is the time the record is produced at when zero
 	Timestamp time.Time
 }
 
+// Acks is the number of acknowledgements the leader must have received before a record is considered published
+type Acks string
+
+const (
+	AcksAll    Acks = "all"
+	AcksLeader Acks = "leader"
+	AcksNone   Acks = "none"
+)
+
+type Compression string
+
+const (
+	NoCompression     Compression = "none"
+	GzipCompression   Compression = "gzip"
+	SnappyCompression Compression = "snappy"
+	LZ4Compression    Compression = "lz4"
+	ZstdCompression   Compression = "zstd"
+)
+
+// ProducerSettings configures the producer records are published with, the zero value uses the defaults.
+type ProducerSettings struct {
+	Acks        Acks
+	Compression Compression
+	Idempotent  bool
+	// Partitioner hashes the keys of the records that are not published to a specific partition
+	Partitioner KeyHasher
+	// Transactional publishes the records atomically in a single transaction, the producer is idempotent as well
+	Transactional bool
+}
+
+var DefaultProducerSettings = ProducerSettings{
+	Acks:        AcksAll,
+	Compression: NoCompression,
+	Partitioner: Murmur2KeyHasher,
+}
+
+// Normalized returns the settings with the defaults filled in and the producer idempotent when transactional
+func (s ProducerSettings) Normalized() ProducerSettings {
+	if s.Acks == "" {
+		s.Acks = DefaultProducerSettings.Acks
+	}
+	if s.Compression == "" {
+		s.Compression = DefaultProducerSettings.Compression
+	}
+	if s.Partitioner == "" {
+		s.Partitioner = DefaultProducerSettings.Partitioner
+	}
+	if s.Transactional {
+		s.Idempotent = true
+	}
+	return s
+}
+
+func (s ProducerSettings) Validate() error {
+	s = s.Normalized()
+	if s.Idempotent && s.Acks != AcksAll {
+		if s.Transactional {
+			return errors.New("transactions require acks all")
+		}
+		return errors.New("an idempotent producer requires acks all")
+	}
+	if _, err := s.requiredAcks(); err != nil {
+		return err
+	}
+	if _, err := s.compressionCodec(); err != nil {
+		return err
+	}
+	_, err := newKeyPartitioner(s.Partitioner)
+	return err
+}
+
+func (s ProducerSettings) requiredAcks() (sarama.RequiredAcks, error) {
+	switch s.Acks {
+	case AcksAll:
+		return sarama.WaitForAll, nil
+	case AcksLeader:
+		return sarama.WaitForLocal, nil
+	case AcksNone:
+		return sarama.NoResponse, nil
+	}
+	return 0, fmt.Errorf("unknown acks %q", s.Acks)
+}
+
+func (s ProducerSettings) compressionCodec() (sarama.CompressionCodec, error) {
+	switch s.Compression {
+	case NoCompression:
+		return sarama.CompressionNone, nil
+	case GzipCompression:
+		return sarama.CompressionGZIP, nil
+	case SnappyCompression:
+		return sarama.CompressionSnappy, nil
+	case LZ4Compression:
+		return sarama.CompressionLZ4, nil
+	case ZstdCompression:
+		return sarama.CompressionZSTD, nil
+	}
+	return 0, fmt.Errorf("unknown compression %q", s.Compression)
+}
+
+// apply configures the producer of cfg with the normalized settings
+func (s ProducerSettings) apply(cfg *sarama.Config) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	cfg.Producer.RequiredAcks, _ = s.requiredAcks()
+	cfg.Producer.Compression, _ = s.compressionCodec()
+	cfg.Producer.Partitioner = newRecordPartitioner(s.Partitioner)
+	if s.Idempotent {
+		cfg.Producer.Idempotent = true
+		cfg.Net.MaxOpenRequests = 1
+	}
+	if s.Transactional {
+		cfg.Producer.Transaction.ID = "ktea-" + uuid.NewString()
+	}
+	return nil
+}
+
+// manualPartition marks a message that is published to a specific partition
+type manualPartition struct{}
+
+// recordPartitioner publishes records to their partition when specified and hashes their key otherwise
+type recordPartitioner struct {
+	keyPartitioner sarama.Partitioner
+}
+
+func newRecordPartitioner(hasher KeyHasher) sarama.PartitionerConstructor {
+	return func(topic string) sarama.Partitioner {
+		keyPartitioner, err := newKeyPartitioner(hasher)
+		if err != nil {
+			keyPartitioner, _ = newKeyPartitioner(Murmur2KeyHasher)
+		}
+		return &recordPartitioner{keyPartitioner: keyPartitioner}
+	}
+}
+
+func (p *recordPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if _, ok := message.Metadata.(manualPartition); ok {
+		return message.Partition, nil
+	}
+	return p.keyPartitioner.Partition(message, numPartitions)
+}
+
+func (p *recordPartitioner) RequiresConsistency() bool {
+	return true
+}
+
 type PublicationStartedMsg struct {
 	Err       chan error
 	Published chan bool
@@ -42,10 +199,14 @@ func (p *PublicationStartedMsg) AwaitCompletion() tea.Msg {
 }
 
 func (ka *SaramaKafkaAdmin) PublishRecord(p *ProducerRecord) PublicationStartedMsg {
+	return ka.PublishRecords([]*ProducerRecord{p}, DefaultProducerSettings)
+}
+
+func (ka *SaramaKafkaAdmin) PublishRecords(records []*ProducerRecord, settings ProducerSettings) PublicationStartedMsg {
 	errChan := make(chan error)
 	published := make(chan bool)
 
-	go ka.doPublishRecord(p, errChan, published)
+	go ka.doPublishRecords(records, settings, errChan, published)
 
 	return PublicationStartedMsg{
 		Err:       errChan,
@@ -53,39 +214,151 @@ func (ka *SaramaKafkaAdmin) PublishRecord(p *ProducerRecord) PublicationStartedM
 	}
 }
 
-func (ka *SaramaKafkaAdmin) doPublishRecord(
-	p *ProducerRecord,
+func (ka *SaramaKafkaAdmin) doPublishRecords(
+	records []*ProducerRecord,
+	settings ProducerSettings,
 	errChan chan error,
 	published chan bool,
 ) {
 	MaybeIntroduceLatency()
-	var partition int32
-	if p.Partition == nil {
-		ka.config.Producer.Partitioner = kafkautil.NewJVMCompatiblePartitioner
+
+	producer, err := ka.producerFor(settings)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	if settings.Transactional {
+		err = publishInTransaction(producer, records)
 	} else {
-		partition = int32(*p.Partition)
-		ka.config.Producer.Partitioner = sarama.NewManualPartitioner
+		for _, record := range records {
+			if _, _, err = producer.SendMessage(toProducerMessage(record)); err != nil {
+				break
+			}
+		}
 	}
+	if err != nil {
+		ka.discardFailedProducer(settings, producer)
+		errChan <- err
+		return
+	}
+	published <- true
+}
 
+func publishInTransaction(producer sarama.SyncProducer, records []*ProducerRecord) error {
+	if err := producer.BeginTxn(); err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	for _, record := range records {
+		if _, _, err := producer.SendMessage(toProducerMessage(record)); err != nil {
+			if abortErr := producer.AbortTxn(); abortErr != nil {
+				return fmt.Errorf("%w, unable to abort transaction: %w", err, abortErr)
+			}
+			return fmt.Errorf("transaction aborted: %w", err)
+		}
+	}
+	if err := producer.CommitTxn(); err != nil {
+		if abortErr := producer.AbortTxn(); abortErr != nil {
+			return fmt.Errorf("unable to commit transaction: %w, unable to abort transaction: %w", err, abortErr)
+		}
+		return fmt.Errorf("transaction aborted, unable to commit: %w", err)
+	}
+	return nil
+}
+
+// producerFor returns the producer of the settings, creating a dedicated one when they differ from the defaults.
+// Producers are kept until the connection is closed or their transaction failed beyond recovery.
+func (ka *SaramaKafkaAdmin) producerFor(settings ProducerSettings) (sarama.SyncProducer, error) {
+	settings = settings.Normalized()
+	if settings == DefaultProducerSettings {
+		return ka.producer, nil
+	}
+
+	ka.producersMu.Lock()
+	defer ka.producersMu.Unlock()
+
+	if producer, ok := ka.producers[settings]; ok {
+		return producer, nil
+	}
+
+	cfg := ToSaramaCfg(ka.cluster)
+	if err := settings.apply(cfg); err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducer(ka.addrs, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ka.producers == nil {
+		ka.producers = make(map[ProducerSettings]sarama.SyncProducer)
+	}
+	ka.producers[settings] = producer
+	return producer, nil
+}
+
+// discardFailedProducer closes a dedicated producer that can't be used anymore, as its transaction could not be aborted
+// or failed fatally, so the next publication creates a new one.
+func (ka *SaramaKafkaAdmin) discardFailedProducer(settings ProducerSettings, producer sarama.SyncProducer) {
+	if producer == ka.producer {
+		return
+	}
+	failed := sarama.ProducerTxnFlagInError | sarama.ProducerTxnFlagAbortableError | sarama.ProducerTxnFlagFatalError
+	if producer.TxnStatus()&failed == 0 {
+		return
+	}
+
+	ka.producersMu.Lock()
+	if ka.producers[settings.Normalized()] == producer {
+		delete(ka.producers, settings.Normalized())
+	}
+	ka.producersMu.Unlock()
+
+	if err := producer.Close(); err != nil {
+		log.Error("Unable to close failed producer", "err", err)
+	}
+}
+
+// closeProducers closes the dedicated producers
+func (ka *SaramaKafkaAdmin) closeProducers() error {
+	ka.producersMu.Lock()
+	defer ka.producersMu.Unlock()
+
+	var errs []error
+	for settings, producer := range ka.producers {
+		errs = append(errs, producer.Close())
+		delete(ka.producers, settings)
+	}
+	return errors.Join(errs...)
+}
+
+func toProducerMessage(p *ProducerRecord) *sarama.ProducerMessage {
 	var headers []sarama.RecordHeader
-	for key, value := range p.Headers {
+	for _, header := range p.Headers {
 		headers = append(headers, sarama.RecordHeader{
-			Key:   []byte(key),
-			Value: []byte(value),
+			Key:   []byte(header.Key),
+			Value: header.Value.Bytes(),
 		})
 	}
 
-	_, _, err := ka.producer.SendMessage(&sarama.ProducerMessage{
+	// a nil encoder is produced as null
+	var key, value sarama.Encoder
+	if p.Key != nil {
+		key = sarama.ByteEncoder(p.Key)
+	}
+	if p.Value != nil {
+		value = sarama.ByteEncoder(p.Value)
+	}
+
+	msg := &sarama.ProducerMessage{
 		Topic:     p.Topic,
-		Key:       sarama.StringEncoder(p.Key),
-		Value:     sarama.ByteEncoder(p.Value),
-		Partition: partition,
+		Key:       key,
+		Value:     value,
 		Headers:   headers,
 		Timestamp: p.Timestamp,
-	})
-	if err != nil {
-		errChan <- err
-		return
 	}
-	published <- true
+	if p.Partition != nil {
+		msg.Partition = int32(*p.Partition)
+		msg.Metadata = manualPartition{}
+	}
+	return msg
 }
@@ -2,8 +2,10 @@ package kadmin
 
 import (
 	"context"
+	"github.com/IBM/sarama"
 	kgo "github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -24,7 +26,7 @@ func TestPublish(t *testing.T) {
 		assert.EventuallyWithT(t, func(c *assert.CollectT) {
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic: topic,
-				Key:   "123",
+				Key:   []byte("123"),
 				Value: []byte("{\"id\":\"123\"}"),
 			})
 
@@ -68,11 +70,12 @@ func TestPublish(t *testing.T) {
 		assert.EventuallyWithT(t, func(c *assert.CollectT) {
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic: topic,
-				Key:   "123",
+				Key:   []byte("123"),
 				Value: []byte("{\"id\":\"123\"}"),
-				Headers: map[string]string{
-					"id":   "123",
-					"user": "456",
+				Headers: []Header{
+					{"id", NewHeaderValue("123")},
+					{"user", NewHeaderValue("456")},
+					{"id", NewHeaderValue("789")},
 				},
 			})
 
@@ -106,12 +109,11 @@ func TestPublish(t *testing.T) {
 
 	assertRecords:
 		assert.Equal(t, "{\"id\":\"123\"}", receivedRecords[0].Payload.Value)
-		assert.Contains(t, receivedRecords[0].Headers, Header{
-			"id", NewHeaderValue("123"),
-		})
-		assert.Contains(t, receivedRecords[0].Headers, Header{
-			"user", NewHeaderValue("456"),
-		})
+		assert.Equal(t, []Header{
+			{"id", NewHeaderValue("123")},
+			{"user", NewHeaderValue("456")},
+			{"id", NewHeaderValue("789")},
+		}, receivedRecords[0].Headers)
 
 		// clean up
 		cancel()
@@ -138,7 +140,7 @@ func TestPublish(t *testing.T) {
 			var partition = 2
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic:     topic,
-				Key:       "123",
+				Key:       []byte("123"),
 				Value:     []byte("{\"id\":\"123\"}"),
 				Partition: &partition,
 			})
@@ -165,4 +167,248 @@ func TestPublish(t *testing.T) {
 		// clean up
 		ka.DeleteTopic(topic)
 	})
+
+	t.Run("Publish a tombstone with a null key", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+			},
+		})
+
+		// when
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			psm := ka.PublishRecord(&ProducerRecord{
+				Topic: topic,
+			})
+
+			select {
+			case err := <-psm.Err:
+				t.Fatal(c, "Unable to publish", err)
+			case p := <-psm.Published:
+				assert.True(c, p)
+			}
+		}, 10*time.Second, 10*time.Millisecond)
+
+		// then
+		ctx, cancel := context.WithCancel(context.Background())
+		rsm := ka.ReadRecords(ctx, ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0},
+			StartPoint:      Beginning,
+			Limit:           1,
+		}).(*ReadingStartedMsg)
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			record := <-rsm.ConsumerRecord
+			assert.True(c, record.Tombstone)
+			assert.Nil(c, record.RawKey)
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// clean up
+		cancel()
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Publish an empty value", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+			},
+		})
+
+		// when
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			psm := ka.PublishRecord(&ProducerRecord{
+				Topic: topic,
+				Key:   []byte{},
+				Value: []byte{},
+			})
+
+			select {
+			case err := <-psm.Err:
+				t.Fatal(c, "Unable to publish", err)
+			case p := <-psm.Published:
+				assert.True(c, p)
+			}
+		}, 10*time.Second, 10*time.Millisecond)
+
+		// then
+		ctx, cancel := context.WithCancel(context.Background())
+		rsm := ka.ReadRecords(ctx, ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0},
+			StartPoint:      Beginning,
+			Limit:           1,
+		}).(*ReadingStartedMsg)
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			record := <-rsm.ConsumerRecord
+			assert.False(c, record.Tombstone)
+			assert.Equal(c, "", record.Payload.Value)
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// clean up
+		cancel()
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Publish compressed to a specific partition with a dedicated producer", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     3,
+				ReplicationFactor: 1,
+			},
+		})
+		partition := 2
+		timestamp := time.Date(2024, time.March, 1, 13, 14, 15, 0, time.UTC)
+
+		// when
+		psm := ka.PublishRecords([]*ProducerRecord{{
+			Topic:     topic,
+			Key:       []byte("123"),
+			Value:     []byte("{\"id\":\"123\"}"),
+			Partition: &partition,
+			Timestamp: timestamp,
+		}}, ProducerSettings{Acks: AcksLeader, Compression: ZstdCompression, Partitioner: CRC32KeyHasher})
+		assert.IsType(t, PublicationSucceeded{}, psm.AwaitCompletion())
+
+		// then
+		ctx, cancel := context.WithCancel(context.Background())
+		rsm := ka.ReadRecords(ctx, ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{partition},
+			StartPoint:      Beginning,
+			Limit:           1,
+		}).(*ReadingStartedMsg)
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			record := <-rsm.ConsumerRecord
+			assert.Equal(c, "{\"id\":\"123\"}", record.Payload.Value)
+			assert.Equal(c, timestamp, record.Timestamp.UTC())
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// clean up
+		cancel()
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Publish in a transaction", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+			},
+		})
+
+		// when
+		var records []*ProducerRecord
+		for i := 0; i < 3; i++ {
+			records = append(records, &ProducerRecord{
+				Topic: topic,
+				Key:   []byte(strconv.Itoa(i)),
+				Value: []byte("{}"),
+			})
+		}
+		psm := ka.PublishRecords(records, ProducerSettings{Transactional: true})
+		assert.IsType(t, PublicationSucceeded{}, psm.AwaitCompletion())
+
+		// then
+		ctx, cancel := context.WithCancel(context.Background())
+		rsm := ka.ReadRecords(ctx, ReadDetails{
+			TopicName:       topic,
+			PartitionToRead: []int{0},
+			StartPoint:      Beginning,
+			Limit:           3,
+		}).(*ReadingStartedMsg)
+		var keys []string
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			keys = append(keys, (<-rsm.ConsumerRecord).Key)
+			assert.Equal(c, []string{"0", "1", "2"}, keys)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// clean up
+		cancel()
+		ka.DeleteTopic(topic)
+	})
+}
+
+func TestProducerSettings(t *testing.T) {
+	t.Run("zero value uses the defaults", func(t *testing.T) {
+		assert.Equal(t, DefaultProducerSettings, ProducerSettings{}.Normalized())
+	})
+
+	t.Run("transactions are idempotent", func(t *testing.T) {
+		assert.True(t, ProducerSettings{Transactional: true}.Normalized().Idempotent)
+	})
+
+	t.Run("idempotence requires acks all", func(t *testing.T) {
+		assert.EqualError(t, ProducerSettings{Idempotent: true, Acks: AcksLeader}.Validate(),
+			"an idempotent producer requires acks all")
+		assert.EqualError(t, ProducerSettings{Transactional: true, Acks: AcksNone}.Validate(),
+			"transactions require acks all")
+	})
+
+	t.Run("unknown compression", func(t *testing.T) {
+		assert.EqualError(t, ProducerSettings{Compression: "brotli"}.Validate(), `unknown compression "brotli"`)
+	})
+
+	t.Run("records with a partition are not hashed", func(t *testing.T) {
+		partitioner := newRecordPartitioner(Murmur2KeyHasher)("topic")
+		partition := 4
+
+		p, err := partitioner.Partition(toProducerMessage(&ProducerRecord{Key: []byte("k"), Partition: &partition}), 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), p)
+	})
+}
+
+// failedProducer is a dedicated producer of which the transaction failed fatally
+type failedProducer struct {
+	sarama.SyncProducer
+	closed bool
+}
+
+func (p *failedProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagInError | sarama.ProducerTxnFlagFatalError
+}
+
+func (p *failedProducer) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestDedicatedProducers(t *testing.T) {
+	settings := ProducerSettings{Transactional: true}.Normalized()
+
+	t.Run("a failed producer is closed and no longer used", func(t *testing.T) {
+		producer := &failedProducer{}
+		admin := &SaramaKafkaAdmin{producers: map[ProducerSettings]sarama.SyncProducer{settings: producer}}
+
+		admin.discardFailedProducer(settings, producer)
+
+		assert.True(t, producer.closed)
+		assert.NotContains(t, admin.producers, settings)
+	})
+
+	t.Run("all are closed with the connection", func(t *testing.T) {
+		producer := &failedProducer{}
+		admin := &SaramaKafkaAdmin{producers: map[ProducerSettings]sarama.SyncProducer{settings: producer}}
+
+		assert.NoError(t, admin.closeProducers())
+
+		assert.True(t, producer.closed)
+		assert.Empty(t, admin.producers)
+	})
 }
@@ -12,80 +12,190 @@ import (
 
 type GoAvroDeserializer struct {
 	sra sradmin.Client
+	// codecs holds the compiled schemas by schema id
+	codecs *AvroCodecCache
+	// localSchema is used to decode records without a schema registry
+	localSchema *localSchema
+}
+
+type localSchema struct {
+	schema *avroSchema
+	err    error
+}
+
+type AvroOption func(d *GoAvroDeserializer)
+
+// WithLocalSchemaFile decodes records that are not registered in a schema registry with a schema read from disk,
+// see LoadAvroSchema.
+func WithLocalSchemaFile(path string, name string) AvroOption {
+	return func(d *GoAvroDeserializer) {
+		schema, err := LoadAvroSchema(path, name)
+		if err != nil {
+			d.localSchema = &localSchema{err: fmt.Errorf("unable to load local avro schema: %w", err)}
+			return
+		}
+		compiled, err := newAvroSchema(schema)
+		if err != nil {
+			d.localSchema = &localSchema{err: fmt.Errorf("invalid local avro schema: %w", err)}
+			return
+		}
+		d.localSchema = &localSchema{schema: compiled}
+	}
+}
+
+// WithCodecCache shares the compiled schemas with other deserializers using the same cache,
+// the cache must be backed by the same schema registry as the deserializer.
+func WithCodecCache(codecs *AvroCodecCache) AvroOption {
+	return func(d *GoAvroDeserializer) {
+		d.codecs = codecs
+	}
 }
 
 type DesData struct {
 	Value  string
 	Schema string
+	// Format is the format Value was decoded from, e.g. Avro or MessagePack.
+	// It is empty when the data is used as is.
+	Format string
+	// SchemaId is the schema registry id of Schema, zero when the schema did not come from a registry
+	SchemaId int
+	// avro is the value Value was rendered from, it renders the Avro JSON encoding on demand
+	avro *avroValue
+}
+
+// avroValue is a decoded Avro value along with the schema it was written with
+type avroValue struct {
+	schema *avroSchema
+	native any
+}
+
+// HasAvroJsonUnions reports whether the data was decoded with a schema containing unions,
+// which are rendered differently in the Avro JSON encoding.
+func (d DesData) HasAvroJsonUnions() bool {
+	return d.avro != nil && d.avro.schema.unions
+}
+
+// AvroJsonValue renders Value with its unions in the Avro JSON encoding, it is Value when there are none
+func (d DesData) AvroJsonValue() (string, error) {
+	if !d.HasAvroJsonUnions() {
+		return d.Value, nil
+	}
+	avroJson, err := json.Marshal(d.avro.schema.renderNative(d.avro.native, AvroJsonUnions))
+	if err != nil {
+		return "", err
+	}
+	return string(avroJson), nil
 }
 
 var ErrNoSchemaRegistry = errors.New("no schema registry configured")
 
+// ocfMagic prefixes Avro Object Container Files
+var ocfMagic = []byte{'O', 'b', 'j', 0x01}
+
 func (d *GoAvroDeserializer) Deserialize(data []byte) (DesData, error) {
 	if len(data) == 0 {
 		return DesData{}, nil
 	}
 
+	if bytes.HasPrefix(data, ocfMagic) {
+		return deserializeOCF(data)
+	}
+
 	schemaId, isAvro := isAvroWithSchemaID(data)
 
+	if d.localSchema != nil && (!isAvro || d.sra == nil) {
+		return d.deserializeSchemaless(data, isAvro)
+	}
+
 	if isAvro {
 
 		if d.sra == nil {
 			return DesData{}, fmt.Errorf("avro deserialization failed: %w", ErrNoSchemaRegistry)
 		}
 
-		if schema, err := d.getSchema(schemaId); err != nil {
+		schema, err := d.codecs.get(schemaId)
+		if err != nil {
+			return DesData{}, err
+		}
+
+		deserData, _, err := schema.codec.NativeFromBinary(data[5:])
+		if err != nil {
 			return DesData{}, err
-		} else {
-			var codec *goavro.Codec
-			if codec, err = goavro.NewCodec(schema.Value); err != nil {
-				return DesData{}, err
-			}
-
-			deserData, _, err := codec.NativeFromBinary(data[5:])
-			if err != nil {
-				return DesData{}, err
-			}
-
-			jsonData, err := json.Marshal(deserData)
-			if err != nil {
-				return DesData{}, err
-			}
-			return DesData{string(jsonData), schema.Value}, nil
 		}
+
+		desData, err := schema.render(deserData)
+		desData.SchemaId = schemaId
+		return desData, err
 	} else {
 		return DesData{Value: string(data)}, nil
 	}
 
 }
 
-func (d *GoAvroDeserializer) getSchema(schemaId int) (sradmin.Schema, error) {
-	var schema sradmin.Schema
+// deserializeOCF decodes an Object Container File with the schema embedded in its header,
+// a file holding multiple records is rendered as array.
+func deserializeOCF(data []byte) (DesData, error) {
+	reader, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return DesData{}, fmt.Errorf("avro object container deserialization failed: %w", err)
+	}
 
-	switch msg := d.sra.GetSchemaById(schemaId).(type) {
+	var records []any
+	for reader.Scan() {
+		record, err := reader.Read()
+		if err != nil {
+			return DesData{}, fmt.Errorf("avro object container deserialization failed: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := reader.Err(); err != nil {
+		return DesData{}, fmt.Errorf("avro object container deserialization failed: %w", err)
+	}
 
-	case sradmin.GettingSchemaByIdMsg:
-		{
-			switch msg := msg.AwaitCompletion().(type) {
+	codec := reader.Codec()
+	if len(records) == 1 {
+		return parseAvroSchema(codec.Schema(), codec).render(records[0])
+	}
 
-			case sradmin.SchemaByIdReceived:
-				{
-					schema = msg.Schema
-				}
-			case sradmin.FailedToFetchLatestSchemaBySubject:
-				{
-					return sradmin.Schema{}, msg.Err
-				}
-			}
-		}
+	desData, err := parseAvroSchema(`{"type":"array","items":`+codec.Schema()+`}`, codec).render(records)
+	desData.Schema = codec.Schema()
+	return desData, err
+}
 
-	case sradmin.SchemaByIdReceived:
-		{
-			schema = msg.Schema
-		}
+// deserializeSchemaless decodes binary Avro without any framing using the local schema,
+// records that are framed with a schema id despite the absence of a registry are decoded as well.
+func (d *GoAvroDeserializer) deserializeSchemaless(data []byte, hasSchemaId bool) (DesData, error) {
+	if d.localSchema.err != nil {
+		return DesData{}, d.localSchema.err
+	}
+
+	codec := d.localSchema.schema.codec
+	native, remaining, err := codec.NativeFromBinary(data)
+	if (err != nil || len(remaining) > 0) && hasSchemaId {
+		native, remaining, err = codec.NativeFromBinary(data[5:])
+	}
+	if err != nil {
+		return DesData{}, fmt.Errorf("avro deserialization with local schema failed: %w", err)
+	}
+	if len(remaining) > 0 {
+		return DesData{}, fmt.Errorf("avro deserialization with local schema failed: %d trailing bytes", len(remaining))
 	}
 
-	return schema, nil
+	return d.localSchema.schema.render(native)
+}
+
+// render renders the native value with plain unions, the Avro JSON encoding is only rendered when asked for
+func (s *avroSchema) render(native any) (DesData, error) {
+	plain, err := json.Marshal(s.renderNative(native, PlainJsonUnions))
+	if err != nil {
+		return DesData{}, err
+	}
+	return DesData{
+		Value:  string(plain),
+		Schema: s.schema,
+		Format: "Avro",
+		avro:   &avroValue{schema: s, native: native},
+	}, nil
 }
 
 func isAvroWithSchemaID(data []byte) (int, bool) {
@@ -108,6 +218,13 @@ func isAvroWithSchemaID(data []byte) (int, bool) {
 	return int(schemaId), true
 }
 
-func NewAvroDeserializer(sra sradmin.Client) Deserializer {
-	return &GoAvroDeserializer{sra: sra}
+func NewAvroDeserializer(sra sradmin.Client, options ...AvroOption) Deserializer {
+	d := &GoAvroDeserializer{sra: sra}
+	for _, option := range options {
+		option(d)
+	}
+	if d.codecs == nil {
+		d.codecs = NewAvroCodecCache(sra)
+	}
+	return d
 }
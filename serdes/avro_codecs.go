@@ -0,0 +1,110 @@
+package serdes
+
+import (
+	"encoding/json"
+	"fmt"
+	"ktea/sradmin"
+	"strconv"
+	"sync"
+
+	"github.com/linkedin/goavro/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// avroSchema is a compiled schema, shared by all records written with it
+type avroSchema struct {
+	schema string
+	codec  *goavro.Codec
+	parsed any
+	// named holds the record, enum and fixed schemas by their full name
+	named map[string]map[string]any
+	// unions is whether the schema contains any union
+	unions bool
+}
+
+func newAvroSchema(schema string) (*avroSchema, error) {
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, err
+	}
+	return parseAvroSchema(schema, codec), nil
+}
+
+func parseAvroSchema(schema string, codec *goavro.Codec) *avroSchema {
+	var parsed any
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		// a bare primitive schema such as string is not quoted
+		parsed = schema
+	}
+	collector := &avroRenderer{named: make(map[string]map[string]any)}
+	collector.collect(parsed, "")
+	return &avroSchema{schema: schema, codec: codec, parsed: parsed, named: collector.named, unions: collector.hasUnions}
+}
+
+// AvroCodecCache compiles the schema of every schema id once and shares the codec between
+// all deserializers using it. Concurrent lookups of the same unknown schema id result in a
+// single call to the schema registry. It is safe for concurrent use.
+type AvroCodecCache struct {
+	sra     sradmin.Client
+	mu      sync.RWMutex
+	schemas map[int]*avroSchema
+	lookups singleflight.Group
+}
+
+// get returns the compiled schema of the given id, fetching it from the schema registry when unknown.
+// Failed lookups are not cached, they are retried on the next record.
+func (c *AvroCodecCache) get(schemaId int) (*avroSchema, error) {
+	c.mu.RLock()
+	schema, ok := c.schemas[schemaId]
+	c.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	compiled, err, _ := c.lookups.Do(strconv.Itoa(schemaId), func() (any, error) {
+		c.mu.RLock()
+		schema, ok := c.schemas[schemaId]
+		c.mu.RUnlock()
+		if ok {
+			return schema, nil
+		}
+
+		registered, err := fetchSchema(c.sra, schemaId)
+		if err != nil {
+			return nil, err
+		}
+		schema, err = newAvroSchema(registered.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.schemas[schemaId] = schema
+		c.mu.Unlock()
+		return schema, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compiled.(*avroSchema), nil
+}
+
+func fetchSchema(sra sradmin.Client, schemaId int) (sradmin.Schema, error) {
+	switch msg := sra.GetSchemaById(schemaId).(type) {
+	case sradmin.GettingSchemaByIdMsg:
+		switch msg := msg.AwaitCompletion().(type) {
+		case sradmin.SchemaByIdReceived:
+			return msg.Schema, nil
+		case sradmin.FailedToFetchLatestSchemaBySubject:
+			return sradmin.Schema{}, msg.Err
+		}
+	case sradmin.SchemaByIdReceived:
+		return msg.Schema, nil
+	}
+	return sradmin.Schema{}, fmt.Errorf("unable to fetch schema %d", schemaId)
+}
+
+// NewAvroCodecCache creates an empty cache backed by the given schema registry.
+func NewAvroCodecCache(sra sradmin.Client) *AvroCodecCache {
+	return &AvroCodecCache{sra: sra, schemas: make(map[int]*avroSchema)}
+}
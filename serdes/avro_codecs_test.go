@@ -0,0 +1,160 @@
+package serdes
+
+import (
+	"errors"
+	"ktea/sradmin"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const personSchema = `{
+	"type": "record",
+	"namespace": "ktea.test",
+	"name": "Person",
+	"fields": [
+		{"name": "Name", "type": "string"},
+		{"name": "Age", "type": "int"}
+	]
+}`
+
+func encodePerson(t testing.TB, schemaId byte) []byte {
+	codec, err := goavro.NewCodec(personSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := codec.BinaryFromNative([]byte{0x00, 0x00, 0x00, 0x00, schemaId}, map[string]any{
+		"Name": "John",
+		"Age":  21,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// countingSra answers schema lookups asynchronously, as the schema registry client does
+func countingSra(lookups *atomic.Int32, release <-chan struct{}) *sradmin.MockSrAdmin {
+	sraMock := sradmin.NewMock()
+	sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+		lookups.Add(1)
+		schemaChan := make(chan sradmin.Schema)
+		go func() {
+			if release != nil {
+				<-release
+			}
+			schemaChan <- sradmin.Schema{Value: personSchema}
+		}()
+		return sradmin.GettingSchemaByIdMsg{SchemaChan: schemaChan, ErrChan: make(chan error)}
+	}
+	return sraMock
+}
+
+func TestAvroCodecCache(t *testing.T) {
+	t.Run("schema is fetched once per schema id", func(t *testing.T) {
+		var lookups atomic.Int32
+		sra := countingSra(&lookups, nil)
+		codecs := NewAvroCodecCache(sra)
+		for i := 0; i < 3; i++ {
+			res, err := NewAvroDeserializer(sra, WithCodecCache(codecs)).Deserialize(encodePerson(t, 1))
+			assert.NoError(t, err)
+			assert.Equal(t, `{"Age":21,"Name":"John"}`, res.Value)
+		}
+		_, err := NewAvroDeserializer(sra, WithCodecCache(codecs)).Deserialize(encodePerson(t, 2))
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(2), lookups.Load())
+	})
+
+	t.Run("concurrent lookups of an unknown schema id are collapsed", func(t *testing.T) {
+		var lookups atomic.Int32
+		release := make(chan struct{})
+		sra := countingSra(&lookups, release)
+		codecs := NewAvroCodecCache(sra)
+		data := encodePerson(t, 1)
+
+		var started, done sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			started.Add(1)
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				deserializer := NewAvroDeserializer(sra, WithCodecCache(codecs))
+				started.Done()
+				res, err := deserializer.Deserialize(data)
+				assert.NoError(t, err)
+				assert.Equal(t, `{"Age":21,"Name":"John"}`, res.Value)
+			}()
+		}
+		started.Wait()
+		close(release)
+		done.Wait()
+
+		assert.Equal(t, int32(1), lookups.Load())
+	})
+
+	t.Run("failed lookups are retried", func(t *testing.T) {
+		var lookups int
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			lookups++
+			if lookups == 1 {
+				errChan := make(chan error, 1)
+				errChan <- errors.New("registry unavailable")
+				return sradmin.GettingSchemaByIdMsg{SchemaChan: make(chan sradmin.Schema), ErrChan: errChan}
+			}
+			return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: personSchema}}
+		}
+		deserializer := NewAvroDeserializer(sraMock)
+
+		_, err := deserializer.Deserialize(encodePerson(t, 1))
+		assert.EqualError(t, err, "registry unavailable")
+
+		res, err := deserializer.Deserialize(encodePerson(t, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"Age":21,"Name":"John"}`, res.Value)
+		assert.Equal(t, 2, lookups)
+	})
+}
+
+func BenchmarkAvroDeserializer(b *testing.B) {
+	var lookups atomic.Int32
+	sra := countingSra(&lookups, nil)
+	codecs := NewAvroCodecCache(sra)
+	data := encodePerson(b, 1)
+
+	b.Run("cached codec", func(b *testing.B) {
+		deserializer := NewAvroDeserializer(sra, WithCodecCache(codecs))
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := deserializer.Deserialize(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached codec across goroutines", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			deserializer := NewAvroDeserializer(sra, WithCodecCache(codecs))
+			for pb.Next() {
+				if _, err := deserializer.Deserialize(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+
+	b.Run("codec compiled per record", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := NewAvroDeserializer(sra).Deserialize(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package serdes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadAvroSchema reads an Avro schema from disk.
+//
+// When path is a file, it is read as is. When path is a directory, the schema is read from the file
+// named after its fully qualified name, e.g. com.acme.Order.avsc, and the named types it references
+// are inlined from the other schemas in that directory.
+func LoadAvroSchema(path string, name string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("a schema name is required to pick a schema from directory %s", path)
+	}
+
+	loader := schemaDirLoader{dir: path, defined: make(map[string]bool)}
+	schema, err := loader.load(name)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+type schemaDirLoader struct {
+	dir string
+	// defined holds the full names of the named types defined so far
+	defined map[string]bool
+}
+
+func (l *schemaDirLoader) load(fullName string) (any, error) {
+	content, err := os.ReadFile(filepath.Join(l.dir, fullName+".avsc"))
+	if err != nil {
+		return nil, err
+	}
+
+	var schema any
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", fullName, err)
+	}
+	return l.resolve(schema, "")
+}
+
+// resolve inlines the referenced named types that are not defined yet
+func (l *schemaDirLoader) resolve(schema any, namespace string) (any, error) {
+	var err error
+
+	switch s := schema.(type) {
+	case string:
+		if isPrimitive(s) {
+			return s, nil
+		}
+		candidates := referenceCandidates(s, namespace)
+		for _, candidate := range candidates {
+			if l.defined[candidate] {
+				return s, nil
+			}
+		}
+		for _, candidate := range candidates {
+			resolved, err := l.load(candidate)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return resolved, err
+		}
+		return nil, fmt.Errorf("schema %s not found in %s", s, l.dir)
+	case []any:
+		for i := range s {
+			if s[i], err = l.resolve(s[i], namespace); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]any:
+		typeName, _ := s["type"].(string)
+		switch typeName {
+		case "record", "error":
+			name, _ := s["name"].(string)
+			ns, _ := s["namespace"].(string)
+			l.defined[fullName(name, ns, namespace)] = true
+			fields, _ := s["fields"].([]any)
+			for _, f := range fields {
+				if field, ok := f.(map[string]any); ok {
+					if field["type"], err = l.resolve(field["type"], childNamespace(s, namespace)); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case "enum", "fixed":
+			name, _ := s["name"].(string)
+			ns, _ := s["namespace"].(string)
+			l.defined[fullName(name, ns, namespace)] = true
+		case "array":
+			s["items"], err = l.resolve(s["items"], namespace)
+		case "map":
+			s["values"], err = l.resolve(s["values"], namespace)
+		default:
+			s["type"], err = l.resolve(s["type"], namespace)
+		}
+	}
+
+	return schema, err
+}
+
+// referenceCandidates returns the full names a reference to a named type can resolve to
+func referenceCandidates(name string, namespace string) []string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return []string{name}
+	}
+	return []string{namespace + "." + name, name}
+}
+
+func isPrimitive(typeName string) bool {
+	switch typeName {
+	case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package serdes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalAvroSchemas(t *testing.T) {
+	personSchema := `{
+		"type": "record",
+		"namespace": "com.acme",
+		"name": "Person",
+		"fields": [
+			{"name": "name", "type": "string"},
+			{"name": "address", "type": ["null", "Address"]}
+		]
+	}`
+	addressSchema := `{
+		"type": "record",
+		"namespace": "com.acme",
+		"name": "Address",
+		"fields": [
+			{"name": "city", "type": "string"}
+		]
+	}`
+	person := map[string]any{
+		"name":    "John",
+		"address": goavro.Union("com.acme.Address", map[string]any{"city": "Ghent"}),
+	}
+
+	schemaDir := func(t *testing.T) string {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "com.acme.Person.avsc"), []byte(personSchema), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "com.acme.Address.avsc"), []byte(addressSchema), 0644))
+		return dir
+	}
+
+	encode := func(t *testing.T, schema string, native any) []byte {
+		codec, err := goavro.NewCodec(schema)
+		assert.NoError(t, err)
+		data, err := codec.BinaryFromNative(nil, native)
+		assert.NoError(t, err)
+		return data
+	}
+
+	t.Run("load schema file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "address.avsc")
+		assert.NoError(t, os.WriteFile(path, []byte(addressSchema), 0644))
+
+		schema, err := LoadAvroSchema(path, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, addressSchema, schema)
+	})
+
+	t.Run("load schema from directory inlines referenced schemas", func(t *testing.T) {
+		schema, err := LoadAvroSchema(schemaDir(t), "com.acme.Person")
+		assert.NoError(t, err)
+
+		codec, err := goavro.NewCodec(schema)
+		assert.NoError(t, err)
+		assert.Contains(t, codec.Schema(), `"name":"Address","namespace":"com.acme"`)
+	})
+
+	t.Run("load schema from directory requires a name", func(t *testing.T) {
+		_, err := LoadAvroSchema(schemaDir(t), "")
+
+		assert.ErrorContains(t, err, "a schema name is required")
+	})
+
+	t.Run("load schema from directory with missing reference", func(t *testing.T) {
+		dir := schemaDir(t)
+		assert.NoError(t, os.Remove(filepath.Join(dir, "com.acme.Address.avsc")))
+
+		_, err := LoadAvroSchema(dir, "com.acme.Person")
+
+		assert.ErrorContains(t, err, "schema Address not found in "+dir)
+	})
+
+	t.Run("deserialize schemaless binary avro without schema registry", func(t *testing.T) {
+		dir := schemaDir(t)
+		schema, _ := LoadAvroSchema(dir, "com.acme.Person")
+
+		res, err := NewAvroDeserializer(nil, WithLocalSchemaFile(dir, "com.acme.Person")).
+			Deserialize(encode(t, schema, person))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"John","address":{"city":"Ghent"}}`, res.Value)
+		avroJson, err := res.AvroJsonValue()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"John","address":{"com.acme.Address":{"city":"Ghent"}}}`, avroJson)
+		assert.Equal(t, schema, res.Schema)
+	})
+
+	t.Run("deserialize records framed with a schema id without schema registry", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "address.avsc")
+		assert.NoError(t, os.WriteFile(path, []byte(addressSchema), 0644))
+		data := append([]byte{0x00, 0x00, 0x00, 0x00, 0x07}, encode(t, addressSchema, map[string]any{"city": "Ghent"})...)
+
+		res, err := NewAvroDeserializer(nil, WithLocalSchemaFile(path, "")).Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"city":"Ghent"}`, res.Value)
+	})
+
+	t.Run("deserialize with invalid local schema", func(t *testing.T) {
+		res, err := NewAvroDeserializer(nil, WithLocalSchemaFile(filepath.Join(t.TempDir(), "missing.avsc"), "")).
+			Deserialize([]byte{0x02})
+
+		assert.ErrorContains(t, err, "unable to load local avro schema")
+		assert.Equal(t, DesData{}, res)
+	})
+
+	t.Run("deserialize data not matching local schema", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "address.avsc")
+		assert.NoError(t, os.WriteFile(path, []byte(addressSchema), 0644))
+
+		_, err := NewAvroDeserializer(nil, WithLocalSchemaFile(path, "")).Deserialize([]byte(`{"city":"Ghent"}`))
+
+		assert.ErrorContains(t, err, "avro deserialization with local schema failed")
+	})
+
+	t.Run("deserialize object container file", func(t *testing.T) {
+		writeOCF := func(t *testing.T, records ...any) []byte {
+			var buf bytes.Buffer
+			writer, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Schema: addressSchema})
+			assert.NoError(t, err)
+			assert.NoError(t, writer.Append(records))
+			return buf.Bytes()
+		}
+
+		t.Run("single record", func(t *testing.T) {
+			res, err := NewAvroDeserializer(nil).Deserialize(writeOCF(t, map[string]any{"city": "Ghent"}))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"city":"Ghent"}`, res.Value)
+			assert.Contains(t, res.Schema, `"Address"`)
+		})
+
+		t.Run("multiple records", func(t *testing.T) {
+			res, err := NewAvroDeserializer(nil).Deserialize(writeOCF(t,
+				map[string]any{"city": "Ghent"},
+				map[string]any{"city": "Bruges"},
+			))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[{"city":"Ghent"},{"city":"Bruges"}]`, res.Value)
+			assert.NotContains(t, res.Schema, `"array"`)
+		})
+	})
+}
@@ -0,0 +1,315 @@
+package serdes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// UnionEncoding determines how values of union types are rendered
+type UnionEncoding int
+
+const (
+	// PlainJsonUnions renders the value of a union as is
+	PlainJsonUnions UnionEncoding = iota
+	// AvroJsonUnions wraps the value of a non-null union in an object keyed by its type, as the Avro JSON encoding does
+	AvroJsonUnions
+)
+
+// avroRenderer converts goavro native values into JSON friendly values,
+// using the schema to render logical types in a human-readable way.
+type avroRenderer struct {
+	unions UnionEncoding
+	// named holds the record, enum and fixed schemas by their full name
+	named map[string]map[string]any
+	// hasUnions is set by collect when the schema contains a union
+	hasUnions bool
+}
+
+func (s *avroSchema) renderNative(native any, unions UnionEncoding) any {
+	r := &avroRenderer{unions: unions, named: s.named}
+	return r.render(s.parsed, native, "")
+}
+
+func (r *avroRenderer) render(schema any, native any, namespace string) any {
+	switch s := schema.(type) {
+	case string:
+		if full, ok := r.lookup(s, namespace); ok {
+			return r.render(r.named[full], native, namespace)
+		}
+		return renderNative(native)
+	case []any:
+		return r.renderUnion(s, native, namespace)
+	case map[string]any:
+		return r.renderComplex(s, native, namespace)
+	default:
+		return renderNative(native)
+	}
+}
+
+func (r *avroRenderer) renderUnion(branches []any, native any, namespace string) any {
+	wrapped, ok := native.(map[string]any)
+	if !ok || len(wrapped) != 1 {
+		return renderNative(native)
+	}
+
+	for typeName, value := range wrapped {
+		var branch any
+		for _, b := range branches {
+			if r.unionTypeName(b, namespace) == typeName {
+				branch = b
+				break
+			}
+		}
+
+		rendered := r.render(branch, value, namespace)
+		if r.unions == AvroJsonUnions {
+			if branch != nil {
+				typeName = r.avroJsonTypeName(branch, namespace)
+			}
+			return map[string]any{typeName: rendered}
+		}
+		return rendered
+	}
+	return nil
+}
+
+func (r *avroRenderer) renderComplex(schema map[string]any, native any, namespace string) any {
+	if native == nil {
+		return nil
+	}
+
+	typeName, _ := schema["type"].(string)
+	if logicalType, ok := schema["logicalType"].(string); ok {
+		if rendered, ok := renderLogicalType(logicalType, schema, native); ok {
+			return rendered
+		}
+	}
+
+	switch typeName {
+	case "record", "error":
+		namespace = childNamespace(schema, namespace)
+		fields, _ := schema["fields"].([]any)
+		values, ok := native.(map[string]any)
+		if !ok {
+			return renderNative(native)
+		}
+		rendered := make(map[string]any, len(values))
+		for _, f := range fields {
+			field, _ := f.(map[string]any)
+			name, _ := field["name"].(string)
+			if value, ok := values[name]; ok {
+				rendered[name] = r.render(field["type"], value, namespace)
+			}
+		}
+		return rendered
+	case "enum", "fixed":
+		return renderNative(native)
+	case "array":
+		items, ok := native.([]any)
+		if !ok {
+			return renderNative(native)
+		}
+		rendered := make([]any, len(items))
+		for i, item := range items {
+			rendered[i] = r.render(schema["items"], item, namespace)
+		}
+		return rendered
+	case "map":
+		values, ok := native.(map[string]any)
+		if !ok {
+			return renderNative(native)
+		}
+		rendered := make(map[string]any, len(values))
+		for k, v := range values {
+			rendered[k] = r.render(schema["values"], v, namespace)
+		}
+		return rendered
+	default:
+		// a primitive or a reference written as {"type": "..."}
+		return r.render(schema["type"], native, namespace)
+	}
+}
+
+func renderLogicalType(logicalType string, schema map[string]any, native any) (any, bool) {
+	switch v := native.(type) {
+	case *big.Rat:
+		if logicalType == "decimal" {
+			scale, _ := schema["scale"].(float64)
+			return json.Number(v.FloatString(int(scale))), true
+		}
+	case time.Time:
+		switch logicalType {
+		case "date":
+			return v.UTC().Format(time.DateOnly), true
+		case "timestamp-millis":
+			return v.UTC().Format("2006-01-02T15:04:05.000Z07:00"), true
+		case "timestamp-micros":
+			return v.UTC().Format("2006-01-02T15:04:05.000000Z07:00"), true
+		}
+	case time.Duration:
+		switch logicalType {
+		case "time-millis":
+			return time.Time{}.Add(v).Format("15:04:05.000"), true
+		case "time-micros":
+			return time.Time{}.Add(v).Format("15:04:05.000000"), true
+		}
+	case string:
+		// uuids are decoded as strings already
+		return v, logicalType == "uuid"
+	}
+	return nil, false
+}
+
+// renderNative renders a value of which the schema is unknown
+func renderNative(native any) any {
+	switch v := native.(type) {
+	case *big.Rat:
+		return json.Number(v.FloatString(decimalPlaces(v)))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return time.Time{}.Add(v).Format("15:04:05.999999")
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+		for k, val := range v {
+			rendered[k] = renderNative(val)
+		}
+		return rendered
+	case []any:
+		rendered := make([]any, len(v))
+		for i, val := range v {
+			rendered[i] = renderNative(val)
+		}
+		return rendered
+	default:
+		return v
+	}
+}
+
+// decimalPlaces returns the number of decimals needed to render r exactly, capped at 18
+func decimalPlaces(r *big.Rat) int {
+	places := 0
+	scaled := new(big.Rat).Set(r)
+	ten := big.NewRat(10, 1)
+	for !scaled.IsInt() && places < 18 {
+		scaled.Mul(scaled, ten)
+		places++
+	}
+	return places
+}
+
+// unionTypeName returns the name goavro uses to key the value of the given union branch
+func (r *avroRenderer) unionTypeName(branch any, namespace string) string {
+	switch b := branch.(type) {
+	case string:
+		if full, ok := r.lookup(b, namespace); ok {
+			return full
+		}
+		return b
+	case map[string]any:
+		typeName, _ := b["type"].(string)
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			name, _ := b["name"].(string)
+			ns, _ := b["namespace"].(string)
+			return fullName(name, ns, namespace)
+		}
+		if logicalType, ok := b["logicalType"].(string); ok {
+			if logicalType == "decimal" {
+				if name, ok := b["name"].(string); ok {
+					return name
+				}
+			}
+			return typeName + "." + logicalType
+		}
+		return typeName
+	}
+	return ""
+}
+
+// avroJsonTypeName returns the name the Avro JSON encoding keys the value of the given union branch by,
+// which is the underlying type of logical types rather than the name goavro uses.
+func (r *avroRenderer) avroJsonTypeName(branch any, namespace string) string {
+	if b, ok := branch.(map[string]any); ok {
+		typeName, _ := b["type"].(string)
+		if _, logical := b["logicalType"]; logical && typeName != "fixed" {
+			return typeName
+		}
+	}
+	return r.unionTypeName(branch, namespace)
+}
+
+// collect registers all named schemas so they can be resolved when referenced by name
+func (r *avroRenderer) collect(schema any, namespace string) {
+	switch s := schema.(type) {
+	case []any:
+		r.hasUnions = true
+		for _, branch := range s {
+			r.collect(branch, namespace)
+		}
+	case map[string]any:
+		typeName, _ := s["type"].(string)
+		switch typeName {
+		case "record", "error":
+			name, _ := s["name"].(string)
+			ns, _ := s["namespace"].(string)
+			r.named[fullName(name, ns, namespace)] = s
+			fields, _ := s["fields"].([]any)
+			for _, f := range fields {
+				if field, ok := f.(map[string]any); ok {
+					r.collect(field["type"], childNamespace(s, namespace))
+				}
+			}
+		case "enum", "fixed":
+			name, _ := s["name"].(string)
+			ns, _ := s["namespace"].(string)
+			r.named[fullName(name, ns, namespace)] = s
+		case "array":
+			r.collect(s["items"], namespace)
+		case "map":
+			r.collect(s["values"], namespace)
+		default:
+			r.collect(s["type"], namespace)
+		}
+	}
+}
+
+// lookup resolves a reference to a named schema and returns its full name
+func (r *avroRenderer) lookup(name string, namespace string) (string, bool) {
+	if namespace != "" {
+		if _, ok := r.named[namespace+"."+name]; ok {
+			return namespace + "." + name, true
+		}
+	}
+	_, ok := r.named[name]
+	return name, ok
+}
+
+// childNamespace returns the namespace the children of a named schema are defined in
+func childNamespace(schema map[string]any, enclosingNamespace string) string {
+	name, _ := schema["name"].(string)
+	ns, _ := schema["namespace"].(string)
+	full := fullName(name, ns, enclosingNamespace)
+	if i := strings.LastIndex(full, "."); i >= 0 {
+		return full[:i]
+	}
+	return ""
+}
+
+func fullName(name string, namespace string, enclosingNamespace string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	if namespace == "" {
+		namespace = enclosingNamespace
+	}
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
@@ -0,0 +1,281 @@
+package serdes
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// AvroSerializer encodes JSON as rendered by the GoAvroDeserializer, with either plain or Avro JSON encoded unions,
+// in the schema registry wire format: a zero magic byte, the schema id and the Avro binary encoded value.
+type AvroSerializer struct {
+	codecs *AvroCodecCache
+}
+
+func (s *AvroSerializer) Serialize(schemaId int, value string) ([]byte, error) {
+	schema, err := s.codecs.get(schemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("avro serialization failed, invalid JSON: %w", err)
+	}
+
+	r := &avroRenderer{named: schema.named}
+	native, err := r.parse(schema.parsed, decoded, "")
+	if err != nil {
+		return nil, fmt.Errorf("avro serialization failed: %w", err)
+	}
+
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaId))
+	data, err := schema.codec.BinaryFromNative(header, native)
+	if err != nil {
+		return nil, fmt.Errorf("avro serialization failed: %w", err)
+	}
+	return data, nil
+}
+
+// parse converts a rendered value back into the goavro native value of the schema
+func (r *avroRenderer) parse(schema any, value any, namespace string) (any, error) {
+	switch s := schema.(type) {
+	case string:
+		if full, ok := r.lookup(s, namespace); ok {
+			return r.parse(r.named[full], value, namespace)
+		}
+		return parsePrimitive(s, value)
+	case []any:
+		return r.parseUnion(s, value, namespace)
+	case map[string]any:
+		return r.parseComplex(s, value, namespace)
+	default:
+		return value, nil
+	}
+}
+
+// parseUnion accepts the value of a union keyed by its type, or as is when the union has a single non-null type.
+// Values keyed as in the Avro JSON encoding are keyed by the name goavro uses for their branch.
+func (r *avroRenderer) parseUnion(branches []any, value any, namespace string) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if wrapped, ok := value.(map[string]any); ok && len(wrapped) == 1 {
+		for typeName, v := range wrapped {
+			for _, b := range branches {
+				if r.unionTypeName(b, namespace) == typeName || r.avroJsonTypeName(b, namespace) == typeName {
+					parsed, err := r.parse(b, v, namespace)
+					if err != nil {
+						return nil, err
+					}
+					return map[string]any{r.unionTypeName(b, namespace): parsed}, nil
+				}
+			}
+		}
+	}
+
+	var nonNull []any
+	for _, b := range branches {
+		if b != "null" {
+			nonNull = append(nonNull, b)
+		}
+	}
+	if len(nonNull) != 1 {
+		return nil, fmt.Errorf("%s is not keyed by one of the types of its union", render(value))
+	}
+	parsed, err := r.parse(nonNull[0], value, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{r.unionTypeName(nonNull[0], namespace): parsed}, nil
+}
+
+func (r *avroRenderer) parseComplex(schema map[string]any, value any, namespace string) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	typeName, _ := schema["type"].(string)
+	if logicalType, ok := schema["logicalType"].(string); ok {
+		if parsed, ok, err := parseLogicalType(logicalType, value); ok {
+			return parsed, err
+		}
+	}
+
+	switch typeName {
+	case "record", "error":
+		namespace = childNamespace(schema, namespace)
+		values, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a valid %s", render(value), schema["name"])
+		}
+		fields, _ := schema["fields"].([]any)
+		parsed := make(map[string]any, len(values))
+		for _, f := range fields {
+			field, _ := f.(map[string]any)
+			name, _ := field["name"].(string)
+			if v, ok := values[name]; ok {
+				p, err := r.parse(field["type"], v, namespace)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				parsed[name] = p
+			}
+		}
+		return parsed, nil
+	case "enum":
+		return parsePrimitive("string", value)
+	case "fixed":
+		return parsePrimitive("bytes", value)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a valid array", render(value))
+		}
+		parsed := make([]any, len(items))
+		for i, item := range items {
+			p, err := r.parse(schema["items"], item, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			parsed[i] = p
+		}
+		return parsed, nil
+	case "map":
+		values, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a valid map", render(value))
+		}
+		parsed := make(map[string]any, len(values))
+		for k, v := range values {
+			p, err := r.parse(schema["values"], v, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			parsed[k] = p
+		}
+		return parsed, nil
+	default:
+		// a primitive or a reference written as {"type": "..."}
+		return r.parse(schema["type"], value, namespace)
+	}
+}
+
+// parseLogicalType is the inverse of renderLogicalType, ok is false when the logical type is unknown
+func parseLogicalType(logicalType string, value any) (parsed any, ok bool, err error) {
+	switch logicalType {
+	case "decimal":
+		n, isNumber := value.(json.Number)
+		if !isNumber {
+			return nil, true, fmt.Errorf("%s is not a valid decimal", render(value))
+		}
+		rat, valid := new(big.Rat).SetString(n.String())
+		if !valid {
+			return nil, true, fmt.Errorf("%s is not a valid decimal", n)
+		}
+		return rat, true, nil
+	case "date":
+		return parseTime(value, time.DateOnly)
+	case "timestamp-millis", "timestamp-micros":
+		return parseTime(value, time.RFC3339Nano)
+	case "time-millis", "time-micros":
+		t, ok, err := parseTime(value, "15:04:05.999999")
+		if err != nil {
+			return nil, ok, err
+		}
+		return t.(time.Time).Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)), ok, nil
+	}
+	return nil, false, nil
+}
+
+func parseTime(value any, layout string) (any, bool, error) {
+	s, isString := value.(string)
+	if !isString {
+		return nil, true, fmt.Errorf("%s is not a valid %s time", render(value), layout)
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s is not a valid %s time", s, layout)
+	}
+	return t, true, nil
+}
+
+func parsePrimitive(typeName string, value any) (any, error) {
+	invalid := fmt.Errorf("%s is not a valid %s", render(value), typeName)
+	switch typeName {
+	case "null":
+		if value != nil {
+			return nil, invalid
+		}
+		return nil, nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return nil, invalid
+		}
+		return value, nil
+	case "int", "long":
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, invalid
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, invalid
+		}
+		if typeName == "int" {
+			return int32(i), nil
+		}
+		return i, nil
+	case "float", "double":
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, invalid
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, invalid
+		}
+		if typeName == "float" {
+			return float32(f), nil
+		}
+		return f, nil
+	case "bytes":
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, invalid
+		}
+		return b, nil
+	case "string":
+		if _, ok := value.(string); !ok {
+			return nil, invalid
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// render renders a decoded JSON value for an error message
+func render(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+func NewAvroSerializer(codecs *AvroCodecCache) *AvroSerializer {
+	return &AvroSerializer{codecs: codecs}
+}
@@ -0,0 +1,99 @@
+package serdes
+
+import (
+	"ktea/sradmin"
+	"math/big"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvroSerializer(t *testing.T) {
+	sraFor := func(schema string) *sradmin.MockSrAdmin {
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: schema}}
+		}
+		return sraMock
+	}
+
+	t.Run("serializes in the wire format", func(t *testing.T) {
+		serializer := NewAvroSerializer(NewAvroCodecCache(sraFor(personSchema)))
+
+		data, err := serializer.Serialize(7, `{"Name":"John","Age":21}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, encodePerson(t, 7), data)
+	})
+
+	t.Run("serializes what was deserialized", func(t *testing.T) {
+		schema := `{
+			"type": "record",
+			"name": "Payment",
+			"namespace": "ktea.test",
+			"fields": [
+				{"name": "note", "type": ["null", "string"]},
+				{"name": "billing", "type": ["null", {"type": "record", "name": "Address", "fields": [
+					{"name": "city", "type": "string"}
+				]}]},
+				{"name": "shipping", "type": ["null", "Address"]},
+				{"name": "paidAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+				{"name": "dueDate", "type": {"type": "int", "logicalType": "date"}},
+				{"name": "cutOff", "type": {"type": "int", "logicalType": "time-millis"}},
+				{"name": "fee", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}]},
+				{"name": "tags", "type": {"type": "array", "items": "string"}},
+				{"name": "ratio", "type": "double"},
+				{"name": "raw", "type": "bytes"},
+				{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["OPEN", "PAID"]}}
+			]
+		}`
+		codec, err := goavro.NewCodec(schema)
+		assert.NoError(t, err)
+		data, err := codec.BinaryFromNative([]byte{0x00, 0x00, 0x00, 0x00, 0x01}, map[string]any{
+			"note":     goavro.Union("string", "paid"),
+			"billing":  nil,
+			"shipping": goavro.Union("ktea.test.Address", map[string]any{"city": "Ghent"}),
+			"paidAt":   time.Date(2024, time.March, 1, 13, 14, 15, 123_000_000, time.UTC),
+			"dueDate":  time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			"cutOff":   17*time.Hour + 30*time.Minute,
+			"fee":      goavro.Union("bytes.decimal", big.NewRat(525, 100)),
+			"tags":     []any{"a", "b"},
+			"ratio":    0.25,
+			"raw":      []byte{0x00, 0xff},
+			"status":   "PAID",
+		})
+		assert.NoError(t, err)
+		sra := sraFor(schema)
+		deserialized, err := NewAvroDeserializer(sra).Deserialize(data)
+		assert.NoError(t, err)
+		serializer := NewAvroSerializer(NewAvroCodecCache(sra))
+
+		avroJsonValue, err := deserialized.AvroJsonValue()
+		assert.NoError(t, err)
+		avroJson, err := serializer.Serialize(1, avroJsonValue)
+		assert.NoError(t, err)
+		plainJson, err := serializer.Serialize(1, `{"note":"paid","billing":null,"shipping":{"city":"Ghent"},`+
+			`"paidAt":"2024-03-01T13:14:15.123Z","dueDate":"2024-03-31","cutOff":"17:30:00.000","fee":5.25,`+
+			`"tags":["a","b"],"ratio":0.25,"raw":"AP8=","status":"PAID"}`)
+		assert.NoError(t, err)
+
+		assert.Equal(t, data, avroJson)
+		assert.Equal(t, data, plainJson)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		serializer := NewAvroSerializer(NewAvroCodecCache(sraFor(personSchema)))
+
+		_, err := serializer.Serialize(1, `{"Name":"John","Age":"21"}`)
+		assert.EqualError(t, err, `avro serialization failed: Age: "21" is not a valid int`)
+
+		_, err = serializer.Serialize(1, `{"Name":"John"`)
+		assert.ErrorContains(t, err, "avro serialization failed, invalid JSON")
+
+		_, err = serializer.Serialize(1, `{"Name":"John"}`)
+		assert.ErrorContains(t, err, "avro serialization failed")
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/linkedin/goavro/v2"
 	"github.com/stretchr/testify/assert"
 	"ktea/sradmin"
+	"math/big"
 	"testing"
+	"time"
 )
 
 func TestAvroDeserializer(t *testing.T) {
@@ -79,6 +81,7 @@ func TestAvroDeserializer(t *testing.T) {
 
 		assert.Equal(t, `{"Age":21,"Name":"John"}`, res.Value)
 		assert.Equal(t, schema, res.Schema)
+		assert.Equal(t, 1, res.SchemaId)
 	})
 
 	t.Run("deserialize failed", func(t *testing.T) {
@@ -125,3 +128,102 @@ func TestAvroDeserializer(t *testing.T) {
 		})
 	})
 }
+
+func TestAvroRendering(t *testing.T) {
+	deserialize := func(t *testing.T, schema string, native map[string]any) DesData {
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: schema}}
+		}
+		codec, err := goavro.NewCodec(schema)
+		assert.NoError(t, err)
+		data, err := codec.BinaryFromNative([]byte{0x00, 0x00, 0x00, 0x00, 0x01}, native)
+		assert.NoError(t, err)
+
+		res, err := NewAvroDeserializer(sraMock).Deserialize(data)
+		assert.NoError(t, err)
+		return res
+	}
+
+	t.Run("logical types", func(t *testing.T) {
+		schema := `{
+			"type": "record",
+			"name": "Invoice",
+			"fields": [
+				{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 6, "scale": 2}},
+				{"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+				{"name": "updatedAt", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+				{"name": "dueDate", "type": {"type": "int", "logicalType": "date"}},
+				{"name": "cutOff", "type": {"type": "int", "logicalType": "time-millis"}},
+				{"name": "id", "type": {"type": "string", "logicalType": "uuid"}}
+			]
+		}`
+		createdAt := time.Date(2024, time.March, 1, 13, 14, 15, 123_000_000, time.UTC)
+
+		res := deserialize(t, schema, map[string]any{
+			"amount":    big.NewRat(1050, 100),
+			"createdAt": createdAt,
+			"updatedAt": createdAt.Add(456 * time.Microsecond),
+			"dueDate":   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			"cutOff":    17*time.Hour + 30*time.Minute,
+			"id":        "0b3c8b0a-8c7e-4a55-9b4b-0e4f6b1a2c3d",
+		})
+
+		assert.JSONEq(t, `{
+			"amount": 10.50,
+			"createdAt": "2024-03-01T13:14:15.123Z",
+			"updatedAt": "2024-03-01T13:14:15.123456Z",
+			"dueDate": "2024-03-31",
+			"cutOff": "17:30:00.000",
+			"id": "0b3c8b0a-8c7e-4a55-9b4b-0e4f6b1a2c3d"
+		}`, res.Value)
+		assert.Contains(t, res.Value, `"amount":10.50`)
+		assert.False(t, res.HasAvroJsonUnions())
+	})
+
+	t.Run("unions", func(t *testing.T) {
+		schema := `{
+			"type": "record",
+			"name": "Payment",
+			"namespace": "ktea.test",
+			"fields": [
+				{"name": "note", "type": ["null", "string"]},
+				{"name": "missing", "type": ["null", "string"]},
+				{"name": "billing", "type": ["null", {"type": "record", "name": "Address", "fields": [
+					{"name": "city", "type": "string"}
+				]}]},
+				{"name": "shipping", "type": ["null", "Address"]},
+				{"name": "paidAt", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}]},
+				{"name": "fee", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}]}
+			]
+		}`
+
+		res := deserialize(t, schema, map[string]any{
+			"note":     goavro.Union("string", "paid"),
+			"missing":  nil,
+			"billing":  nil,
+			"shipping": goavro.Union("ktea.test.Address", map[string]any{"city": "Ghent"}),
+			"paidAt":   goavro.Union("long.timestamp-millis", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			"fee":      goavro.Union("bytes.decimal", big.NewRat(5, 1)),
+		})
+
+		assert.JSONEq(t, `{
+			"note": "paid",
+			"missing": null,
+			"billing": null,
+			"shipping": {"city": "Ghent"},
+			"paidAt": "2024-03-01T00:00:00.000Z",
+			"fee": 5.00
+		}`, res.Value)
+		avroJson, err := res.AvroJsonValue()
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"note": {"string": "paid"},
+			"missing": null,
+			"billing": null,
+			"shipping": {"ktea.test.Address": {"city": "Ghent"}},
+			"paidAt": {"long": "2024-03-01T00:00:00.000Z"},
+			"fee": {"bytes": 5.00}
+		}`, avroJson)
+	})
+}
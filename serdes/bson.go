@@ -0,0 +1,33 @@
+package serdes
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type BsonDeserializer struct{}
+
+// Deserialize renders the BSON document as relaxed Extended JSON,
+// types without a JSON counterpart such as ObjectId are rendered as e.g. {"$oid": "..."}.
+func (d *BsonDeserializer) Deserialize(data []byte) (DesData, error) {
+	if len(data) == 0 {
+		return DesData{}, nil
+	}
+
+	document := bson.Raw(data)
+	if err := document.Validate(); err != nil {
+		return DesData{}, fmt.Errorf("bson deserialization failed: %w", err)
+	}
+
+	value, err := bson.MarshalExtJSON(document, false, false)
+	if err != nil {
+		return DesData{}, fmt.Errorf("bson deserialization failed: %w", err)
+	}
+
+	return DesData{Value: string(value), Format: "BSON"}, nil
+}
+
+func NewBsonDeserializer() Deserializer {
+	return &BsonDeserializer{}
+}
@@ -0,0 +1,26 @@
+package serdes
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+type CborDeserializer struct{}
+
+func (d *CborDeserializer) Deserialize(data []byte) (DesData, error) {
+	if len(data) == 0 {
+		return DesData{}, nil
+	}
+
+	var value any
+	if err := cbor.Unmarshal(data, &value); err != nil {
+		return DesData{}, fmt.Errorf("cbor deserialization failed: %w", err)
+	}
+
+	return toJson(value, "CBOR")
+}
+
+func NewCborDeserializer() Deserializer {
+	return &CborDeserializer{}
+}
@@ -0,0 +1,107 @@
+package serdes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// FormatDetectingDeserializer falls back to the binary formats when a value is not valid UTF-8
+// and was not decoded by the wrapped deserializer.
+type FormatDetectingDeserializer struct {
+	deserializer Deserializer
+	// formats are tried in order, the most restrictive format first
+	formats []Deserializer
+}
+
+func (d *FormatDetectingDeserializer) Deserialize(data []byte) (DesData, error) {
+	desData, err := d.deserializer.Deserialize(data)
+	if err != nil || desData.Format != "" || utf8.Valid(data) {
+		return desData, err
+	}
+
+	for _, format := range d.formats {
+		detected, err := format.Deserialize(data)
+		// scalars are valid in most binary formats, only documents are considered a match
+		if err == nil && isJsonDocument(detected.Value) {
+			return detected, nil
+		}
+	}
+
+	return desData, nil
+}
+
+func isJsonDocument(value string) bool {
+	return strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")
+}
+
+// WithFormatDetection detects BSON, CBOR and MessagePack values the given deserializer does not decode.
+func WithFormatDetection(deserializer Deserializer) Deserializer {
+	return &FormatDetectingDeserializer{
+		deserializer: deserializer,
+		formats: []Deserializer{
+			NewBsonDeserializer(),
+			NewCborDeserializer(),
+			NewMessagePackDeserializer(),
+		},
+	}
+}
+
+// toJson renders a decoded value of a schemaless binary format as JSON
+func toJson(value any, format string) (DesData, error) {
+	rendered, err := json.Marshal(jsonCompatible(value))
+	if err != nil {
+		return DesData{}, fmt.Errorf("%s deserialization failed: %w", strings.ToLower(format), err)
+	}
+	return DesData{Value: string(rendered), Format: format}, nil
+}
+
+// jsonCompatible converts the values JSON has no counterpart for
+func jsonCompatible(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		rendered := make(map[string]any, len(v))
+		for key, val := range v {
+			rendered[fmt.Sprint(jsonCompatible(key))] = jsonCompatible(val)
+		}
+		return rendered
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+		for key, val := range v {
+			rendered[key] = jsonCompatible(val)
+		}
+		return rendered
+	case []any:
+		rendered := make([]any, len(v))
+		for i, val := range v {
+			rendered[i] = jsonCompatible(val)
+		}
+		return rendered
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case big.Int:
+		return json.Number(v.String())
+	case *big.Int:
+		return json.Number(v.String())
+	case cbor.Tag:
+		return jsonCompatible(v.Content)
+	case float32:
+		return jsonCompatible(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprint(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
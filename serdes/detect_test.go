@@ -0,0 +1,117 @@
+package serdes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"ktea/sradmin"
+)
+
+func TestFormatDetection(t *testing.T) {
+	order := map[string]any{
+		"id":    "o-1",
+		"items": []any{"book", "pen"},
+		"total": 12.5,
+	}
+	orderJson := `{"id":"o-1","items":["book","pen"],"total":12.5}`
+
+	deserializer := WithFormatDetection(NewAvroDeserializer(sradmin.NewMock()))
+
+	t.Run("MessagePack", func(t *testing.T) {
+		data, err := msgpack.Marshal(order)
+		assert.NoError(t, err)
+
+		res, err := deserializer.Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, orderJson, res.Value)
+		assert.Equal(t, "MessagePack", res.Format)
+	})
+
+	t.Run("MessagePack with non string keys and timestamps", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[int]any{
+			1: time.Date(2024, time.March, 1, 13, 14, 15, 0, time.UTC),
+			2: []byte{0x01, 0x02},
+		})
+		assert.NoError(t, err)
+
+		res, err := NewMessagePackDeserializer().Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"1":"2024-03-01T13:14:15Z","2":"AQI="}`, res.Value)
+	})
+
+	t.Run("CBOR", func(t *testing.T) {
+		data, err := cbor.Marshal(order)
+		assert.NoError(t, err)
+
+		res, err := deserializer.Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, orderJson, res.Value)
+		assert.Equal(t, "CBOR", res.Format)
+	})
+
+	t.Run("BSON", func(t *testing.T) {
+		id, _ := primitive.ObjectIDFromHex("65e1d3a7f1c2b3a4d5e6f708")
+		data, err := bson.Marshal(bson.D{
+			{Key: "_id", Value: id},
+			{Key: "id", Value: "o-1"},
+			{Key: "items", Value: bson.A{"book", "pen"}},
+			{Key: "total", Value: 12.5},
+		})
+		assert.NoError(t, err)
+
+		res, err := deserializer.Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"_id":{"$oid":"65e1d3a7f1c2b3a4d5e6f708"},"id":"o-1","items":["book","pen"],"total":12.5}`, res.Value)
+		assert.Equal(t, "BSON", res.Format)
+	})
+
+	t.Run("text is not detected", func(t *testing.T) {
+		res, err := deserializer.Deserialize([]byte(`{"id":"o-1"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, DesData{Value: `{"id":"o-1"}`}, res)
+	})
+
+	t.Run("unknown binary data is kept as is", func(t *testing.T) {
+		data := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff}
+
+		res, err := deserializer.Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.Equal(t, DesData{Value: string(data)}, res)
+	})
+
+	t.Run("scalars are not detected", func(t *testing.T) {
+		data, err := msgpack.Marshal("caf\xe9")
+		assert.NoError(t, err)
+
+		res, err := deserializer.Deserialize(data)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Format)
+	})
+
+	t.Run("trailing bytes are rejected", func(t *testing.T) {
+		data, err := msgpack.Marshal(order)
+		assert.NoError(t, err)
+
+		_, err = NewMessagePackDeserializer().Deserialize(append(data, 0xc1))
+
+		assert.ErrorContains(t, err, "messagepack deserialization failed")
+	})
+
+	t.Run("invalid BSON document", func(t *testing.T) {
+		_, err := NewBsonDeserializer().Deserialize([]byte{0x10, 0x00, 0x00, 0x00, 0xff})
+
+		assert.ErrorContains(t, err, "bson deserialization failed")
+	})
+}
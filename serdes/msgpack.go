@@ -0,0 +1,36 @@
+package serdes
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type MessagePackDeserializer struct{}
+
+func (d *MessagePackDeserializer) Deserialize(data []byte) (DesData, error) {
+	if len(data) == 0 {
+		return DesData{}, nil
+	}
+
+	reader := bytes.NewReader(data)
+	decoder := msgpack.NewDecoder(reader)
+	decoder.SetMapDecoder(func(d *msgpack.Decoder) (any, error) {
+		return d.DecodeUntypedMap()
+	})
+
+	value, err := decoder.DecodeInterface()
+	if err != nil {
+		return DesData{}, fmt.Errorf("messagepack deserialization failed: %w", err)
+	}
+	if reader.Len() > 0 {
+		return DesData{}, fmt.Errorf("messagepack deserialization failed: %d trailing bytes", reader.Len())
+	}
+
+	return toJson(value, "MessagePack")
+}
+
+func NewMessagePackDeserializer() Deserializer {
+	return &MessagePackDeserializer{}
+}
@@ -0,0 +1,286 @@
+package serdes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultPluginTimeout = 5 * time.Second
+
+// Record is the raw record handed to a RecordDeserializer.
+type Record struct {
+	Topic     string
+	Partition int64
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []RecordHeader
+}
+
+type RecordHeader struct {
+	Key   string
+	Value []byte
+}
+
+// RecordDeserializer is implemented by deserializers that take the whole record into account.
+type RecordDeserializer interface {
+	Deserializer
+	// DeserializeRecord stops waiting for the outcome once the context is done.
+	DeserializeRecord(ctx context.Context, record Record) (DesData, error)
+}
+
+// pluginRequest is written as a single line of JSON to the stdin of the plugin,
+// key, value and header values are base64 encoded.
+type pluginRequest struct {
+	Topic     string         `json:"topic"`
+	Partition int64          `json:"partition"`
+	Offset    int64          `json:"offset"`
+	Key       []byte         `json:"key"`
+	Value     []byte         `json:"value"`
+	Headers   []pluginHeader `json:"headers"`
+}
+
+type pluginHeader struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// pluginResponse is read as a single line of JSON from the stdout of the plugin.
+type pluginResponse struct {
+	Value       string `json:"value"`
+	ContentType string `json:"contentType"`
+	Error       string `json:"error"`
+}
+
+// PluginDeserializer delegates deserialization to an external executable.
+//
+// The executable is started on the first record and kept running until Close is called.
+// Records are handed over one at a time, a plugin that crashes or does not respond in time
+// fails the record at hand and is restarted for the next one.
+type PluginDeserializer struct {
+	command string
+	args    []string
+	timeout time.Duration
+
+	mu      sync.Mutex
+	process *pluginProcess
+}
+
+type pluginProcess struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan string
+	// stopped is closed once no more responses are awaited
+	stopped chan struct{}
+	// exited is closed once the process has stopped, err holds the reason
+	exited chan struct{}
+	err    error
+	stderr *tailBuffer
+}
+
+func (d *PluginDeserializer) Deserialize(data []byte) (DesData, error) {
+	return d.DeserializeRecord(context.Background(), Record{Value: data})
+}
+
+func (d *PluginDeserializer) DeserializeRecord(ctx context.Context, record Record) (DesData, error) {
+	if len(record.Value) == 0 {
+		return DesData{}, nil
+	}
+
+	request := pluginRequest{
+		Topic:     record.Topic,
+		Partition: record.Partition,
+		Offset:    record.Offset,
+		Key:       record.Key,
+		Value:     record.Value,
+	}
+	for _, h := range record.Headers {
+		request.Headers = append(request.Headers, pluginHeader{Key: h.Key, Value: h.Value})
+	}
+	line, err := json.Marshal(request)
+	if err != nil {
+		return DesData{}, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// records waiting for the plugin are not handed over once reading stopped
+	if err := ctx.Err(); err != nil {
+		return DesData{}, err
+	}
+
+	process, err := d.running()
+	if err != nil {
+		return DesData{}, err
+	}
+
+	if _, err := process.stdin.Write(append(line, '\n')); err != nil {
+		d.stop()
+		return DesData{}, fmt.Errorf("plugin %s failed: %w", d.command, err)
+	}
+
+	select {
+	case responseLine := <-process.responses:
+		var response pluginResponse
+		if err := json.Unmarshal([]byte(responseLine), &response); err != nil {
+			d.stop()
+			return DesData{}, fmt.Errorf("plugin %s responded with invalid JSON: %w", d.command, err)
+		}
+		if response.Error != "" {
+			return DesData{}, fmt.Errorf("plugin %s: %s", d.command, response.Error)
+		}
+		format := response.ContentType
+		if format == "" {
+			format = "Plugin"
+		}
+		return DesData{Value: response.Value, Format: format}, nil
+	case <-process.exited:
+		close(process.stopped)
+		d.process = nil
+		return DesData{}, fmt.Errorf("plugin %s exited: %w", d.command, process.exitErr())
+	case <-time.After(d.timeout):
+		d.stop()
+		return DesData{}, fmt.Errorf("plugin %s did not respond within %s", d.command, d.timeout)
+	case <-ctx.Done():
+		// the response would be taken for the one of the next record
+		d.stop()
+		return DesData{}, ctx.Err()
+	}
+}
+
+// running returns the plugin process, starting it when needed
+func (d *PluginDeserializer) running() (*pluginProcess, error) {
+	if d.process != nil {
+		return d.process, nil
+	}
+
+	cmd := exec.Command(d.command, d.args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tailBuffer{limit: 512}
+	cmd.Stderr = stderr
+	// do not hang on a plugin whose child processes keep its output open
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("unable to start plugin %s: %w", d.command, err)
+	}
+
+	process := &pluginProcess{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan string),
+		stopped:   make(chan struct{}),
+		exited:    make(chan struct{}),
+		stderr:    stderr,
+	}
+
+	go func() {
+		defer func() {
+			process.err = cmd.Wait()
+			close(process.exited)
+		}()
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			select {
+			case process.responses <- scanner.Text():
+			case <-process.stopped:
+				return
+			}
+		}
+	}()
+
+	d.process = process
+	return process, nil
+}
+
+// stop kills the plugin, the next record starts a new one
+func (d *PluginDeserializer) stop() {
+	if d.process == nil {
+		return
+	}
+	close(d.process.stopped)
+	_ = d.process.stdin.Close()
+	_ = d.process.cmd.Process.Kill()
+	d.process = nil
+}
+
+// Close stops the plugin by closing its stdin, it is killed when it does not exit in time.
+func (d *PluginDeserializer) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.process == nil {
+		return nil
+	}
+	process := d.process
+	d.process = nil
+
+	close(process.stopped)
+	_ = process.stdin.Close()
+	select {
+	case <-process.exited:
+	case <-time.After(d.timeout):
+		_ = process.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (p *pluginProcess) exitErr() error {
+	err := p.err
+	if err == nil {
+		err = errors.New("no response")
+	}
+	if stderr := strings.TrimSpace(p.stderr.String()); stderr != "" {
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+	return err
+}
+
+// tailBuffer keeps the last bytes written to it
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+// NewPluginDeserializer creates a deserializer backed by the given executable,
+// a timeout of zero or less falls back to DefaultPluginTimeout.
+func NewPluginDeserializer(command string, args []string, timeout time.Duration) *PluginDeserializer {
+	if timeout <= 0 {
+		timeout = DefaultPluginTimeout
+	}
+	return &PluginDeserializer{command: command, args: args, timeout: timeout}
+}
@@ -0,0 +1,177 @@
+package serdes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluginHelperProcess is not a real test, it is started by TestPluginDeserializer as plugin.
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("KTEA_PLUGIN_HELPER") != "1" {
+		return
+	}
+
+	mode := os.Args[len(os.Args)-1]
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request pluginRequest
+		_ = json.Unmarshal(scanner.Bytes(), &request)
+
+		switch mode {
+		case "echo":
+			var headers []string
+			for _, h := range request.Headers {
+				headers = append(headers, h.Key+"="+string(h.Value))
+			}
+			response, _ := json.Marshal(pluginResponse{
+				Value: fmt.Sprintf(`{"topic":"%s","offset":%d,"key":"%s","value":"%s","headers":"%s"}`,
+					request.Topic, request.Offset, request.Key, strings.ToUpper(string(request.Value)), strings.Join(headers, ",")),
+				ContentType: "application/json",
+			})
+			fmt.Println(string(response))
+		case "error":
+			fmt.Println(`{"error":"unsupported version"}`)
+		case "crash":
+			fmt.Fprintln(os.Stderr, "segmentation fault")
+			os.Exit(3)
+		case "hang":
+			time.Sleep(time.Minute)
+		}
+	}
+	os.Exit(0)
+}
+
+func TestPluginDeserializer(t *testing.T) {
+	t.Setenv("KTEA_PLUGIN_HELPER", "1")
+
+	plugin := func(mode string, timeout time.Duration) *PluginDeserializer {
+		return NewPluginDeserializer(os.Args[0], []string{"-test.run=^TestPluginHelperProcess$", "--", mode}, timeout)
+	}
+
+	t.Run("deserializes the whole record", func(t *testing.T) {
+		d := plugin("echo", 0)
+		defer d.Close()
+
+		res, err := d.DeserializeRecord(context.Background(), Record{
+			Topic:   "orders",
+			Offset:  42,
+			Key:     []byte("k1"),
+			Value:   []byte("payload"),
+			Headers: []RecordHeader{{Key: "version", Value: []byte("2")}},
+		})
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"topic":"orders","offset":42,"key":"k1","value":"PAYLOAD","headers":"version=2"}`, res.Value)
+		assert.Equal(t, "application/json", res.Format)
+	})
+
+	t.Run("process is reused for subsequent records", func(t *testing.T) {
+		d := plugin("echo", 0)
+		defer d.Close()
+
+		_, err := d.Deserialize([]byte("first"))
+		assert.NoError(t, err)
+		pid := d.process.cmd.Process.Pid
+
+		res, err := d.Deserialize([]byte("second"))
+
+		assert.NoError(t, err)
+		assert.Contains(t, res.Value, "SECOND")
+		assert.Equal(t, pid, d.process.cmd.Process.Pid)
+	})
+
+	t.Run("no data deserializes to empty string", func(t *testing.T) {
+		d := plugin("echo", 0)
+		defer d.Close()
+
+		res, err := d.Deserialize(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, DesData{}, res)
+		assert.Nil(t, d.process)
+	})
+
+	t.Run("reports errors of the plugin per record", func(t *testing.T) {
+		d := plugin("error", 0)
+		defer d.Close()
+
+		_, err := d.Deserialize([]byte("payload"))
+
+		assert.ErrorContains(t, err, "unsupported version")
+	})
+
+	t.Run("reports a crashed plugin and restarts it", func(t *testing.T) {
+		d := plugin("crash", 0)
+		defer d.Close()
+
+		_, err := d.Deserialize([]byte("payload"))
+		assert.ErrorContains(t, err, "exited")
+		assert.ErrorContains(t, err, "segmentation fault")
+
+		_, err = d.Deserialize([]byte("payload"))
+		assert.ErrorContains(t, err, "exited")
+	})
+
+	t.Run("times out on an unresponsive plugin", func(t *testing.T) {
+		d := plugin("hang", 100*time.Millisecond)
+		defer d.Close()
+
+		start := time.Now()
+		_, err := d.Deserialize([]byte("payload"))
+
+		assert.EqualError(t, err, fmt.Sprintf("plugin %s did not respond within 100ms", os.Args[0]))
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Nil(t, d.process)
+	})
+
+	t.Run("stops waiting once the context is done", func(t *testing.T) {
+		d := plugin("hang", time.Minute)
+		defer d.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := d.DeserializeRecord(ctx, Record{Value: []byte("payload")})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Nil(t, d.process)
+
+		_, err = d.DeserializeRecord(ctx, Record{Value: []byte("payload")})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, d.process, "Expected no plugin to be started once the context is done")
+	})
+
+	t.Run("unknown executable", func(t *testing.T) {
+		d := NewPluginDeserializer("ktea-plugin-does-not-exist", nil, 0)
+
+		_, err := d.Deserialize([]byte("payload"))
+
+		assert.ErrorContains(t, err, "unable to start plugin ktea-plugin-does-not-exist")
+	})
+
+	t.Run("close stops the plugin", func(t *testing.T) {
+		d := plugin("echo", 0)
+		_, err := d.Deserialize([]byte("payload"))
+		assert.NoError(t, err)
+		process := d.process
+
+		assert.NoError(t, d.Close())
+
+		select {
+		case <-process.exited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("plugin did not exit")
+		}
+		assert.Nil(t, d.process)
+	})
+}
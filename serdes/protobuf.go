@@ -0,0 +1,144 @@
+package serdes
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type ProtobufDeserializer struct {
+	message protoreflect.MessageDescriptor
+	types   *dynamicpb.Types
+	// err is the reason the message type could not be loaded
+	err error
+}
+
+func (d *ProtobufDeserializer) Deserialize(data []byte) (DesData, error) {
+	if d.err != nil {
+		return DesData{}, d.err
+	}
+
+	if len(data) == 0 {
+		return DesData{}, nil
+	}
+
+	msg := dynamicpb.NewMessage(d.message)
+	if err := (proto.UnmarshalOptions{Resolver: d.types}).Unmarshal(data, msg); err != nil {
+		return DesData{}, fmt.Errorf("protobuf deserialization of %s failed: %w", d.message.FullName(), err)
+	}
+
+	value, err := protojson.MarshalOptions{
+		UseProtoNames: true,
+		Resolver:      d.types,
+	}.Marshal(msg)
+	if err != nil {
+		return DesData{}, fmt.Errorf("protobuf deserialization of %s failed: %w", d.message.FullName(), err)
+	}
+
+	return DesData{Value: string(value), Format: "Protobuf"}, nil
+}
+
+// LoadProtobufDescriptors reads the descriptors from either a compiled FileDescriptorSet
+// or a directory of .proto files. The well-known types can be imported without being part of the directory.
+func LoadProtobufDescriptors(path string) (*protoregistry.Files, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return compileProtoFiles(path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &descriptorSet); err != nil {
+		return nil, fmt.Errorf("%s is not a FileDescriptorSet: %w", path, err)
+	}
+	return protodesc.NewFiles(&descriptorSet)
+}
+
+func compileProtoFiles(dir string) (*protoregistry.Files, error) {
+	var protoFiles []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".proto") {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		protoFiles = append(protoFiles, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(protoFiles) == 0 {
+		return nil, fmt.Errorf("no .proto files found in %s", dir)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: []string{dir},
+		}),
+	}
+	compiled, err := compiler.Compile(context.Background(), protoFiles...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := new(protoregistry.Files)
+	for _, file := range compiled {
+		if err := registerFile(files, file); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// registerFile registers the file after the files it imports
+func registerFile(files *protoregistry.Files, file protoreflect.FileDescriptor) error {
+	if _, err := files.FindFileByPath(file.Path()); err == nil {
+		return nil
+	}
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if err := registerFile(files, imports.Get(i).FileDescriptor); err != nil {
+			return err
+		}
+	}
+	return files.RegisterFile(file)
+}
+
+// NewProtobufDeserializer decodes records as the given fully qualified message type,
+// using the descriptors found at path, see LoadProtobufDescriptors.
+func NewProtobufDeserializer(path string, messageType string) Deserializer {
+	files, err := LoadProtobufDescriptors(path)
+	if err != nil {
+		return &ProtobufDeserializer{err: fmt.Errorf("unable to load protobuf descriptors: %w", err)}
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+	if err != nil {
+		return &ProtobufDeserializer{err: fmt.Errorf("message type %s not found in %s", messageType, path)}
+	}
+	message, ok := descriptor.(protoreflect.MessageDescriptor)
+	if !ok {
+		return &ProtobufDeserializer{err: fmt.Errorf("%s is not a message type", messageType)}
+	}
+
+	return &ProtobufDeserializer{message: message, types: dynamicpb.NewTypes(files)}
+}
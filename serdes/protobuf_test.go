@@ -0,0 +1,137 @@
+package serdes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProtobufDeserializer(t *testing.T) {
+	orderProto := `
+syntax = "proto3";
+
+package com.acme;
+
+import "google/protobuf/any.proto";
+import "google/protobuf/duration.proto";
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+import "com/acme/customer.proto";
+
+message Order {
+  string order_id = 1;
+  google.protobuf.Timestamp created_at = 2;
+  google.protobuf.Duration ttl = 3;
+  google.protobuf.StringValue note = 4;
+  google.protobuf.Any details = 5;
+  Customer customer = 6;
+}
+`
+	customerProto := `
+syntax = "proto3";
+
+package com.acme;
+
+message Customer {
+  string full_name = 1;
+}
+`
+	orderJson := `{
+		"order_id": "o-1",
+		"created_at": "2024-03-01T13:14:15Z",
+		"ttl": "90s",
+		"note": "fragile",
+		"details": {"@type": "type.googleapis.com/com.acme.Customer", "full_name": "John"},
+		"customer": {"full_name": "Jane"}
+	}`
+
+	protoDir := func(t *testing.T) string {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "com", "acme"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "order.proto"), []byte(orderProto), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "com", "acme", "customer.proto"), []byte(customerProto), 0644))
+		return dir
+	}
+
+	encode := func(t *testing.T, files *protoregistry.Files, messageType string, value string) []byte {
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+		assert.NoError(t, err)
+		msg := dynamicpb.NewMessage(descriptor.(protoreflect.MessageDescriptor))
+		err = protojson.UnmarshalOptions{Resolver: dynamicpb.NewTypes(files)}.Unmarshal([]byte(value), msg)
+		assert.NoError(t, err)
+		data, err := proto.Marshal(msg)
+		assert.NoError(t, err)
+		return data
+	}
+
+	t.Run("deserialize with directory of proto files", func(t *testing.T) {
+		dir := protoDir(t)
+		files, err := LoadProtobufDescriptors(dir)
+		assert.NoError(t, err)
+
+		res, err := NewProtobufDeserializer(dir, "com.acme.Order").
+			Deserialize(encode(t, files, "com.acme.Order", orderJson))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, orderJson, res.Value)
+	})
+
+	t.Run("deserialize with FileDescriptorSet", func(t *testing.T) {
+		files, err := LoadProtobufDescriptors(protoDir(t))
+		assert.NoError(t, err)
+		var descriptorSet descriptorpb.FileDescriptorSet
+		files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+			descriptorSet.File = append(descriptorSet.File, protodesc.ToFileDescriptorProto(file))
+			return true
+		})
+		content, err := proto.Marshal(&descriptorSet)
+		assert.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "orders.binpb")
+		assert.NoError(t, os.WriteFile(path, content, 0644))
+
+		res, err := NewProtobufDeserializer(path, "com.acme.Order").
+			Deserialize(encode(t, files, "com.acme.Order", orderJson))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, orderJson, res.Value)
+	})
+
+	t.Run("no data deserializes to empty string", func(t *testing.T) {
+		res, err := NewProtobufDeserializer(protoDir(t), "com.acme.Order").Deserialize(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, DesData{}, res)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := NewProtobufDeserializer(protoDir(t), "com.acme.Order").Deserialize([]byte{0x0a, 0xff})
+
+		assert.ErrorContains(t, err, "protobuf deserialization of com.acme.Order failed")
+	})
+
+	t.Run("unknown message type", func(t *testing.T) {
+		dir := protoDir(t)
+
+		_, err := NewProtobufDeserializer(dir, "com.acme.Invoice").Deserialize([]byte{0x0a})
+
+		assert.EqualError(t, err, "message type com.acme.Invoice not found in "+dir)
+	})
+
+	t.Run("invalid descriptor set", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "orders.binpb")
+		assert.NoError(t, os.WriteFile(path, []byte("not a descriptor set"), 0644))
+
+		_, err := NewProtobufDeserializer(path, "com.acme.Order").Deserialize([]byte{0x0a})
+
+		assert.ErrorContains(t, err, "unable to load protobuf descriptors")
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 type MockSrAdmin struct {
 	GetSchemaByIdFunc           func(id int) tea.Msg
+	ResolveSchemaSubjectFunc    func(schemaId int, topic string) tea.Msg
 	hardDeleteSubjectCallbackFn func(string) tea.Msg
 	softDeleteSubjectCallbackFn func(string) tea.Msg
 }
@@ -58,6 +59,13 @@ func (m *MockSrAdmin) GetSchemaById(id int) tea.Msg {
 	return nil
 }
 
+func (m *MockSrAdmin) ResolveSchemaSubject(schemaId int, topic string) tea.Msg {
+	if m.ResolveSchemaSubjectFunc != nil {
+		return m.ResolveSchemaSubjectFunc(schemaId, topic)
+	}
+	return nil
+}
+
 func (m *MockSrAdmin) HardDeleteSubject(subject string) tea.Msg {
 	if m.hardDeleteSubjectCallbackFn != nil {
 		return m.hardDeleteSubjectCallbackFn(subject)
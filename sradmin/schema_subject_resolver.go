@@ -0,0 +1,94 @@
+package sradmin
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type SchemaSubjectResolver interface {
+	// ResolveSchemaSubject returns a sradmin.SchemaSubjectResolvingStartedMsg
+	ResolveSchemaSubject(schemaId int, topic string) tea.Msg
+}
+
+type SchemaSubjectResolvingStartedMsg struct {
+	resolved chan SchemaSubjectResolvedMsg
+	err      chan error
+	schemaId int
+}
+
+// SchemaSubjectResolvedMsg holds the subject and version a schema id is registered as
+type SchemaSubjectResolvedMsg struct {
+	SchemaId int
+	Subject  Subject
+	Version  int
+}
+
+type SchemaSubjectResolvingErrMsg struct {
+	SchemaId int
+	Err      error
+}
+
+// AwaitCompletion returns
+// a SchemaSubjectResolvedMsg upon success
+// or SchemaSubjectResolvingErrMsg upon failure.
+func (msg *SchemaSubjectResolvingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case resolved := <-msg.resolved:
+		return resolved
+	case err := <-msg.err:
+		return SchemaSubjectResolvingErrMsg{SchemaId: msg.schemaId, Err: err}
+	}
+}
+
+// ResolveSchemaSubject looks up the subject and version of a schema id. A schema registered under multiple
+// subjects resolves to the value or key subject of the given topic when it is one of them.
+func (s *DefaultSrClient) ResolveSchemaSubject(schemaId int, topic string) tea.Msg {
+	resolvedChan := make(chan SchemaSubjectResolvedMsg)
+	errChan := make(chan error)
+
+	go s.doResolveSchemaSubject(schemaId, topic, resolvedChan, errChan)
+
+	return SchemaSubjectResolvingStartedMsg{resolvedChan, errChan, schemaId}
+}
+
+func (s *DefaultSrClient) doResolveSchemaSubject(
+	schemaId int,
+	topic string,
+	resolvedChan chan SchemaSubjectResolvedMsg,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+
+	pairs, err := s.client.GetSubjectVersionsById(schemaId)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if len(pairs) == 0 {
+		errChan <- fmt.Errorf("schema %d is not registered under any subject", schemaId)
+		return
+	}
+
+	// prefer the subjects of the topic named after the default TopicNameStrategy, the value subject above all
+	pair := pairs[0]
+	for _, subject := range []string{topic + "-key", topic + "-value"} {
+		for _, p := range pairs {
+			if p.Subject == subject {
+				pair = p
+			}
+		}
+	}
+
+	versions, err := s.client.GetSchemaVersions(pair.Subject)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	resolvedChan <- SchemaSubjectResolvedMsg{
+		SchemaId: schemaId,
+		Subject:  Subject{Name: pair.Subject, Versions: versions},
+		Version:  pair.Version,
+	}
+}
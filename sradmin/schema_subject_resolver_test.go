@@ -0,0 +1,48 @@
+package sradmin
+
+import (
+	"fmt"
+	"ktea/config"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSchemaSubject(t *testing.T) {
+	sra := New(&config.SchemaRegistryConfig{
+		Url: fmt.Sprintf("http://localhost:%s", schemaRegistryPort.Port()),
+	})
+
+	v1 := `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	v2 := `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"note","type":["null","string"],"default":null}]}`
+	createSchemas(t, sra, map[string]string{"resolver-orders-value": v1})
+	createSchemas(t, sra, map[string]string{"resolver-orders-value": v2})
+	createSchemas(t, sra, map[string]string{"resolver-archive": v2})
+
+	msg := sra.GetLatestSchemaBySubject("resolver-orders-value")
+	fetching := msg.(FetchingLatestSchemaBySubjectMsg)
+	latest := fetching.AwaitCompletion().(LatestSchemaBySubjectReceived)
+	schemaId, _ := strconv.Atoi(latest.Schema.Id)
+
+	t.Run("resolves to the subject of the topic", func(t *testing.T) {
+		msg := sra.ResolveSchemaSubject(schemaId, "resolver-orders")
+
+		assert.IsType(t, SchemaSubjectResolvingStartedMsg{}, msg)
+		started := msg.(SchemaSubjectResolvingStartedMsg)
+		assert.Equal(t, SchemaSubjectResolvedMsg{
+			SchemaId: schemaId,
+			Subject:  Subject{Name: "resolver-orders-value", Versions: []int{1, 2}},
+			Version:  2,
+		}, started.AwaitCompletion())
+	})
+
+	t.Run("unknown schema id", func(t *testing.T) {
+		msg := sra.ResolveSchemaSubject(999999, "resolver-orders")
+
+		started := msg.(SchemaSubjectResolvingStartedMsg)
+		resolved := started.AwaitCompletion()
+		assert.IsType(t, SchemaSubjectResolvingErrMsg{}, resolved)
+		assert.Equal(t, 999999, resolved.(SchemaSubjectResolvingErrMsg).SchemaId)
+	})
+}
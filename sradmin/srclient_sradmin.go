@@ -29,6 +29,7 @@ type Client interface {
 	GlobalCompatibilityLister
 	LatestSchemaBySubjectFetcher
 	SchemaDeleter
+	SchemaSubjectResolver
 }
 
 type ConnCheckSucceededMsg struct{}
@@ -47,6 +47,7 @@ const ColorMidGrey = "#353533"
 type NotifierStyle struct {
 	Spinner lipgloss.Style
 	Success lipgloss.Style
+	Info    lipgloss.Style
 	Error   lipgloss.Style
 }
 
@@ -285,6 +286,11 @@ func init() {
 			Height(1).
 			Foreground(lipgloss.Color(ColorGreen)).
 			Bold(true)
+		Notifier.Info = lipgloss.NewStyle().
+			MarginTop(0).
+			MarginBottom(0).
+			MarginLeft(2).
+			Height(1)
 		Notifier.Error = lipgloss.NewStyle().
 			MarginTop(0).
 			MarginBottom(0).
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// HexDump renders data as offset, hex and ASCII columns preceded by a ruler with the column offsets.
+// Lines hold 16 bytes, or 8 bytes when the width does not allow 16.
+func HexDump(data []byte, width int) string {
+	bytesPerLine := 16
+	if width > 0 && width < hexDumpWidth(16) {
+		bytesPerLine = 8
+	}
+
+	lines := []string{strings.TrimRight(hexDumpLine("", byteOffsets(bytesPerLine), bytesPerLine), " ")}
+	for start := 0; start < len(data); start += bytesPerLine {
+		chunk := data[start:min(start+bytesPerLine, len(data))]
+		hexes := make([]string, len(chunk))
+		ascii := make([]byte, len(chunk))
+		for i, b := range chunk {
+			hexes[i] = fmt.Sprintf("%02x", b)
+			if b >= 0x20 && b <= 0x7e {
+				ascii[i] = b
+			} else {
+				ascii[i] = '.'
+			}
+		}
+		lines = append(lines, hexDumpLine(fmt.Sprintf("%08x", start), hexes, bytesPerLine)+" |"+string(ascii)+"|")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func byteOffsets(count int) []string {
+	offsets := make([]string, count)
+	for i := range offsets {
+		offsets[i] = fmt.Sprintf("%02x", i)
+	}
+	return offsets
+}
+
+// hexDumpLine lays out the hex columns with an extra space after every 8 bytes
+func hexDumpLine(offset string, hexes []string, bytesPerLine int) string {
+	line := strings.Builder{}
+	line.WriteString(fmt.Sprintf("%-8s  ", offset))
+	for i := 0; i < bytesPerLine; i++ {
+		if i < len(hexes) {
+			line.WriteString(hexes[i])
+		} else {
+			line.WriteString("  ")
+		}
+		line.WriteString(" ")
+		if i%8 == 7 && i < bytesPerLine-1 {
+			line.WriteString(" ")
+		}
+	}
+	return line.String()
+}
+
+func hexDumpWidth(bytesPerLine int) int {
+	// offset, hex columns, group separators and the ASCII column
+	return 10 + bytesPerLine*3 + (bytesPerLine/8 - 1) + 1 + bytesPerLine + 2
+}
+
+// Base64 renders data as standard base64 wrapped at the given width.
+func Base64(data []byte, width int) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if width <= 0 {
+		return encoded
+	}
+	var lines []string
+	for len(encoded) > width {
+		lines = append(lines, encoded[:width])
+		encoded = encoded[width:]
+	}
+	return strings.Join(append(lines, encoded), "\n")
+}
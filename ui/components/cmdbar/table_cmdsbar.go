@@ -57,7 +57,7 @@ func (m *TableCmdsBar[T]) Update(msg tea.Msg, selection *T) (tea.Msg, tea.Cmd) {
 		case "/":
 			return m.handleSlash(msg)
 		case "f2":
-			if selection != nil {
+			if selection != nil && m.deleteCBar != nil {
 				return m.handleF2(selection, msg)
 			}
 			return nil, nil
@@ -89,7 +89,9 @@ func (m *TableCmdsBar[T]) handleSlash(msg tea.Msg) (tea.Msg, tea.Cmd) {
 	active, pmsg, cmd := m.searchCBar.Update(msg)
 	if active {
 		m.activeCBar = m.searchCBar
-		m.deleteCBar.active = false
+		if m.deleteCBar != nil {
+			m.deleteCBar.active = false
+		}
 		if m.sortByCBar != nil {
 			m.sortByCBar.Active = false
 		}
@@ -106,7 +108,9 @@ func (m *TableCmdsBar[T]) handleF3(msg tea.Msg, pmsg tea.Msg, cmd tea.Cmd) (tea.
 	} else {
 		m.activeCBar = m.sortByCBar
 		m.searchCBar.Hide()
-		m.deleteCBar.Hide()
+		if m.deleteCBar != nil {
+			m.deleteCBar.Hide()
+		}
 	}
 	return pmsg, cmd
 }
@@ -151,11 +155,16 @@ func (m *TableCmdsBar[T]) ResetSearch() {
 
 func (m *TableCmdsBar[T]) Hide() {
 	m.searchCBar.state = hidden
-	m.deleteCBar.Hide()
-	m.sortByCBar.Active = false
+	if m.deleteCBar != nil {
+		m.deleteCBar.Hide()
+	}
+	if m.sortByCBar != nil {
+		m.sortByCBar.Active = false
+	}
 	m.activeCBar = nil
 }
 
+// NewTableCmdsBar creates a TableCmdsBar, the deleteCmdBar and sortByCmdBar are optional and can be nil.
 func NewTableCmdsBar[T any](
 	deleteCmdBar *DeleteCmdBar[T],
 	searchCmdBar *SearchCmdBar,
@@ -20,6 +20,7 @@ const (
 	Err      state = 1
 	success  state = 2
 	Spinning state = 3
+	info     state = 4
 )
 
 type Model struct {
@@ -47,6 +48,11 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 			wordwrap.String(m.msg, ktx.WindowWidth),
 			styles.Notifier.Success,
 		)
+	} else if m.State == info {
+		return renderer.RenderWithStyle(
+			wordwrap.String(m.msg, ktx.WindowWidth),
+			styles.Notifier.Info,
+		)
 	} else if m.State == Err {
 		return renderer.RenderWithStyle(
 			wordwrap.String(m.msg, ktx.WindowWidth),
@@ -121,6 +127,13 @@ func (m *Model) ShowSuccessMsg(msg string) tea.Cmd {
 	return nil
 }
 
+func (m *Model) ShowInfoMsg(msg string) tea.Cmd {
+	m.autoHide.Store(false)
+	m.State = info
+	m.msg = "👀 " + msg
+	return nil
+}
+
 func (m *Model) Idle() {
 	m.autoHide.Store(false)
 	m.State = idle
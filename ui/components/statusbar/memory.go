@@ -0,0 +1,32 @@
+package statusbar
+
+import (
+	"fmt"
+	"runtime/metrics"
+)
+
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// HeapInUse returns the number of bytes occupied by live and not yet swept heap objects.
+// Unlike runtime.ReadMemStats it does not stop the world, so it is cheap enough to read on every render.
+func HeapInUse() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
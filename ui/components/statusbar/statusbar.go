@@ -11,8 +11,11 @@ import (
 type Model struct {
 	provider      Provider
 	showShortcuts bool
+	memoryUsage   func() uint64
 }
 
+type Option func(m *Model)
+
 type Provider interface {
 	Shortcuts() []Shortcut
 	Title() string
@@ -132,13 +135,22 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 		leftover--
 	}
 
+	var memory string
+	if m.memoryUsage != nil {
+		memory = "MEM " + formatBytes(m.memoryUsage()) + " "
+		// only show memory usage when there is room for it
+		if lg.Width(memory)+1 > leftover {
+			memory = ""
+		}
+	}
+
 	barView := lg.NewStyle().
 		MarginTop(1).
 		MarginBottom(1).
 		Render(lg.JoinHorizontal(lg.Top,
 			activeCluster,
 			indicator,
-			styles.Statusbar.Spacer.Width(leftover).Render(""),
+			styles.Statusbar.Spacer.Width(leftover).Align(lg.Right).Render(memory),
 			renderText(endSeparator, styles.ColorMidGrey),
 		))
 
@@ -170,6 +182,17 @@ func (m *Model) SetProvider(provider Provider) {
 	m.provider = provider
 }
 
-func New() *Model {
-	return &Model{showShortcuts: false}
+// WithMemoryUsage displays the memory usage as reported by the given function.
+func WithMemoryUsage(memoryUsage func() uint64) Option {
+	return func(m *Model) {
+		m.memoryUsage = memoryUsage
+	}
+}
+
+func New(options ...Option) *Model {
+	m := &Model{showShortcuts: false}
+	for _, option := range options {
+		option(m)
+	}
+	return m
 }
@@ -122,4 +122,25 @@ func TestStatusbar(t *testing.T) {
 		assert.NotContains(t, render, "\uE0B0")
 		assert.NotContains(t, render, "\uE0B6")
 	})
+
+	t.Run("memory usage", func(t *testing.T) {
+		sb := statusbar.New(statusbar.WithMemoryUsage(func() uint64 {
+			return 157286400
+		}))
+		sb.SetProvider(TestProvider{})
+
+		render := sb.View(tests.NewKontext(
+			tests.WithWindowWidth(60),
+			tests.WithConfig(&config.Config{})), tests.Renderer)
+
+		assert.Contains(t, render, "MEM 150.0 MiB")
+
+		t.Run("hidden when there is no room", func(t *testing.T) {
+			render := sb.View(tests.NewKontext(
+				tests.WithWindowWidth(30),
+				tests.WithConfig(&config.Config{})), tests.Renderer)
+
+			assert.NotContains(t, render, "MEM")
+		})
+	})
 }
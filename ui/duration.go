@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// CompactDuration renders a duration in at most two units, e.g. 45s, 12m, 3h5m or 2d4h.
+func CompactDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return compactUnits(int(d.Hours()), "h", int(d.Minutes())%60, "m")
+	default:
+		return compactUnits(int(d.Hours())/24, "d", int(d.Hours())%24, "h")
+	}
+}
+
+func compactUnits(major int, majorUnit string, minor int, minorUnit string) string {
+	if minor == 0 {
+		return fmt.Sprintf("%d%s", major, majorUnit)
+	}
+	return fmt.Sprintf("%d%s%d%s", major, majorUnit, minor, minorUnit)
+}
@@ -0,0 +1,104 @@
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultEditor = "vi"
+
+// Editor suspends the program to open content in the editor of the user, $VISUAL or $EDITOR.
+type Editor interface {
+	// Edit opens content in a temporary file with the given extension and reports the edited content
+	// through an EditedMsg tagged with the given tag once the editor exits.
+	Edit(tag string, content string, extension string) tea.Cmd
+	// View opens content in a read-only temporary file and reports a ViewedMsg once the editor exits.
+	View(content string, extension string) tea.Cmd
+}
+
+type EditedMsg struct {
+	// Tag identifies what was edited
+	Tag     string
+	Content string
+	Err     error
+}
+
+type ViewedMsg struct {
+	Err error
+}
+
+type DefaultEditor struct {
+}
+
+func (e *DefaultEditor) Edit(tag string, content string, extension string) tea.Cmd {
+	file, err := writeTempFile(content, extension, 0o600)
+	if err != nil {
+		return func() tea.Msg {
+			return EditedMsg{Tag: tag, Err: err}
+		}
+	}
+
+	return tea.ExecProcess(command(file), func(err error) tea.Msg {
+		defer os.Remove(file)
+		if err != nil {
+			return EditedMsg{Tag: tag, Err: err}
+		}
+		edited, err := os.ReadFile(file)
+		if err != nil {
+			return EditedMsg{Tag: tag, Err: err}
+		}
+		// editors end the last line with a newline
+		return EditedMsg{Tag: tag, Content: strings.TrimSuffix(string(edited), "\n")}
+	})
+}
+
+func (e *DefaultEditor) View(content string, extension string) tea.Cmd {
+	file, err := writeTempFile(content, extension, 0o400)
+	if err != nil {
+		return func() tea.Msg {
+			return ViewedMsg{Err: err}
+		}
+	}
+
+	return tea.ExecProcess(command(file), func(err error) tea.Msg {
+		os.Remove(file)
+		return ViewedMsg{Err: err}
+	})
+}
+
+func writeTempFile(content string, extension string, perm os.FileMode) (string, error) {
+	file, err := os.CreateTemp("", "ktea-*."+extension)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Chmod(perm); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// command creates the command to open the file with, $VISUAL takes precedence over $EDITOR
+func command(file string) *exec.Cmd {
+	editor := []string{defaultEditor}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			editor = fields
+			break
+		}
+	}
+	return exec.Command(editor[0], append(editor[1:], file)...)
+}
+
+func New() Editor {
+	return &DefaultEditor{}
+}
@@ -0,0 +1,30 @@
+package editor
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type EditFunc func(tag string, content string, extension string) tea.Cmd
+
+type ViewFunc func(content string, extension string) tea.Cmd
+
+type MockEditor struct {
+	EditFunc EditFunc
+	ViewFunc ViewFunc
+}
+
+func (m *MockEditor) Edit(tag string, content string, extension string) tea.Cmd {
+	if m.EditFunc != nil {
+		return m.EditFunc(tag, content, extension)
+	}
+	return nil
+}
+
+func (m *MockEditor) View(content string, extension string) tea.Cmd {
+	if m.ViewFunc != nil {
+		return m.ViewFunc(content, extension)
+	}
+	return nil
+}
+
+func NewMock() *MockEditor {
+	return &MockEditor{}
+}
@@ -18,6 +18,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,6 +35,7 @@ const (
 
 type Model struct {
 	lister        kadmin.CGroupLister
+	timeLagReader kadmin.TimeLagReader
 	table         table.Model
 	border        *border.Model
 	tcb           *cmdbar.TableCmdsBar[string]
@@ -43,6 +45,10 @@ type Model struct {
 	sort          cmdbar.SortLabel
 	state         state
 	goToTop       bool
+	// maxTimeLags are the largest time lags per group, a group is absent until read
+	maxTimeLags    map[string]time.Duration
+	timeLagQueue   []string
+	timeLagReading string
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -51,8 +57,11 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	views = append(views, cmdBarView)
 
 	m.table.SetColumns([]table.Column{
-		{m.columnTitle("Consumer Group"), int(float64(ktx.WindowWidth-5) * 0.7)},
-		{m.columnTitle("Members"), int(float64(ktx.WindowWidth-5) * 0.3)},
+		{m.columnTitle("Consumer Group"), int(float64(ktx.WindowWidth-11) * 0.34)},
+		{m.columnTitle("Members"), int(float64(ktx.WindowWidth-11) * 0.12)},
+		{"State", int(float64(ktx.WindowWidth-11) * 0.15)},
+		{"Assignor", int(float64(ktx.WindowWidth-11) * 0.22)},
+		{m.columnTitle("Behind By"), int(float64(ktx.WindowWidth-11) * 0.17)},
 	})
 	m.table.SetRows(m.rows)
 	m.table.SetWidth(ktx.WindowWidth - 2)
@@ -96,6 +105,18 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.state = stateLoaded
 		m.groups = msg.ConsumerGroups
 		m.tcb.ResetSearch()
+		m.maxTimeLags = make(map[string]time.Duration)
+	case groupTimeLagReadMsg:
+		if msg.err != nil {
+			m.maxTimeLags[msg.group] = unknownTimeLag
+		} else {
+			m.maxTimeLags[msg.group] = maxTimeLag(msg.timeLags)
+		}
+		if msg.group == m.timeLagReading {
+			m.timeLagReading = ""
+		}
+	case nav.LoadCGroupsPageMsg, ui.RegainedFocusMsg:
+		cmds = append(cmds, m.resumeTimeLagReading())
 	case kadmin.CGroupDeletedMsg:
 		for i, group := range m.groups {
 			if group.Name == msg.GroupName {
@@ -124,6 +145,12 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.goToTop = true
 	}
 
+	// a read in progress continues with the groups in view once done
+	m.queueVisibleTimeLags()
+	if m.timeLagReading == "" {
+		cmds = append(cmds, m.readNextTimeLag())
+	}
+
 	return tea.Batch(cmds...)
 }
 
@@ -163,6 +190,8 @@ func (m *Model) createRows() []table.Row {
 				return partitionI < partitionJ
 			}
 			return partitionI > partitionJ
+		case "Behind By":
+			return m.compareTimeLags(rows[i][0], rows[j][0]) < 0
 		default:
 			panic(fmt.Sprintf("unexpected sort label: %s", m.sort.Label))
 		}
@@ -176,6 +205,9 @@ func (m *Model) appendGroupToRows(rows []table.Row, group *kadmin.ConsumerGroup)
 		table.Row{
 			group.Name,
 			strconv.Itoa(len(group.Members)),
+			group.DisplayState(),
+			group.Protocol,
+			m.timeLagValue(group.Name),
 		},
 	)
 	return rows
@@ -213,9 +245,12 @@ func (m *Model) Title() string {
 func New(
 	lister kadmin.CGroupLister,
 	deleter kadmin.CGroupDeleter,
+	timeLagReader kadmin.TimeLagReader,
 ) (*Model, tea.Cmd) {
 	m := &Model{}
 	m.lister = lister
+	m.timeLagReader = timeLagReader
+	m.maxTimeLags = make(map[string]time.Duration)
 
 	// Use ktable.NewDefaultTable() instead of direct initialization
 	t := ktable.NewDefaultTable()
@@ -316,6 +351,10 @@ func New(
 				Label:     "Members",
 				Direction: cmdbar.Desc,
 			},
+			{
+				Label:     "Behind By",
+				Direction: cmdbar.Desc,
+			},
 		},
 		cmdbar.WithSortSelectedCallback(func(label cmdbar.SortLabel) {
 			m.sort = label
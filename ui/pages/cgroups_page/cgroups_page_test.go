@@ -1,12 +1,15 @@
 package cgroups_page
 
 import (
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"ktea/kadmin"
 	"ktea/tests"
+	"ktea/ui"
 	"strings"
 	"testing"
+	"time"
 )
 
 type MockCGroupLister struct {
@@ -25,9 +28,16 @@ func (m MockCGroupDeleter) DeleteCGroup(name string) tea.Msg {
 	return MockCGroupDeletionStartedMsg{}
 }
 
+type MockTimeLagReader struct {
+}
+
+func (m MockTimeLagReader) ReadTimeLag(_ string) tea.Msg {
+	return nil
+}
+
 func TestCgroupsPage(t *testing.T) {
 	t.Run("Default sort by Consumer Group Asc", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -59,8 +69,28 @@ func TestCgroupsPage(t *testing.T) {
 		assert.Less(t, g2Idx, g3Idx)
 	})
 
+	t.Run("Show the state and assignor of every group", func(t *testing.T) {
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
+
+		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
+			ConsumerGroups: []*kadmin.ConsumerGroup{
+				{Name: "group1", State: "Stable", Protocol: "range"},
+				{Name: "group2", State: "PreparingRebalance", Protocol: "cooperative-sticky"},
+				{Name: "group3", State: "Empty"},
+				{Name: "group4", State: "Dead"},
+			},
+		})
+
+		render := page.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Regexp(t, `group1\s+0\s+Stable\s+range`, render)
+		assert.Regexp(t, `group2\s+0\s+Rebalancing\s+cooperative-sticky`, render)
+		assert.Regexp(t, `group3\s+0\s+Empty`, render)
+		assert.Regexp(t, `group4\s+0\s+Dead`, render)
+	})
+
 	t.Run("Toggle sort by Consumer Group Desc", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -95,7 +125,7 @@ func TestCgroupsPage(t *testing.T) {
 	})
 
 	t.Run("Toggle sort by Members", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -177,7 +207,7 @@ func TestCgroupsPage(t *testing.T) {
 	})
 
 	t.Run("Delete consumer group", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -242,7 +272,7 @@ func TestCgroupsPage(t *testing.T) {
 	})
 
 	t.Run("When groups are loaded or refresh then the search form is reset", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -289,7 +319,7 @@ func TestCgroupsPage(t *testing.T) {
 	})
 
 	t.Run("Searching resets selected row to top row", func(t *testing.T) {
-		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{})
+		page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, &MockTimeLagReader{})
 
 		_ = page.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -322,4 +352,161 @@ func TestCgroupsPage(t *testing.T) {
 		page.View(tests.NewKontext(), tests.Renderer)
 		assert.Equal(t, "group1", page.table.SelectedRow()[0])
 	})
+	t.Run("Behind By", func(t *testing.T) {
+		listed := func(reader kadmin.TimeLagReader) (*Model, tea.Cmd) {
+			page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, reader)
+			cmd := page.Update(kadmin.ConsumerGroupsListedMsg{
+				ConsumerGroups: []*kadmin.ConsumerGroup{
+					{Name: "group1", Members: []kadmin.GroupMember{}},
+					{Name: "group2", Members: []kadmin.GroupMember{}},
+					{Name: "group3", Members: []kadmin.GroupMember{}},
+				},
+			})
+			return page, cmd
+		}
+
+		t.Run("shows the largest time lag of every group", func(t *testing.T) {
+			page, _ := listed(&MockTimeLagReader{})
+
+			page.Update(groupTimeLagReadMsg{
+				group: "group1",
+				timeLags: []kadmin.PartitionTimeLag{
+					{Topic: "topic-1", Partition: 0, Lag: 2 * time.Minute},
+					{Topic: "topic-1", Partition: 1, Lag: 12 * time.Minute},
+					{Topic: "topic-2", Partition: 0, Lag: time.Duration(kadmin.ErrorValue)},
+				},
+			})
+			page.Update(groupTimeLagReadMsg{group: "group2", err: fmt.Errorf("unable to fetch")})
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, `group1\s+0\s+Unknown\s+12m`, render)
+			assert.Regexp(t, `group2\s+0\s+Unknown\s+N/A`, render)
+			assert.Regexp(t, `group3\s+0\s+Unknown\s+…`, render)
+		})
+
+		t.Run("caught up when no partition lags", func(t *testing.T) {
+			page, _ := listed(&MockTimeLagReader{})
+
+			page.Update(groupTimeLagReadMsg{
+				group:    "group1",
+				timeLags: []kadmin.PartitionTimeLag{{Topic: "topic-1", Partition: 0, Lag: 0}},
+			})
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, `group1\s+0\s+Unknown\s+caught up`, render)
+		})
+
+		t.Run("reads one group at a time", func(t *testing.T) {
+			reader := &recordingTimeLagReader{lag: 3 * time.Minute}
+			page, cmd := listed(reader)
+
+			msg := cmd()
+			assert.Equal(t, groupTimeLagReadMsg{
+				group:    "group1",
+				timeLags: []kadmin.PartitionTimeLag{{Topic: "topic-1", Lag: 3 * time.Minute}},
+			}, msg)
+			assert.Equal(t, []string{"group1"}, reader.groups)
+
+			cmd = page.Update(msg)
+			cmd()
+			assert.Equal(t, []string{"group1", "group2"}, reader.groups)
+		})
+
+		t.Run("only reads the groups in view", func(t *testing.T) {
+			reader := &recordingTimeLagReader{}
+			page, _ := New(&MockCGroupLister{}, &MockCGroupDeleter{}, reader)
+			var groups []*kadmin.ConsumerGroup
+			for i := 0; i < 500; i++ {
+				groups = append(groups, &kadmin.ConsumerGroup{Name: fmt.Sprintf("group%03d", i), Members: []kadmin.GroupMember{}})
+			}
+			readAll := func(cmd tea.Cmd) {
+				for cmd != nil {
+					var next []tea.Cmd
+					for _, msg := range tests.ExecuteBatchCmd(cmd) {
+						if _, ok := msg.(groupTimeLagReadMsg); ok {
+							next = append(next, page.Update(msg))
+						}
+					}
+					cmd = tea.Batch(next...)
+				}
+			}
+
+			cmd := page.Update(kadmin.ConsumerGroupsListedMsg{ConsumerGroups: groups})
+			page.View(tests.NewKontext(), tests.Renderer)
+			readAll(cmd)
+
+			assert.Contains(t, reader.groups, "group000")
+			assert.NotContains(t, reader.groups, "group499")
+			assert.Less(t, len(reader.groups), 100)
+
+			read := len(reader.groups)
+			readAll(page.Update(tests.Key(tea.KeyEnd)))
+
+			assert.Equal(t, "group499", reader.groups[read], "Expected the selected group first")
+			assert.NotContains(t, reader.groups[read:], "group000")
+		})
+
+		t.Run("results of a previous listing do not advance", func(t *testing.T) {
+			page, _ := listed(&MockTimeLagReader{})
+
+			assert.Nil(t, page.Update(groupTimeLagReadMsg{group: "group2"}))
+		})
+
+		t.Run("resumes when regaining focus", func(t *testing.T) {
+			reader := &recordingTimeLagReader{}
+			page, _ := listed(reader)
+
+			cmd := page.Update(ui.RegainedFocusMsg{})
+			cmd()
+
+			assert.Equal(t, []string{"group1"}, reader.groups)
+		})
+
+		t.Run("sort by Behind By", func(t *testing.T) {
+			page, _ := listed(&MockTimeLagReader{})
+			page.Update(groupTimeLagReadMsg{
+				group:    "group1",
+				timeLags: []kadmin.PartitionTimeLag{{Topic: "topic-1", Lag: time.Minute}},
+			})
+			page.Update(groupTimeLagReadMsg{
+				group:    "group3",
+				timeLags: []kadmin.PartitionTimeLag{{Topic: "topic-1", Lag: time.Hour}},
+			})
+
+			page.Update(tests.Key(tea.KeyF3))
+			page.Update(tests.Key(tea.KeyRight))
+			page.Update(tests.Key(tea.KeyRight))
+			page.Update(tests.Key(tea.KeyEnter))
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "▼ Behind By")
+			g1Idx := strings.Index(render, "group1")
+			g2Idx := strings.Index(render, "group2")
+			g3Idx := strings.Index(render, "group3")
+			assert.Less(t, g3Idx, g1Idx)
+			assert.Less(t, g1Idx, g2Idx, "Expected groups without time lag last")
+
+			page.Update(tests.Key(tea.KeyEnter))
+			render = page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "▲ Behind By")
+			g1Idx = strings.Index(render, "group1")
+			g2Idx = strings.Index(render, "group2")
+			g3Idx = strings.Index(render, "group3")
+			assert.Less(t, g1Idx, g3Idx)
+			assert.Less(t, g3Idx, g2Idx, "Expected groups without time lag last")
+		})
+	})
+}
+
+type recordingTimeLagReader struct {
+	groups []string
+	lag    time.Duration
+}
+
+func (r *recordingTimeLagReader) ReadTimeLag(group string) tea.Msg {
+	r.groups = append(r.groups, group)
+	timeLags := make(chan []kadmin.PartitionTimeLag, 1)
+	timeLags <- []kadmin.PartitionTimeLag{{Topic: "topic-1", Lag: r.lag}}
+	return kadmin.TimeLagReadingStartedMsg{Group: group, TimeLags: timeLags, Err: make(chan error)}
 }
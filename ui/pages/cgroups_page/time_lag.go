@@ -0,0 +1,127 @@
+package cgroups_page
+
+import (
+	"cmp"
+	"fmt"
+	"ktea/kadmin"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// unknownTimeLag is the time lag of groups of which no partition's time lag could be read
+const unknownTimeLag = time.Duration(kadmin.ErrorValue)
+
+// groupTimeLagReadMsg holds the time lag of every partition of a group
+type groupTimeLagReadMsg struct {
+	group    string
+	timeLags []kadmin.PartitionTimeLag
+	err      error
+}
+
+// maxTimeLag returns how far the group is behind on the partition it lags the most on
+func maxTimeLag(timeLags []kadmin.PartitionTimeLag) time.Duration {
+	maxLag := unknownTimeLag
+	for _, timeLag := range timeLags {
+		if timeLag.Known() {
+			maxLag = max(maxLag, timeLag.Lag)
+		}
+	}
+	return maxLag
+}
+
+// readNextTimeLag reads the time lag of the next queued group,
+// groups are read one at a time as every partition's records are fetched.
+func (m *Model) readNextTimeLag() tea.Cmd {
+	if len(m.timeLagQueue) == 0 {
+		m.timeLagReading = ""
+		return nil
+	}
+	m.timeLagReading, m.timeLagQueue = m.timeLagQueue[0], m.timeLagQueue[1:]
+	return m.readTimeLag(m.timeLagReading)
+}
+
+// readTimeLag reads the time lag of the group without notifying, only the outcome is returned
+func (m *Model) readTimeLag(group string) tea.Cmd {
+	reader := m.timeLagReader
+	return func() tea.Msg {
+		started, ok := reader.ReadTimeLag(group).(kadmin.TimeLagReadingStartedMsg)
+		if !ok {
+			return groupTimeLagReadMsg{group: group, err: fmt.Errorf("unable to read the time lag of %s", group)}
+		}
+		switch msg := started.AwaitCompletion().(type) {
+		case kadmin.TimeLagReadMsg:
+			return groupTimeLagReadMsg{group: group, timeLags: msg.TimeLags}
+		case kadmin.TimeLagReadingErrMsg:
+			return groupTimeLagReadMsg{group: group, err: msg.Err}
+		}
+		return groupTimeLagReadMsg{group: group, err: fmt.Errorf("unable to read the time lag of %s", group)}
+	}
+}
+
+// queueVisibleTimeLags queues the groups in view of which the time lag was not read yet, the selected group first.
+// Groups scrolled out of view are dropped from the queue, they are read once they are in view again.
+func (m *Model) queueVisibleTimeLags() {
+	m.timeLagQueue = nil
+	if len(m.rows) == 0 {
+		return
+	}
+	cursor := max(min(m.table.Cursor(), len(m.rows)-1), 0)
+	// the table keeps the cursor in view, the rows in view lie within a table height of it
+	height := max(m.table.Height(), 1)
+	m.queueTimeLag(m.rows[cursor][0])
+	for _, row := range m.rows[max(cursor-height+1, 0):min(cursor+height, len(m.rows))] {
+		m.queueTimeLag(row[0])
+	}
+}
+
+func (m *Model) queueTimeLag(group string) {
+	if _, read := m.maxTimeLags[group]; read || group == m.timeLagReading || slices.Contains(m.timeLagQueue, group) {
+		return
+	}
+	m.timeLagQueue = append(m.timeLagQueue, group)
+}
+
+// resumeTimeLagReading reads the group being read again, its outcome is lost when another page was active
+func (m *Model) resumeTimeLagReading() tea.Cmd {
+	if m.timeLagReading == "" {
+		return nil
+	}
+	return m.readTimeLag(m.timeLagReading)
+}
+
+func (m *Model) timeLagValue(group string) string {
+	timeLag, ok := m.maxTimeLags[group]
+	switch {
+	case !ok:
+		return "…"
+	case timeLag == unknownTimeLag:
+		return "N/A"
+	case timeLag == 0:
+		return "caught up"
+	}
+	return ui.CompactDuration(timeLag)
+}
+
+// compareTimeLags orders groups by their time lag, groups of which it is not known yet or unknown sort last
+func (m *Model) compareTimeLags(a string, b string) int {
+	aLag, aOk := m.maxTimeLags[a]
+	bLag, bOk := m.maxTimeLags[b]
+	aKnown := aOk && aLag != unknownTimeLag
+	bKnown := bOk && bLag != unknownTimeLag
+	switch {
+	case aKnown != bKnown && aKnown:
+		return -1
+	case aKnown != bKnown:
+		return 1
+	case !aKnown:
+		return 0
+	case m.sort.Direction == cmdbar.Asc:
+		return cmp.Compare(aLag, bLag)
+	default:
+		return cmp.Compare(bLag, aLag)
+	}
+}
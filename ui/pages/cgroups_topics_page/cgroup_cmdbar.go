@@ -11,6 +11,8 @@ import (
 type CGroupCmdbar[T any] struct {
 	searchWidget     cmdbar.CmdBar
 	notifierWidget   cmdbar.CmdBar
+	deleteWidget     *cmdbar.DeleteCmdBar[T]
+	sortByWidget     *cmdbar.SortByCmdBar
 	active           cmdbar.CmdBar
 	searchPrevActive bool
 }
@@ -24,7 +26,8 @@ func (m *CGroupCmdbar[T]) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) s
 	return ""
 }
 
-func (m *CGroupCmdbar[T]) Update(msg tea.Msg) (tea.Msg, tea.Cmd) {
+// Update handles msg, the selection is what gets deleted when F2 is pressed, nil when nothing can be deleted
+func (m *CGroupCmdbar[T]) Update(msg tea.Msg, selection *T) (tea.Msg, tea.Cmd) {
 	// when the notifier is active and has priority (because of a loading spinner) it should handle all msgs
 	if m.active == m.notifierWidget {
 		if m.notifierWidget.(*cmdbar.NotifierCmdBar).Notifier.HasPriority() {
@@ -41,6 +44,8 @@ func (m *CGroupCmdbar[T]) Update(msg tea.Msg) (tea.Msg, tea.Cmd) {
 	active, pmsg, cmd := m.notifierWidget.Update(msg)
 	if active && pmsg == nil {
 		m.active = m.notifierWidget
+		m.deleteWidget.Hide()
+		m.sortByWidget.Active = false
 		return msg, cmd
 	}
 
@@ -59,6 +64,34 @@ func (m *CGroupCmdbar[T]) Update(msg tea.Msg) (tea.Msg, tea.Cmd) {
 				m.active = nil
 			}
 			return pmsg, cmd
+		case "f2":
+			if selection == nil {
+				return nil, nil
+			}
+			active, pmsg, cmd := m.deleteWidget.Update(msg)
+			if active {
+				m.active = m.deleteWidget
+				m.deleteWidget.Delete(*selection)
+				m.sortByWidget.Active = false
+			} else if m.searchPrevActive {
+				m.searchPrevActive = false
+				m.active = m.searchWidget
+			} else {
+				m.active = nil
+			}
+			return pmsg, cmd
+		case "f3":
+			active, pmsg, cmd := m.sortByWidget.Update(msg)
+			if active {
+				m.active = m.sortByWidget
+				m.deleteWidget.Hide()
+			} else if m.searchPrevActive {
+				m.searchPrevActive = false
+				m.active = m.searchWidget
+			} else {
+				m.active = nil
+			}
+			return pmsg, cmd
 		}
 	}
 
@@ -103,10 +136,14 @@ func (m *CGroupCmdbar[T]) Shortcuts() []statusbar.Shortcut {
 func NewCGroupCmdbar[T any](
 	searchCmdBar *cmdbar.SearchCmdBar,
 	notifierCmdBar *cmdbar.NotifierCmdBar,
+	deleteCmdBar *cmdbar.DeleteCmdBar[T],
+	sortByCmdBar *cmdbar.SortByCmdBar,
 ) *CGroupCmdbar[T] {
 	return &CGroupCmdbar[T]{
 		searchCmdBar,
 		notifierCmdBar,
+		deleteCmdBar,
+		sortByCmdBar,
 		notifierCmdBar,
 		false,
 	}
@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 
@@ -41,6 +42,8 @@ const (
 
 type Model struct {
 	lister            kadmin.OffsetLister
+	describer         kadmin.CGroupDescriber
+	timeLagReader     kadmin.TimeLagReader
 	tableFocus        tableFocus
 	topicsTable       table.Model
 	offsetsTable      table.Model
@@ -52,13 +55,37 @@ type Model struct {
 	totalLag          int64
 	groupName         string
 	topicByPartOffset map[string][]partOffset
-	cmdBar            *CGroupCmdbar[string]
+	cmdBar            *CGroupCmdbar[offsetsDeletion]
 	offsets           []kadmin.TopicPartitionOffset
 	state             state
+	// group holds the state, coordinator and members of the group, nil until described
+	group         *kadmin.ConsumerGroup
+	showMembers   bool
+	membersTable  table.Model
+	membersBorder *border.Model
+	memberRows    []table.Row
+	// markedPartitions are the partitions of the selected topic marked for deletion
+	markedPartitions map[int32]bool
+	markedTopic      string
+	// history holds the lag sampled in the background every samplingInterval
+	history          *lagHistory
+	sampling         *samplingRun
+	samplingInterval time.Duration
+	// timeLags are the time lags by partition, nil until read
+	timeLags map[string]kadmin.PartitionTimeLag
+	sort     cmdbar.SortLabel
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 
+	if m.showMembers {
+		return ui.JoinVertical(lg.Left,
+			m.cmdBar.View(ktx, renderer),
+			m.detailsView(),
+			m.membersView(ktx, renderer),
+		)
+	}
+
 	if m.state == stateNoOffsets {
 		return styles.
 			CenterText(ktx.WindowWidth, ktx.AvailableHeight).
@@ -68,24 +95,29 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	cmdBarView := m.cmdBar.View(ktx, renderer)
 
 	halfWidth := int(float64(ktx.WindowWidth / 2))
+	// the offsets table gets the larger part as it holds the most columns
+	topicsWidth := int(float64(ktx.WindowWidth) * 0.4)
+	offsetsWidth := 2*halfWidth - topicsWidth
 	m.topicsTable.SetHeight(ktx.AvailableTableHeight())
-	m.topicsTable.SetWidth(int(float64(halfWidth)))
+	m.topicsTable.SetWidth(topicsWidth)
 	m.topicsTable.SetColumns([]table.Column{
-		{Title: "Topic Name", Width: int(float64(halfWidth - 2))},
+		{Title: "Topic Name", Width: topicsWidth - 2},
 	})
 	m.topicsTable.SetRows(m.topicsRows)
 
-	partitionColumnWidth := int(float64(halfWidth-4) * 0.22)
-	offsetColumnWidth := int(float64(halfWidth-4) * 0.24)
-	hwmColumnWidth := int(float64(halfWidth-4) * 0.24)
-	lagColumnWidth := int(float64(halfWidth-4) * 0.22)
+	partitionColumnWidth := int(float64(offsetsWidth-4) * 0.22)
+	offsetColumnWidth := int(float64(offsetsWidth-4) * 0.15)
+	hwmColumnWidth := int(float64(offsetsWidth-4) * 0.2)
+	lagColumnWidth := int(float64(offsetsWidth-4) * 0.14)
+	timeLagColumnWidth := int(float64(offsetsWidth-4) * 0.22)
 
 	m.offsetsTable.SetHeight(ktx.AvailableTableHeight())
 	m.offsetsTable.SetColumns([]table.Column{
-		{Title: "Partition", Width: partitionColumnWidth},
+		{Title: m.columnTitle("Partition"), Width: partitionColumnWidth},
 		{Title: "Offset", Width: offsetColumnWidth},
 		{Title: "High Watermark", Width: hwmColumnWidth},
 		{Title: "Lag", Width: lagColumnWidth},
+		{Title: m.columnTitle("Behind By"), Width: timeLagColumnWidth},
 	})
 	m.offsetsTable.SetRows(m.offsetRows)
 
@@ -105,6 +137,8 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 
 	return ui.JoinVertical(lg.Left,
 		cmdBarView,
+		m.detailsView(),
+		m.trendView(),
 		lg.JoinHorizontal(
 			lg.Top,
 			[]string{
@@ -116,10 +150,11 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 }
 
 type partOffset struct {
-	partition string
+	partition int32
 	offset    int64
 	hwm       int64
 	lag       int64
+	timeLag   *kadmin.PartitionTimeLag
 }
 
 func (partOffset *partOffset) getHwmValue() string {
@@ -154,39 +189,97 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			}
 		case "f5":
 			m.state = stateOffsetsLoading
-			return func() tea.Msg {
-				return m.lister.ListOffsets(m.groupName)
+			m.timeLags = nil
+			return tea.Batch(m.listOffsets, m.describeGroup, m.readTimeLag)
+		case "f6":
+			// only accept when the table is focussed
+			if !m.cmdBar.IsFocussed() {
+				m.showMembers = !m.showMembers
+				m.membersTable.GotoTop()
+				return nil
 			}
 		case "tab":
 			// only accept when the table is focussed
-			if !m.cmdBar.IsFocussed() {
+			if !m.cmdBar.IsFocussed() && !m.showMembers {
 				if m.tableFocus == topicFocus {
 					m.tableFocus = offsetFocus
+					// the cursor is only valid once the offsets table has rows
+					m.offsetsTable.GotoTop()
 				} else {
 					m.tableFocus = topicFocus
 				}
 			}
+		case " ":
+			// only accept when the offsets table is focussed
+			if !m.cmdBar.IsFocussed() && !m.showMembers && m.tableFocus == offsetFocus {
+				m.toggleMark()
+				m.recreateOffsetRows()
+				return nil
+			}
 		}
 	case kadmin.OffsetListingStartedMsg:
 		cmds = append(cmds, msg.AwaitCompletion)
 	case kadmin.OffsetListedMsg:
-		if msg.Offsets == nil {
-			m.state = stateNoOffsets
-		} else {
-			m.state = stateOffsetsLoaded
-			m.offsets = msg.Offsets
+		m.history.record(time.Now(), msg.Offsets)
+		m.offsetsListed(msg.Offsets)
+	case lagSamplingTickMsg:
+		if msg.run != m.sampling {
+			return nil
+		}
+		return m.sampleLag()
+	case lagSampledMsg:
+		if msg.run != m.sampling {
+			return nil
+		}
+		// a failed sample is skipped, the next one might succeed
+		if msg.err == nil {
+			m.history.record(msg.at, msg.offsets)
+			m.offsetsListed(msg.offsets)
+		}
+		cmds = append(cmds, m.scheduleSample())
+	case kadmin.TimeLagReadingStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.TimeLagReadMsg:
+		if msg.Group == m.groupName {
+			m.timeLags = make(map[string]kadmin.PartitionTimeLag)
+			for _, timeLag := range msg.TimeLags {
+				m.timeLags[partitionKey(timeLag.Topic, timeLag.Partition)] = timeLag
+			}
+		}
+	case kadmin.TimeLagReadingErrMsg:
+		if msg.Group == m.groupName {
+			// no partition's time lag is known
+			m.timeLags = make(map[string]kadmin.PartitionTimeLag)
+		}
+	case ui.RegainedFocusMsg:
+		// ticks are lost while another tab is active
+		cmds = append(cmds, m.startSampling())
+	case kadmin.CGroupDescribingStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.CGroupDescribedMsg:
+		m.group = msg.Group
+	case kadmin.CGroupOffsetsDeletionStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.CGroupOffsetsDeletedMsg:
+		m.removeOffsets(msg.Topic, msg.Partitions)
+	case kadmin.CGroupOffsetsDeletionErrMsg:
+		// partitions deleted before the failure are gone nonetheless
+		if len(msg.Deleted) > 0 {
+			m.removeOffsets(msg.Topic, msg.Deleted)
 		}
 	}
 
 	var cmd tea.Cmd
-	msg, cmd = m.cmdBar.Update(msg)
+	msg, cmd = m.cmdBar.Update(msg, m.deletionSelection())
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
 
 	// make sure table navigation is off when the cmdbar is focussed
 	if !m.cmdBar.IsFocussed() {
-		if m.tableFocus == topicFocus {
+		if m.showMembers {
+			m.membersTable, cmd = m.membersTable.Update(msg)
+		} else if m.tableFocus == topicFocus {
 			m.topicsTable, cmd = m.topicsTable.Update(msg)
 			m.offsetsTable.GotoTop()
 		} else {
@@ -201,10 +294,20 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	// recreate offset rows after topic table has been updated
 	m.recreateTopicRows()
 	m.recreateOffsetRows()
+	m.recreateMemberRows()
 
 	return tea.Batch(cmds...)
 }
 
+func (m *Model) offsetsListed(offsets []kadmin.TopicPartitionOffset) {
+	if offsets == nil {
+		m.state = stateNoOffsets
+	} else {
+		m.state = stateOffsetsLoaded
+		m.offsets = offsets
+	}
+}
+
 func (m *Model) recreateOffsetRows() {
 	// if topics aren't listed yet
 	if m.topicsRows == nil {
@@ -212,24 +315,30 @@ func (m *Model) recreateOffsetRows() {
 	}
 
 	selectedTopic := m.selectedRow()
+	if selectedTopic != m.markedTopic {
+		m.markedTopic = selectedTopic
+		m.markedPartitions = make(map[int32]bool)
+	}
 	if selectedTopic != "" {
 		totalLag := int64(0)
 		m.offsetRows = []table.Row{}
-		for _, partOffset := range m.topicByPartOffset[selectedTopic] {
+		partOffsets := slices.Clone(m.topicByPartOffset[selectedTopic])
+		m.sortPartOffsets(partOffsets)
+		for _, partOffset := range partOffsets {
 			totalLag += int64(partOffset.lag)
+			partition := strconv.FormatInt(int64(partOffset.partition), 10)
+			if m.markedPartitions[partOffset.partition] {
+				partition = markedPrefix + partition
+			}
 			m.offsetRows = append(m.offsetRows, table.Row{
-				partOffset.partition,
+				partition,
 				humanize.Comma(partOffset.offset),
 				partOffset.getHwmValue(),
 				partOffset.getLagValue(),
+				m.getTimeLagValue(partOffset),
 			})
 		}
 		m.totalLag = totalLag
-		sort.SliceStable(m.offsetRows, func(i, j int) bool {
-			a, _ := strconv.Atoi(m.offsetRows[i][0])
-			b, _ := strconv.Atoi(m.offsetRows[j][0])
-			return a < b
-		})
 	}
 }
 
@@ -250,11 +359,14 @@ func (m *Model) recreateTopicRows() {
 			topics = append(topics, offset.Topic)
 		}
 		partOffset := partOffset{
-			partition: strconv.FormatInt(int64(offset.Partition), 10),
+			partition: offset.Partition,
 			offset:    offset.Offset,
 			hwm:       offset.HighWaterMark,
 			lag:       offset.Lag,
 		}
+		if timeLag, ok := m.timeLags[partitionKey(offset.Topic, offset.Partition)]; ok {
+			partOffset.timeLag = &timeLag
+		}
 		m.topicByPartOffset[offset.Topic] = append(m.topicByPartOffset[offset.Topic], partOffset)
 	}
 	m.topicsRows = []table.Row{}
@@ -275,18 +387,52 @@ func (m *Model) selectedRow() string {
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
-	return []statusbar.Shortcut{
+	if m.cmdBar.IsFocussed() {
+		shortcuts := m.cmdBar.Shortcuts()
+		if shortcuts != nil {
+			return shortcuts
+		}
+	}
+	shortcuts := []statusbar.Shortcut{
 		{Name: "Go Back", Keybinding: "esc"},
 		{Name: "Search", Keybinding: "/"},
 		{Name: "Refresh", Keybinding: "F5"},
+		{Name: "Toggle Members", Keybinding: "F6"},
 	}
+	if !m.showMembers {
+		shortcuts = append(shortcuts, statusbar.Shortcut{Name: "Sort", Keybinding: "F3"})
+		if m.tableFocus == offsetFocus {
+			shortcuts = append(shortcuts, statusbar.Shortcut{Name: "Mark Partition", Keybinding: "space"})
+		}
+		shortcuts = append(shortcuts, statusbar.Shortcut{Name: "Delete Offsets", Keybinding: "F2"})
+	}
+	return shortcuts
+}
+
+func (m *Model) listOffsets() tea.Msg {
+	return m.lister.ListOffsets(m.groupName)
+}
+
+func (m *Model) readTimeLag() tea.Msg {
+	return m.timeLagReader.ReadTimeLag(m.groupName)
+}
+
+func (m *Model) describeGroup() tea.Msg {
+	return m.describer.DescribeCGroup(m.groupName)
 }
 
 func (m *Model) Title() string {
 	return "Consumer Groups / " + m.groupName
 }
 
-func New(lister kadmin.OffsetLister, group string) (*Model, tea.Cmd) {
+func New(
+	lister kadmin.OffsetLister,
+	describer kadmin.CGroupDescriber,
+	deleter kadmin.CGroupOffsetsDeleter,
+	timeLagReader kadmin.TimeLagReader,
+	group string,
+	samplingInterval time.Duration,
+) (*Model, tea.Cmd) {
 	tt := table.New(
 		table.WithFocused(true),
 		table.WithStyles(styles.Table.Styles),
@@ -309,6 +455,26 @@ func New(lister kadmin.OffsetLister, group string) (*Model, tea.Cmd) {
 		},
 	)
 
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.CGroupDescribingErrorMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			return true, m.ShowErrorMsg("Unable to describe group", msg.Err)
+		},
+	)
+
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.TimeLagReadingErrMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			return true, m.ShowErrorMsg("Unable to read time lag", msg.Err)
+		},
+	)
+
 	cmdbar.BindNotificationHandler(
 		notifierCmdBar,
 		func(
@@ -320,17 +486,64 @@ func New(lister kadmin.OffsetLister, group string) (*Model, tea.Cmd) {
 		},
 	)
 
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.CGroupOffsetsDeletionStartedMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			cmd := m.SpinWithLoadingMsg("Deleting Offsets")
+			return true, cmd
+		},
+	)
+
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.CGroupOffsetsDeletedMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.ShowSuccessMsg("Offsets of " + offsetsDeletion{topic: msg.Topic, partitions: msg.Partitions}.String() + " deleted")
+			return true, m.AutoHideCmd("cgroup")
+		},
+	)
+
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.CGroupOffsetsDeletionErrMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			if len(msg.Deleted) > 0 {
+				deleted := offsetsDeletion{topic: msg.Topic, partitions: msg.Deleted}.String()
+				return true, m.ShowErrorMsg("Failed to delete offsets, those of "+deleted+" were deleted", msg.Err)
+			}
+			return true, m.ShowErrorMsg("Failed to delete offsets", msg.Err)
+		},
+	)
+
+	deleteFn := func(deletion offsetsDeletion) tea.Cmd {
+		return func() tea.Msg {
+			return deleter.DeleteCGroupOffsets(group, deletion.topic, deletion.partitions)
+		}
+	}
+
 	model := Model{
-		lister: lister,
-		cmdBar: NewCGroupCmdbar[string](
-			cmdbar.NewSearchCmdBar("Search groups by name"),
-			notifierCmdBar,
+		lister:        lister,
+		describer:     describer,
+		timeLagReader: timeLagReader,
+		tableFocus:    topicFocus,
+		groupName:     group,
+		topicsTable:   tt,
+		offsetsTable:  ot,
+		membersTable: table.New(
+			table.WithFocused(true),
+			table.WithStyles(styles.Table.Styles),
 		),
-		tableFocus:   topicFocus,
-		groupName:    group,
-		topicsTable:  tt,
-		offsetsTable: ot,
-		state:        stateOffsetsLoading,
+		state:            stateOffsetsLoading,
+		markedPartitions: make(map[int32]bool),
+		history:          newLagHistory(),
+		samplingInterval: samplingInterval,
 	}
 	model.topicsBorder = border.New(
 		border.WithInnerPaddingTop(),
@@ -341,7 +554,36 @@ func New(lister kadmin.OffsetLister, group string) (*Model, tea.Cmd) {
 		border.WithTitleFn(func() string {
 			return border.KeyValueTitle("Total Lag", fmt.Sprintf(" %d", model.totalLag), false)
 		}))
-	return &model, func() tea.Msg {
-		return lister.ListOffsets(group)
-	}
+	model.membersBorder = border.New(border.WithInnerPaddingTop(),
+		border.WithTitleFn(func() string {
+			members := 0
+			if model.group != nil {
+				members = len(model.group.Members)
+			}
+			return border.KeyValueTitle("Total Members", fmt.Sprintf(" %d", members), true)
+		}))
+	sortByBar := cmdbar.NewSortByCmdBar(
+		[]cmdbar.SortLabel{
+			{
+				Label:     "Partition",
+				Direction: cmdbar.Asc,
+			},
+			{
+				Label:     "Behind By",
+				Direction: cmdbar.Desc,
+			},
+		},
+		cmdbar.WithSortSelectedCallback(func(label cmdbar.SortLabel) {
+			model.sort = label
+		}),
+	)
+	model.sort = sortByBar.SortedBy()
+	model.cmdBar = NewCGroupCmdbar[offsetsDeletion](
+		cmdbar.NewSearchCmdBar("Search groups by name"),
+		notifierCmdBar,
+		cmdbar.NewDeleteCmdBar(deletionMsg(group), deleteFn),
+		sortByBar,
+	)
+
+	return &model, tea.Batch(model.listOffsets, model.describeGroup, model.readTimeLag, model.startSampling())
 }
@@ -4,17 +4,28 @@ import (
 	"fmt"
 	"ktea/kadmin"
 	"ktea/tests"
+	"ktea/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
 
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCgroupPartsOffsetsPage(t *testing.T) {
+	// press renders after every key like the program does, the tables only know their rows once rendered
+	press := func(model *Model, keys ...tests.AKey) {
+		for _, key := range keys {
+			model.Update(tests.Key(key))
+			model.View(tests.NewKontext(), tests.Renderer)
+		}
+	}
 
 	t.Run("Show empty page and loading indicator when listing started", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 		model.Update(kadmin.OffsetListingStartedMsg{})
 		view := model.View(tests.NewKontext(), tests.Renderer)
 
@@ -28,14 +39,14 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 			`┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┓
 ┃  ⣾ ⏳ Loading Offsets                                                                            ┃
 ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛
-╭──────────────────────────── [ Total Topics:  0 ] ╮╭─────────────────────────────── [ Total Lag:  0 ] ╮
-│                                                  ││                                                  │
-│ Topic Name                                       ││ Partition   Offset       High Water…  Lag        │
-│──────────────────────────────────────────────────││──────────────────────────────────────────────────│`)
+╭────────────────── [ Total Topics:  0 ] ╮╭───────────────────────────────────────── [ Total Lag:  0 ] ╮
+│                                        ││                                                            │
+│ Topic Name                             ││ ▲ Partition   Offset    High Water…  Lag      Behind By    │
+│────────────────────────────────────────││────────────────────────────────────────────────────────────│`)
 	})
 
 	t.Run("List consumer groups", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 
 		model.Update(kadmin.OffsetListedMsg{
 			Offsets: []kadmin.TopicPartitionOffset{
@@ -92,7 +103,7 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 	})
 
 	t.Run("List consumer groups when hwm and lag values are not available", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 
 		model.Update(kadmin.OffsetListedMsg{
 			Offsets: []kadmin.TopicPartitionOffset{
@@ -124,7 +135,7 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 	})
 
 	t.Run("Searching", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 
 		var topicPartOffsets []kadmin.TopicPartitionOffset
 		for i := 0; i < 25; i++ {
@@ -161,7 +172,7 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 	})
 
 	t.Run("Order partitions ascending", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 
 		var topicPartOffsets []kadmin.TopicPartitionOffset
 		for i := 0; i < 25; i++ {
@@ -180,22 +191,22 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 
 		view := model.View(tests.NewKontext(), tests.Renderer)
 
-		idx10 := strings.Index(view, "│ 10          10           10           0")
+		idx10 := strings.Index(view, "│ 10            10        10           0")
 		assert.Greater(t, idx10, 0, "Expected partition 10 to be present")
 
-		idx2 := strings.Index(view, "│ 2           10           10           0")
+		idx2 := strings.Index(view, "│ 2             10        10           0")
 		assert.Greater(t, idx2, 0, "Expected partition 2 to be present")
 
-		idx5 := strings.Index(view, "│ 5           10           10           0")
+		idx5 := strings.Index(view, "│ 5             10        10           0")
 		assert.Greater(t, idx5, 0, "Expected partition 5 to be present")
 
-		idx20 := strings.Index(view, "│ 20          10           10           0")
+		idx20 := strings.Index(view, "│ 20            10        10           0")
 		assert.Greater(t, idx20, 0, "Expected partition 20 to be present")
 
-		idx0 := strings.Index(view, "│ 0           10           10           0")
+		idx0 := strings.Index(view, "│ 0             10        10           0")
 		assert.Greater(t, idx0, 0, "Expected partition 0 to be present")
 
-		idx9 := strings.LastIndex(view, "│ 9           10           10           0")
+		idx9 := strings.LastIndex(view, "│ 9             10        10           0")
 		assert.Greater(t, idx9, 0, "Expected partition 9 to be present")
 
 		assert.Less(t, idx2, idx10, "Expected partition 2 to be before partition 10")
@@ -204,7 +215,7 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 	})
 
 	t.Run("Render empty page when no offsets found", func(t *testing.T) {
-		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
 
 		model.Update(kadmin.OffsetListedMsg{
 			Offsets: nil,
@@ -215,4 +226,477 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 		assert.Contains(t, view, "👀 No Committed Offsets Found")
 	})
 
+	t.Run("Members", func(t *testing.T) {
+		described := func() *Model {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+			model.Update(kadmin.OffsetListedMsg{
+				Offsets: []kadmin.TopicPartitionOffset{
+					{Topic: "topic-1", Partition: 0, Offset: 10, HighWaterMark: 1510, Lag: 1500},
+					{Topic: "topic-1", Partition: 1, Offset: 11, HighWaterMark: 17, Lag: 6},
+					{Topic: "topic-2", Partition: 0, Offset: 30, HighWaterMark: kadmin.ErrorValue, Lag: kadmin.ErrorValue},
+				},
+			})
+			model.Update(kadmin.CGroupDescribedMsg{
+				Group: &kadmin.ConsumerGroup{
+					Name:         "test-group",
+					State:        "CompletingRebalance",
+					ProtocolType: "consumer",
+					Protocol:     "cooperative-sticky",
+					Coordinator:  &kadmin.Broker{ID: 2, Address: "broker-2:9092"},
+					Members: []kadmin.GroupMember{
+						{
+							MemberId:   "member-a",
+							ClientId:   "client-a",
+							ClientHost: "/10.0.0.1",
+							Assignments: []kadmin.TopicAssignment{
+								{Topic: "topic-1", Partitions: []int32{0, 1}},
+							},
+						},
+						{
+							MemberId:   "member-b",
+							ClientId:   "client-b",
+							ClientHost: "/10.0.0.2",
+							Assignments: []kadmin.TopicAssignment{
+								{Topic: "topic-2", Partitions: []int32{0}},
+							},
+						},
+						{
+							MemberId:   "member-c",
+							ClientId:   "client-c",
+							ClientHost: "/10.0.0.3",
+						},
+					},
+				},
+			})
+			return model
+		}
+
+		t.Run("show the state, assignor and coordinator of the group", func(t *testing.T) {
+			model := described()
+
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "State:  Rebalancing")
+			assert.Contains(t, view, "Protocol Type:  consumer")
+			assert.Contains(t, view, "Assignor:  cooperative-sticky")
+			assert.Contains(t, view, "Coordinator:  2 (broker-2:9092)")
+		})
+
+		t.Run("F6 toggles the partitions assigned to every member with their lag", func(t *testing.T) {
+			model := described()
+
+			model.Update(tests.Key(tea.KeyF6))
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Total Members:  3")
+			assert.Regexp(t, `member-a\s+client-a\s+/10.0.0.1\s+topic-1\s+0\s+1,500`, view)
+			assert.Regexp(t, `member-a\s+client-a\s+/10.0.0.1\s+topic-1\s+1\s+6`, view)
+			assert.Regexp(t, `member-b\s+client-b\s+/10.0.0.2\s+topic-2\s+0\s+N/A`, view)
+			assert.Regexp(t, `member-c\s+client-c\s+/10.0.0.3\s+-\s+-\s+-`, view)
+
+			model.Update(tests.Key(tea.KeyF6))
+			view = model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "member-a")
+			assert.Contains(t, view, "Total Topics")
+		})
+
+		t.Run("members are shown without committed offsets", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+			model.Update(kadmin.OffsetListedMsg{Offsets: nil})
+			model.Update(kadmin.CGroupDescribedMsg{
+				Group: &kadmin.ConsumerGroup{
+					Name:    "test-group",
+					State:   "Stable",
+					Members: []kadmin.GroupMember{{MemberId: "member-a", ClientId: "client-a"}},
+				},
+			})
+
+			model.Update(tests.Key(tea.KeyF6))
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "State:  Stable")
+			assert.Contains(t, view, "member-a")
+		})
+	})
+
+	t.Run("Delete offsets", func(t *testing.T) {
+		listed := func() (*Model, *mockOffsetsDeleter) {
+			deleter := &mockOffsetsDeleter{}
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), deleter, kadmin.NewMockKadmin(), "test-group", time.Second)
+			model.Update(kadmin.OffsetListedMsg{
+				Offsets: []kadmin.TopicPartitionOffset{
+					{Topic: "topic-1", Partition: 0, Offset: 10, HighWaterMark: 18, Lag: 8},
+					{Topic: "topic-1", Partition: 1, Offset: 11, HighWaterMark: 17, Lag: 6},
+					{Topic: "topic-1", Partition: 2, Offset: 12, HighWaterMark: 16, Lag: 4},
+					{Topic: "topic-2", Partition: 0, Offset: 30, HighWaterMark: 49, Lag: 19},
+				},
+			})
+			model.View(tests.NewKontext(), tests.Renderer)
+			return model, deleter
+		}
+
+		confirm := func(model *Model) {
+			model.Update(tests.Key('d'))
+			cmd := model.Update(tests.Key(tea.KeyEnter))
+			cmd()
+		}
+
+		t.Run("F2 on a topic deletes the offsets of all its partitions", func(t *testing.T) {
+			model, deleter := listed()
+
+			model.Update(tests.Key(tea.KeyF2))
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, "🗑️  Offsets of test-group for topic-1 will be deleted permanently\\W+Delete!\\W+Cancel.", view)
+
+			confirm(model)
+
+			assert.Equal(t, "test-group", deleter.group)
+			assert.Equal(t, "topic-1", deleter.topic)
+			assert.Equal(t, []int32{0, 1, 2}, deleter.partitions)
+		})
+
+		t.Run("esc cancels the deletion", func(t *testing.T) {
+			model, deleter := listed()
+
+			model.Update(tests.Key(tea.KeyF2))
+			model.Update(tests.Key(tea.KeyEsc))
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "will be deleted permanently")
+			assert.Nil(t, deleter.partitions)
+		})
+
+		t.Run("F2 on a partition deletes the offset of that partition", func(t *testing.T) {
+			model, deleter := listed()
+
+			press(model, tea.KeyTab, tea.KeyDown, tea.KeyF2)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Offsets of test-group for partition 1 of topic-1 will be deleted permanently")
+
+			confirm(model)
+
+			assert.Equal(t, "topic-1", deleter.topic)
+			assert.Equal(t, []int32{1}, deleter.partitions)
+		})
+
+		t.Run("space marks the partitions to delete", func(t *testing.T) {
+			model, deleter := listed()
+
+			press(model, tea.KeyTab, ' ', tea.KeyDown, tea.KeyDown, ' ')
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "│ ● 0")
+			assert.Contains(t, view, "│ 1 ")
+			assert.Contains(t, view, "│ ● 2")
+
+			model.Update(tests.Key(tea.KeyF2))
+			view = model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Offsets of test-group for partitions 0, 2 of topic-1 will be deleted permanently")
+
+			confirm(model)
+
+			assert.Equal(t, []int32{0, 2}, deleter.partitions)
+		})
+
+		t.Run("space unmarks a marked partition", func(t *testing.T) {
+			model, _ := listed()
+
+			press(model, tea.KeyTab, ' ', ' ')
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "● 0")
+		})
+
+		t.Run("deleted offsets are removed and the rest of the group is kept", func(t *testing.T) {
+			model, _ := listed()
+
+			model.Update(kadmin.CGroupOffsetsDeletedMsg{
+				Group:      "test-group",
+				Topic:      "topic-1",
+				Partitions: []int32{0, 1, 2},
+			})
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Offsets of partitions 0, 1, 2 of topic-1 deleted")
+			assert.NotContains(t, view, "│ topic-1")
+			assert.Contains(t, view, "│ topic-2")
+			assert.Contains(t, view, "Total Topics:  1")
+		})
+
+		t.Run("show error when deletion failed", func(t *testing.T) {
+			model, _ := listed()
+
+			model.Update(kadmin.CGroupOffsetsDeletionErrMsg{
+				Err: fmt.Errorf("test-group is still consuming topic-1, stop its members first"),
+			})
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Failed to delete offsets")
+		})
+
+		t.Run("offsets deleted before a failure are removed", func(t *testing.T) {
+			model, _ := listed()
+
+			model.Update(kadmin.CGroupOffsetsDeletionErrMsg{
+				Group:   "test-group",
+				Topic:   "topic-1",
+				Deleted: []int32{0, 1},
+				Err:     fmt.Errorf("unable to delete the offset of partition 2: timeout"),
+			})
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Failed to delete offsets, those of partitions 0, 1 of topic-1 were deleted")
+			var remaining []int32
+			for _, offset := range model.offsets {
+				if offset.Topic == "topic-1" {
+					remaining = append(remaining, offset.Partition)
+				}
+			}
+			assert.Equal(t, []int32{2}, remaining)
+			assert.Contains(t, view, "│ topic-1")
+		})
+
+		t.Run("F2 is ignored in the members view", func(t *testing.T) {
+			model, _ := listed()
+
+			model.Update(tests.Key(tea.KeyF6))
+			model.Update(tests.Key(tea.KeyF2))
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "will be deleted permanently")
+		})
+	})
+
+	t.Run("Lag trend", func(t *testing.T) {
+		start := time.Now()
+		sampled := func(model *Model, after time.Duration, offset int64, hwm int64) {
+			model.Update(lagSampledMsg{
+				run: model.sampling,
+				at:  start.Add(after),
+				offsets: []kadmin.TopicPartitionOffset{
+					{Topic: "topic-1", Partition: 0, Offset: offset, HighWaterMark: hwm, Lag: hwm - offset},
+				},
+			})
+		}
+
+		t.Run("shown once sampled twice", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+
+			sampled(model, 0, 10, 110)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "Lag Trend")
+
+			sampled(model, 5*time.Second, 40, 120)
+			sampled(model, 10*time.Second, 60, 130)
+			view = model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Lag Trend:  █▃▁")
+			assert.Contains(t, view, "Consumed:  5/s")
+			assert.Contains(t, view, "Produced:  2/s")
+			assert.Contains(t, view, "Catch Up:  ~23s")
+			assert.Contains(t, view, "│ 0             60        130          70")
+		})
+
+		t.Run("a consumer without progress is stuck", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+
+			sampled(model, 0, 10, 110)
+			sampled(model, 10*time.Second, 10, 150)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Catch Up:  stuck")
+		})
+
+		t.Run("a consumer slower than the producers falls behind", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+
+			sampled(model, 0, 10, 110)
+			sampled(model, 10*time.Second, 20, 150)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Catch Up:  falling behind")
+		})
+
+		t.Run("a consumer without lag is caught up", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+
+			sampled(model, 0, 10, 20)
+			sampled(model, 10*time.Second, 20, 20)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "Catch Up:  caught up")
+		})
+
+		t.Run("history is bounded", func(t *testing.T) {
+			history := newLagHistory()
+
+			for i := 0; i < lagHistorySize+10; i++ {
+				history.record(start.Add(time.Duration(i)*time.Second), []kadmin.TopicPartitionOffset{
+					{Topic: "topic-1", Partition: 0, Offset: int64(i), HighWaterMark: 100, Lag: 100 - int64(i)},
+				})
+			}
+
+			samples := history.topicSamples("topic-1")
+			assert.Len(t, samples, lagHistorySize)
+			assert.Equal(t, int64(10), samples[0].offset)
+		})
+
+		t.Run("partition samples are summed per topic", func(t *testing.T) {
+			history := newLagHistory()
+
+			history.record(start, []kadmin.TopicPartitionOffset{
+				{Topic: "topic-1", Partition: 0, Offset: 10, HighWaterMark: 20, Lag: 10},
+				{Topic: "topic-1", Partition: 1, Offset: 5, HighWaterMark: 25, Lag: 20},
+				{Topic: "topic-1", Partition: 2, Offset: 5, HighWaterMark: kadmin.ErrorValue, Lag: kadmin.ErrorValue},
+				{Topic: "topic-2", Partition: 0, Offset: 1, HighWaterMark: 1, Lag: 0},
+			})
+
+			assert.Equal(t, []lagSample{{at: start, offset: 15, hwm: 45, lag: 30}}, history.topicSamples("topic-1"))
+		})
+
+		t.Run("ticks are sampled at the configured interval", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+
+			cmd := model.Update(lagSamplingTickMsg{run: model.sampling})
+
+			assert.IsType(t, lagSampledMsg{}, cmd())
+		})
+
+		t.Run("ticks of a replaced run are ignored", func(t *testing.T) {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+			run := model.sampling
+
+			model.Update(ui.RegainedFocusMsg{})
+
+			assert.Nil(t, model.Update(lagSamplingTickMsg{run: run}))
+		})
+	})
+
+	t.Run("Time lag", func(t *testing.T) {
+		offsets := []kadmin.TopicPartitionOffset{
+			{Topic: "topic-1", Partition: 0, Offset: 10, HighWaterMark: 10, Lag: 0},
+			{Topic: "topic-1", Partition: 1, Offset: 10, HighWaterMark: 20, Lag: 10},
+			{Topic: "topic-1", Partition: 2, Offset: 10, HighWaterMark: 15, Lag: 5},
+			{Topic: "topic-1", Partition: 3, Offset: 10, HighWaterMark: 30, Lag: 20},
+		}
+		listed := func() *Model {
+			model, _ := New(kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), kadmin.NewMockKadmin(), "test-group", time.Second)
+			model.Update(kadmin.OffsetListedMsg{Offsets: offsets})
+			model.View(tests.NewKontext(), tests.Renderer)
+			return model
+		}
+		read := func(model *Model, group string) {
+			model.Update(kadmin.TimeLagReadMsg{
+				Group: group,
+				TimeLags: []kadmin.PartitionTimeLag{
+					{Topic: "topic-1", Partition: 0, Lag: 0},
+					{Topic: "topic-1", Partition: 1, Lag: 12 * time.Minute},
+					{Topic: "topic-1", Partition: 2, Lag: 3*time.Hour + 5*time.Minute},
+					{Topic: "topic-1", Partition: 3, Lag: time.Duration(kadmin.ErrorValue)},
+				},
+			})
+		}
+
+		t.Run("loading until read", func(t *testing.T) {
+			model := listed()
+
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, `│ 1\s+10\s+20\s+10\s+…`, view)
+		})
+
+		t.Run("shown per partition once read", func(t *testing.T) {
+			model := listed()
+
+			read(model, "test-group")
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, `│ 0\s+10\s+10\s+0\s+caught up`, view)
+			assert.Regexp(t, `│ 1\s+10\s+20\s+10\s+12m`, view)
+			assert.Regexp(t, `│ 2\s+10\s+15\s+5\s+3h5m`, view)
+			assert.Regexp(t, `│ 3\s+10\s+30\s+20\s+N/A`, view)
+		})
+
+		t.Run("unknown when reading failed", func(t *testing.T) {
+			model := listed()
+
+			model.Update(kadmin.TimeLagReadingErrMsg{Group: "test-group", Err: fmt.Errorf("unable to fetch")})
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Regexp(t, `│ 1\s+10\s+20\s+10\s+N/A`, view)
+			assert.Contains(t, view, "Unable to read time lag")
+		})
+
+		t.Run("time lag of another group is ignored", func(t *testing.T) {
+			model := listed()
+
+			read(model, "other-group")
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "12m")
+			assert.Regexp(t, `│ 1\s+10\s+20\s+10\s+…`, view)
+		})
+
+		t.Run("reloaded on refresh", func(t *testing.T) {
+			model := listed()
+			read(model, "test-group")
+
+			model.Update(tests.Key(tea.KeyF5))
+			model.Update(kadmin.OffsetListedMsg{Offsets: offsets})
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, view, "12m")
+			assert.Regexp(t, `│ 1\s+10\s+20\s+10\s+…`, view)
+		})
+
+		t.Run("sort by Behind By", func(t *testing.T) {
+			model := listed()
+			read(model, "test-group")
+
+			press(model, tea.KeyF3)
+			press(model, tea.KeyRight)
+			press(model, tea.KeyEnter)
+			view := model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "▼ Behind By")
+			idx0 := strings.Index(view, "│ 0 ")
+			idx1 := strings.Index(view, "│ 1 ")
+			idx2 := strings.Index(view, "│ 2 ")
+			idx3 := strings.Index(view, "│ 3 ")
+			assert.Less(t, idx2, idx1, "Expected the partition furthest behind first")
+			assert.Less(t, idx1, idx0)
+			assert.Less(t, idx0, idx3, "Expected the unknown time lag last")
+
+			// the sort bar stays open, selecting again toggles the direction
+			press(model, tea.KeyEnter)
+			view = model.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, view, "▲ Behind By")
+			idx0 = strings.Index(view, "│ 0 ")
+			idx1 = strings.Index(view, "│ 1 ")
+			idx2 = strings.Index(view, "│ 2 ")
+			idx3 = strings.Index(view, "│ 3 ")
+			assert.Less(t, idx0, idx1)
+			assert.Less(t, idx1, idx2)
+			assert.Less(t, idx2, idx3, "Expected the unknown time lag last")
+		})
+	})
+}
+
+type mockOffsetsDeleter struct {
+	group      string
+	topic      string
+	partitions []int32
+}
+
+func (m *mockOffsetsDeleter) DeleteCGroupOffsets(group string, topic string, partitions []int32) tea.Msg {
+	m.group = group
+	m.topic = topic
+	m.partitions = partitions
+	return nil
 }
@@ -0,0 +1,231 @@
+package cgroups_topics_page
+
+import (
+	"fmt"
+	"ktea/kadmin"
+	"ktea/ui/components/border"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lg "github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+)
+
+// lagHistorySize is the number of samples kept per partition
+const lagHistorySize = 60
+
+// sparks are the bars of a sparkline, from low to high
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+type lagSample struct {
+	at     time.Time
+	offset int64
+	hwm    int64
+	lag    int64
+}
+
+// lagHistory holds the most recent lag samples of every partition per topic
+type lagHistory struct {
+	samples map[string]map[int32][]lagSample
+}
+
+func newLagHistory() *lagHistory {
+	return &lagHistory{samples: make(map[string]map[int32][]lagSample)}
+}
+
+// record adds a sample of every partition of which the lag is known,
+// the oldest samples are dropped once lagHistorySize samples are kept.
+func (h *lagHistory) record(at time.Time, offsets []kadmin.TopicPartitionOffset) {
+	for _, offset := range offsets {
+		if offset.HighWaterMark == kadmin.ErrorValue || offset.Lag == kadmin.ErrorValue {
+			continue
+		}
+		partitions, ok := h.samples[offset.Topic]
+		if !ok {
+			partitions = make(map[int32][]lagSample)
+			h.samples[offset.Topic] = partitions
+		}
+		samples := append(partitions[offset.Partition], lagSample{
+			at:     at,
+			offset: offset.Offset,
+			hwm:    offset.HighWaterMark,
+			lag:    offset.Lag,
+		})
+		if len(samples) > lagHistorySize {
+			samples = samples[len(samples)-lagHistorySize:]
+		}
+		partitions[offset.Partition] = samples
+	}
+}
+
+// forget drops the samples of partitions that no longer have a committed offset
+func (h *lagHistory) forget(topic string, partitions []int32) {
+	for _, partition := range partitions {
+		delete(h.samples[topic], partition)
+	}
+}
+
+// topicSamples sums the samples of all partitions of the topic that were taken at the same time
+func (h *lagHistory) topicSamples(topic string) []lagSample {
+	byTime := make(map[int64]*lagSample)
+	for _, samples := range h.samples[topic] {
+		for _, sample := range samples {
+			total, ok := byTime[sample.at.UnixNano()]
+			if !ok {
+				total = &lagSample{at: sample.at}
+				byTime[sample.at.UnixNano()] = total
+			}
+			total.offset += sample.offset
+			total.hwm += sample.hwm
+			total.lag += sample.lag
+		}
+	}
+
+	var totals []lagSample
+	for _, total := range byTime {
+		totals = append(totals, *total)
+	}
+	slices.SortFunc(totals, func(a, b lagSample) int {
+		return a.at.Compare(b.at)
+	})
+	return totals
+}
+
+// lagTrend is how the lag of a topic evolved over the sampled window
+type lagTrend struct {
+	lags []int64
+	lag  int64
+	// consumedRate and producedRate are in records per second
+	consumedRate float64
+	producedRate float64
+}
+
+// topicTrend returns the trend of the topic, false when less than two samples were taken
+func (h *lagHistory) topicTrend(topic string) (lagTrend, bool) {
+	samples := h.topicSamples(topic)
+	if len(samples) < 2 {
+		return lagTrend{}, false
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	seconds := last.at.Sub(first.at).Seconds()
+	if seconds <= 0 {
+		return lagTrend{}, false
+	}
+
+	trend := lagTrend{
+		lag: last.lag,
+		// offsets that were reset backwards do not count as consumption
+		consumedRate: math.Max(0, float64(last.offset-first.offset)/seconds),
+		producedRate: math.Max(0, float64(last.hwm-first.hwm)/seconds),
+	}
+	for _, sample := range samples {
+		trend.lags = append(trend.lags, sample.lag)
+	}
+	return trend, true
+}
+
+// catchUp estimates how long the group needs to consume its lag at the sampled rates,
+// telling a slow consumer from one that does not consume at all.
+func (t lagTrend) catchUp() string {
+	switch {
+	case t.lag == 0:
+		return "caught up"
+	case t.consumedRate == 0:
+		return "stuck"
+	case t.consumedRate <= t.producedRate:
+		return "falling behind"
+	}
+	seconds := float64(t.lag) / (t.consumedRate - t.producedRate)
+	return "~" + (time.Duration(seconds) * time.Second).String()
+}
+
+func sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := slices.Min(values), slices.Max(values)
+
+	var sb strings.Builder
+	for _, value := range values {
+		idx := 0
+		if high > low {
+			idx = int(float64(value-low) / float64(high-low) * float64(len(sparks)-1))
+		}
+		sb.WriteRune(sparks[idx])
+	}
+	return sb.String()
+}
+
+func rate(recordsPerSecond float64) string {
+	return humanize.CommafWithDigits(recordsPerSecond, 1) + "/s"
+}
+
+// trendView renders the lag trend, rates and time to catch up of the selected topic, empty until sampled twice
+func (m *Model) trendView() string {
+	if len(m.topicsRows) == 0 {
+		return ""
+	}
+	trend, ok := m.history.topicTrend(m.selectedRow())
+	if !ok {
+		return ""
+	}
+
+	chips := []string{
+		border.KeyValueTitle("Lag Trend", " "+sparkline(trend.lags), true),
+		border.KeyValueTitle("Consumed", " "+rate(trend.consumedRate), false),
+		border.KeyValueTitle("Produced", " "+rate(trend.producedRate), false),
+		border.KeyValueTitle("Catch Up", " "+trend.catchUp(), false),
+	}
+	return lg.NewStyle().PaddingLeft(1).Render(strings.Join(chips, " "))
+}
+
+// samplingRun identifies a chain of sampling ticks, ticks of a replaced run are ignored
+type samplingRun struct {
+	startedAt time.Time
+}
+
+type lagSamplingTickMsg struct {
+	run *samplingRun
+}
+
+type lagSampledMsg struct {
+	run     *samplingRun
+	at      time.Time
+	offsets []kadmin.TopicPartitionOffset
+	err     error
+}
+
+// startSampling starts a new run of background lag samples, replacing the running one
+func (m *Model) startSampling() tea.Cmd {
+	m.sampling = &samplingRun{startedAt: time.Now()}
+	return m.scheduleSample()
+}
+
+func (m *Model) scheduleSample() tea.Cmd {
+	run := m.sampling
+	return tea.Tick(m.samplingInterval, func(time.Time) tea.Msg {
+		return lagSamplingTickMsg{run: run}
+	})
+}
+
+// sampleLag lists the offsets of the group without notifying, only the outcome is returned
+func (m *Model) sampleLag() tea.Cmd {
+	run, lister, group := m.sampling, m.lister, m.groupName
+	return func() tea.Msg {
+		started, ok := lister.ListOffsets(group).(kadmin.OffsetListingStartedMsg)
+		if !ok {
+			return lagSampledMsg{run: run, err: fmt.Errorf("unable to sample the lag of %s", group)}
+		}
+		switch msg := started.AwaitCompletion().(type) {
+		case kadmin.OffsetListedMsg:
+			return lagSampledMsg{run: run, at: time.Now(), offsets: msg.Offsets}
+		case kadmin.OffsetListingErrorMsg:
+			return lagSampledMsg{run: run, err: msg.Err}
+		}
+		return lagSampledMsg{run: run, err: fmt.Errorf("unable to sample the lag of %s", group)}
+	}
+}
@@ -0,0 +1,109 @@
+package cgroups_topics_page
+
+import (
+	"fmt"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/border"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	lg "github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+)
+
+// detailsView renders the state, protocol type, assignor and coordinator of the group, empty until described
+func (m *Model) detailsView() string {
+	if m.group == nil {
+		return ""
+	}
+
+	coordinator := na
+	if c := m.group.Coordinator; c != nil {
+		coordinator = fmt.Sprintf("%d (%s)", c.ID, c.Address)
+	}
+	details := []string{
+		border.KeyValueTitle("State", " "+m.group.DisplayState(), true),
+		border.KeyValueTitle("Protocol Type", " "+valueOrNA(m.group.ProtocolType), false),
+		border.KeyValueTitle("Assignor", " "+valueOrNA(m.group.Protocol), false),
+		border.KeyValueTitle("Coordinator", " "+coordinator, false),
+	}
+	return lg.NewStyle().PaddingLeft(1).Render(strings.Join(details, " "))
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return na
+	}
+	return value
+}
+
+func (m *Model) membersView(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	width := ktx.WindowWidth - 10
+	m.membersTable.SetHeight(ktx.AvailableTableHeight() - 1)
+	m.membersTable.SetWidth(ktx.WindowWidth - 2)
+	m.membersTable.SetColumns([]table.Column{
+		{Title: "Member", Width: int(float64(width) * 0.3)},
+		{Title: "Client Id", Width: int(float64(width) * 0.15)},
+		{Title: "Host", Width: int(float64(width) * 0.15)},
+		{Title: "Topic", Width: int(float64(width) * 0.2)},
+		{Title: "Partition", Width: int(float64(width) * 0.1)},
+		{Title: "Lag", Width: int(float64(width) * 0.1)},
+	})
+	m.membersTable.SetRows(m.memberRows)
+
+	return m.membersBorder.View(
+		renderer.RenderWithStyle(m.membersTable.View(), styles.Table.Focus),
+	)
+}
+
+// recreateMemberRows lists a row for every partition assigned to a member with its lag,
+// or a single row for members without an assignment.
+func (m *Model) recreateMemberRows() {
+	m.memberRows = []table.Row{}
+	if m.group == nil {
+		return
+	}
+
+	lagByPartition := make(map[string]int64)
+	for _, offset := range m.offsets {
+		lagByPartition[partitionKey(offset.Topic, offset.Partition)] = offset.Lag
+	}
+
+	searchTerm := m.cmdBar.GetSearchTerm()
+	for _, member := range m.group.Members {
+		if len(member.Assignments) == 0 {
+			if searchTerm == "" {
+				m.memberRows = append(m.memberRows, table.Row{member.MemberId, member.ClientId, member.ClientHost, "-", "-", "-"})
+			}
+			continue
+		}
+		for _, assignment := range member.Assignments {
+			if searchTerm != "" && !strings.Contains(assignment.Topic, searchTerm) {
+				continue
+			}
+			for _, partition := range assignment.Partitions {
+				lag, ok := lagByPartition[partitionKey(assignment.Topic, partition)]
+				lagValue := na
+				if ok && lag != kadmin.ErrorValue {
+					lagValue = humanize.Comma(lag)
+				}
+				m.memberRows = append(m.memberRows, table.Row{
+					member.MemberId,
+					member.ClientId,
+					member.ClientHost,
+					assignment.Topic,
+					strconv.FormatInt(int64(partition), 10),
+					lagValue,
+				})
+			}
+		}
+	}
+}
+
+func partitionKey(topic string, partition int32) string {
+	return topic + "/" + strconv.FormatInt(int64(partition), 10)
+}
@@ -0,0 +1,128 @@
+package cgroups_topics_page
+
+import (
+	"fmt"
+	"ktea/kadmin"
+	"ktea/styles"
+	"slices"
+	"strconv"
+	"strings"
+
+	lg "github.com/charmbracelet/lipgloss"
+)
+
+// markedPrefix prefixes the partitions marked for deletion
+const markedPrefix = "● "
+
+// offsetsDeletion are the partitions of a topic whose committed offsets get deleted
+type offsetsDeletion struct {
+	topic      string
+	partitions []int32
+	// all is true when every partition of the topic is deleted
+	all bool
+}
+
+func (d offsetsDeletion) String() string {
+	if d.all {
+		return d.topic
+	}
+	partitions := make([]string, len(d.partitions))
+	for i, partition := range d.partitions {
+		partitions[i] = strconv.FormatInt(int64(partition), 10)
+	}
+	label := "partition"
+	if len(partitions) > 1 {
+		label = "partitions"
+	}
+	return fmt.Sprintf("%s %s of %s", label, strings.Join(partitions, ", "), d.topic)
+}
+
+// deletionSelection returns the offsets F2 deletes: every partition of the selected topic when the topics
+// table is focussed, otherwise the marked partitions or the one under the cursor when none are marked.
+func (m *Model) deletionSelection() *offsetsDeletion {
+	if m.showMembers || m.state != stateOffsetsLoaded || len(m.topicsRows) == 0 {
+		return nil
+	}
+
+	topic := m.selectedRow()
+	var partitions []int32
+	for _, offset := range m.topicByPartOffset[topic] {
+		partitions = append(partitions, offset.partition)
+	}
+	slices.Sort(partitions)
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	if m.tableFocus == topicFocus {
+		return &offsetsDeletion{topic: topic, partitions: partitions, all: true}
+	}
+
+	var marked []int32
+	for _, partition := range partitions {
+		if m.markedPartitions[partition] {
+			marked = append(marked, partition)
+		}
+	}
+	if len(marked) == 0 {
+		partition, ok := m.selectedPartition()
+		if !ok {
+			return nil
+		}
+		marked = []int32{partition}
+	}
+	return &offsetsDeletion{
+		topic:      topic,
+		partitions: marked,
+		all:        len(marked) == len(partitions),
+	}
+}
+
+// toggleMark marks or unmarks the partition under the cursor of the offsets table for deletion
+func (m *Model) toggleMark() {
+	partition, ok := m.selectedPartition()
+	if !ok {
+		return
+	}
+	if m.markedPartitions[partition] {
+		delete(m.markedPartitions, partition)
+	} else {
+		m.markedPartitions[partition] = true
+	}
+}
+
+// selectedPartition returns the partition under the cursor of the offsets table
+func (m *Model) selectedPartition() (int32, bool) {
+	cursor := m.offsetsTable.Cursor()
+	if cursor < 0 || cursor >= len(m.offsetRows) {
+		return 0, false
+	}
+	partition, err := strconv.ParseInt(strings.TrimPrefix(m.offsetRows[cursor][0], markedPrefix), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(partition), true
+}
+
+// removeOffsets removes the deleted offsets, the rest of the group is left as is
+func (m *Model) removeOffsets(topic string, partitions []int32) {
+	m.offsets = slices.DeleteFunc(m.offsets, func(offset kadmin.TopicPartitionOffset) bool {
+		return offset.Topic == topic && slices.Contains(partitions, offset.Partition)
+	})
+	m.history.forget(topic, partitions)
+	m.markedPartitions = make(map[int32]bool)
+	if len(m.offsets) == 0 {
+		m.state = stateNoOffsets
+	}
+	m.topicsTable.GotoTop()
+	m.offsetsTable.GotoTop()
+}
+
+func deletionMsg(group string) func(offsetsDeletion) string {
+	return func(deletion offsetsDeletion) string {
+		return "Offsets of " + group + " for " + deletion.String() + lg.NewStyle().
+			Foreground(lg.Color(styles.ColorIndigo)).
+			Bold(true).
+			Render(" will be deleted permanently")
+	}
+}
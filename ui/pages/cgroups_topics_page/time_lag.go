@@ -0,0 +1,64 @@
+package cgroups_topics_page
+
+import (
+	"cmp"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"slices"
+
+	lg "github.com/charmbracelet/lipgloss"
+)
+
+// getTimeLagValue renders how far the group is behind on the partition in time, … while it is being read
+func (m *Model) getTimeLagValue(partOffset partOffset) string {
+	if m.timeLags == nil {
+		return "…"
+	}
+	if partOffset.timeLag == nil || !partOffset.timeLag.Known() {
+		return na
+	}
+	if partOffset.timeLag.Lag == 0 {
+		return "caught up"
+	}
+	return ui.CompactDuration(partOffset.timeLag.Lag)
+}
+
+func knownTimeLag(partOffset partOffset) bool {
+	return partOffset.timeLag != nil && partOffset.timeLag.Known()
+}
+
+func (m *Model) sortPartOffsets(partOffsets []partOffset) {
+	slices.SortStableFunc(partOffsets, func(a, b partOffset) int {
+		if m.sort.Label == "Behind By" {
+			// partitions of which the time lag is unknown sort last in both directions
+			if knownTimeLag(a) != knownTimeLag(b) {
+				if knownTimeLag(a) {
+					return -1
+				}
+				return 1
+			}
+			if knownTimeLag(a) && a.timeLag.Lag != b.timeLag.Lag {
+				if m.sort.Direction == cmdbar.Asc {
+					return cmp.Compare(a.timeLag.Lag, b.timeLag.Lag)
+				}
+				return cmp.Compare(b.timeLag.Lag, a.timeLag.Lag)
+			}
+			return cmp.Compare(a.partition, b.partition)
+		}
+		if m.sort.Direction == cmdbar.Asc {
+			return cmp.Compare(a.partition, b.partition)
+		}
+		return cmp.Compare(b.partition, a.partition)
+	})
+}
+
+func (m *Model) columnTitle(title string) string {
+	if m.sort.Label == title {
+		return lg.NewStyle().
+			Foreground(lg.Color(styles.ColorPink)).
+			Bold(true).
+			Render(m.sort.Direction.String()) + " " + title
+	}
+	return title
+}
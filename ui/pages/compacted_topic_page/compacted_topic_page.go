@@ -0,0 +1,420 @@
+package compacted_topic_page
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/border"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	ktable "ktea/ui/components/table"
+	"ktea/ui/tabs"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+const name = "compacted-topic-page"
+
+type Model struct {
+	table             table.Model
+	border            *border.Model
+	tcb               *cmdbar.TableCmdsBar[string]
+	sort              cmdbar.SortLabel
+	reader            kadmin.RecordReader
+	cancelConsumption context.CancelFunc
+	topic             *kadmin.ListedTopic
+	navigator         tabs.TopicsTabNavigator
+	// latest holds the most recent record per key, tombstoned keys are removed
+	latest      map[compactionKey]kadmin.ConsumerRecord
+	recordsRead int
+	// visible holds the searched records in the order they are displayed, rows holds their rows
+	visible       []kadmin.ConsumerRecord
+	rows          []table.Row
+	rowsFor       rowsCriteria
+	consuming     bool
+	emptyTopic    bool
+	tableFocussed bool
+}
+
+// compactionKey distinguishes null keys from empty ones, they are different keys to compaction
+type compactionKey struct {
+	key  string
+	null bool
+}
+
+// rowsCriteria are the search and sort settings the rows were created for
+type rowsCriteria struct {
+	searchTerm string
+	sort       cmdbar.SortLabel
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	cmdBarView := m.tcb.View(ktx, renderer)
+
+	if m.emptyTopic {
+		return ui.JoinVertical(
+			lipgloss.Top,
+			cmdBarView,
+			styles.CenterText(ktx.WindowWidth, ktx.AvailableHeight).Render("👀 Empty topic"),
+		)
+	}
+
+	keyCol := int(float64(ktx.WindowWidth) * 0.25)
+	partCol := int(float64(ktx.WindowWidth) * 0.08)
+	offsetCol := int(float64(ktx.WindowWidth) * 0.1)
+	tsCol := int(float64(ktx.WindowWidth) * 0.15)
+	valueCol := ktx.WindowWidth - keyCol - partCol - offsetCol - tsCol - 12
+	m.table.SetColumns([]table.Column{
+		{Title: m.columnTitle("Key"), Width: keyCol},
+		{Title: m.columnTitle("Value"), Width: valueCol},
+		{Title: m.columnTitle("Partition"), Width: partCol},
+		{Title: m.columnTitle("Offset"), Width: offsetCol},
+		{Title: m.columnTitle("Timestamp"), Width: tsCol},
+	})
+	m.table.SetRows(m.rows)
+	m.table.SetWidth(ktx.WindowWidth - 2)
+	m.table.SetHeight(ktx.AvailableTableHeight())
+
+	return ui.JoinVertical(lipgloss.Top, cmdBarView, m.border.View(m.table.View()))
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+
+	log.Debug("Received Update", "msg", reflect.TypeOf(msg))
+
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if !m.tcb.IsFocussed() {
+				m.cancelConsumption()
+				return m.navigator.ToTopicsPage()
+			}
+		case "f2":
+			if m.consuming {
+				m.cancelConsumption()
+				m.consuming = false
+				return ui.PublishMsg(kadmin.ConsumptionEndedMsg{})
+			}
+		case "enter":
+			if !m.tcb.IsFocussed() && len(m.rows) > 0 {
+				// rows are inserted while reading, the record details get their own copy
+				records := slices.Clone(m.visible)
+				index := m.table.Cursor()
+				return m.navigator.ToRecordDetailsPage(tabs.LoadRecordDetailPageMsg{
+					Record:    &records[index],
+					TopicName: m.topic.Name,
+					Records:   records,
+					Index:     index,
+				})
+			}
+		}
+	case *kadmin.ReadingStartedMsg:
+		m.consuming = true
+		cmds = append(cmds, msg.AwaitRecord)
+	case kadmin.ConsumerRecordReceived:
+		m.compact(msg.Records)
+		cmds = append(cmds, msg.AwaitNextRecord)
+	case kadmin.EmptyTopicMsg, kadmin.NoRecordsFound:
+		m.emptyTopic = true
+		m.consuming = false
+	case kadmin.ConsumptionEndedMsg:
+		m.consuming = false
+	}
+
+	msg, cmd := m.tcb.Update(msg, nil)
+	m.tableFocussed = !m.tcb.IsFocussed()
+	cmds = append(cmds, cmd)
+
+	m.updateRows()
+
+	// make sure table navigation is off when the cmdbar is focussed
+	if !m.tcb.IsFocussed() {
+		t, cmd := m.table.Update(msg)
+		m.table = t
+		cmds = append(cmds, cmd)
+	}
+
+	if m.tcb.HasSearchedAtLeastOneChar() {
+		m.table.GotoTop()
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// compact retains the latest record per key and drops keys of which the latest record is a tombstone,
+// only the rows of the records that changed are replaced.
+func (m *Model) compact(records []kadmin.ConsumerRecord) {
+	for _, rec := range records {
+		m.recordsRead++
+		key := keyOf(rec)
+		current, ok := m.latest[key]
+		if ok && !supersedes(rec, current) {
+			continue
+		}
+		if ok {
+			m.removeRow(current)
+		}
+		if rec.Tombstone {
+			delete(m.latest, key)
+			continue
+		}
+		m.latest[key] = rec
+		m.insertRow(rec)
+	}
+}
+
+func keyOf(rec kadmin.ConsumerRecord) compactionKey {
+	return compactionKey{key: rec.Key, null: rec.Key == "" && rec.RawKey == nil}
+}
+
+// supersedes returns true when the candidate record was written after the current one.
+// Within a partition the offset decides, across partitions only the timestamp can.
+func supersedes(candidate, current kadmin.ConsumerRecord) bool {
+	if candidate.Partition == current.Partition {
+		return candidate.Offset > current.Offset
+	}
+	return !candidate.Timestamp.Before(current.Timestamp)
+}
+
+// updateRows recreates the rows when the search term or sorting changed.
+func (m *Model) updateRows() {
+	criteria := rowsCriteria{
+		searchTerm: strings.ToLower(m.tcb.GetSearchTerm()),
+		sort:       m.sort,
+	}
+	if criteria == m.rowsFor {
+		return
+	}
+	m.rowsFor = criteria
+
+	m.visible = m.visible[:0]
+	for _, rec := range m.latest {
+		if m.matches(rec) {
+			m.visible = append(m.visible, rec)
+		}
+	}
+	slices.SortFunc(m.visible, m.compare)
+
+	m.rows = make([]table.Row, 0, len(m.visible))
+	for _, rec := range m.visible {
+		m.rows = append(m.rows, newRow(rec))
+	}
+}
+
+func (m *Model) insertRow(rec kadmin.ConsumerRecord) {
+	if !m.matches(rec) {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(m.visible, rec, m.compare)
+	m.visible = slices.Insert(m.visible, i, rec)
+	m.rows = slices.Insert(m.rows, i, newRow(rec))
+}
+
+func (m *Model) removeRow(rec kadmin.ConsumerRecord) {
+	if !m.matches(rec) {
+		return
+	}
+	if i, found := slices.BinarySearchFunc(m.visible, rec, m.compare); found {
+		m.visible = slices.Delete(m.visible, i, i+1)
+		m.rows = slices.Delete(m.rows, i, i+1)
+	}
+}
+
+func (m *Model) matches(rec kadmin.ConsumerRecord) bool {
+	searchTerm := m.rowsFor.searchTerm
+	return searchTerm == "" ||
+		strings.Contains(strings.ToLower(rec.Key), searchTerm) ||
+		strings.Contains(strings.ToLower(rec.Payload.Value), searchTerm)
+}
+
+// compare orders the records by the sorted column, records that are equal on it by key
+// to keep the order stable between renders as map iteration is random.
+func (m *Model) compare(a, b kadmin.ConsumerRecord) int {
+	var c int
+	switch m.rowsFor.sort.Label {
+	case "Key":
+		c = strings.Compare(displayKey(a), displayKey(b))
+	case "Value":
+		c = strings.Compare(displayValue(a), displayValue(b))
+	case "Partition":
+		c = cmp.Compare(a.Partition, b.Partition)
+	case "Offset":
+		c = cmp.Compare(a.Offset, b.Offset)
+	case "Timestamp":
+		c = a.Timestamp.Compare(b.Timestamp)
+	default:
+		panic(fmt.Sprintf("unexpected sort label: %s", m.rowsFor.sort.Label))
+	}
+	if m.rowsFor.sort.Direction == cmdbar.Desc {
+		c = -c
+	}
+	return cmp.Or(
+		c,
+		strings.Compare(displayKey(a), displayKey(b)),
+		cmp.Compare(a.Partition, b.Partition),
+		cmp.Compare(a.Offset, b.Offset),
+	)
+}
+
+func newRow(rec kadmin.ConsumerRecord) table.Row {
+	return table.Row{
+		displayKey(rec),
+		displayValue(rec),
+		strconv.FormatInt(rec.Partition, 10),
+		strconv.FormatInt(rec.Offset, 10),
+		rec.Timestamp.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func displayKey(rec kadmin.ConsumerRecord) string {
+	if keyOf(rec).null {
+		return "<null>"
+	}
+	if rec.Key == "" {
+		return `""`
+	}
+	return rec.Key
+}
+
+func displayValue(rec kadmin.ConsumerRecord) string {
+	return strings.Join(strings.Fields(rec.Payload.Value), " ")
+}
+
+func (m *Model) columnTitle(title string) string {
+	if m.sort.Label == title {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(styles.ColorPink)).
+			Bold(true).
+			Render(m.sort.Direction.String()) + " " + title
+	}
+	return title
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	if m.tcb.IsFocussed() {
+		shortCuts := m.tcb.Shortcuts()
+		if shortCuts != nil {
+			return shortCuts
+		}
+	}
+	if m.consuming {
+		return []statusbar.Shortcut{
+			{"View Record", "enter"},
+			{"Search", "/"},
+			{"Stop Reading", "F2"},
+			{"Go Back", "esc"},
+		}
+	}
+	return []statusbar.Shortcut{
+		{"View Record", "enter"},
+		{"Search", "/"},
+		{"Sort", "F3"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Table"
+}
+
+func New(
+	reader kadmin.RecordReader,
+	topic *kadmin.ListedTopic,
+	navigator tabs.TopicsTabNavigator,
+) (*Model, tea.Cmd) {
+	m := &Model{}
+	m.reader = reader
+	m.topic = topic
+	m.navigator = navigator
+	m.latest = make(map[compactionKey]kadmin.ConsumerRecord)
+	m.table = ktable.NewDefaultTable()
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar(name)
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.ConsumptionEndedMsg,
+			model *notifier.Model,
+		) (bool, tea.Cmd) {
+			model.ShowSuccessMsg(fmt.Sprintf("Read %d records", m.recordsRead))
+			return true, model.AutoHideCmd(name)
+		},
+	)
+
+	sortByBar := cmdbar.NewSortByCmdBar(
+		[]cmdbar.SortLabel{
+			{
+				Label:     "Key",
+				Direction: cmdbar.Asc,
+			},
+			{
+				Label:     "Value",
+				Direction: cmdbar.Asc,
+			},
+			{
+				Label:     "Partition",
+				Direction: cmdbar.Asc,
+			},
+			{
+				Label:     "Offset",
+				Direction: cmdbar.Desc,
+			},
+			{
+				Label:     "Timestamp",
+				Direction: cmdbar.Desc,
+			},
+		},
+		cmdbar.WithSortSelectedCallback(func(label cmdbar.SortLabel) {
+			m.sort = label
+		}),
+	)
+	m.sort = sortByBar.SortedBy()
+	m.rowsFor = rowsCriteria{sort: m.sort}
+
+	m.tcb = cmdbar.NewTableCmdsBar[string](
+		nil,
+		cmdbar.NewSearchCmdBar("Search by key or value"),
+		notifierCmdBar,
+		sortByBar,
+	)
+
+	m.border = border.New(
+		border.WithInnerPaddingTop(),
+		border.WithTitleFn(func() string {
+			title := border.KeyValueTitle(
+				"Distinct Keys",
+				fmt.Sprintf(" %d/%d", len(m.rows), len(m.latest)),
+				m.tableFocussed,
+			)
+			if m.consuming {
+				title += border.KeyValueTitle(
+					"Reading",
+					fmt.Sprintf(" %d records", m.recordsRead),
+					m.tableFocussed,
+				)
+			}
+			return title
+		}))
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	m.cancelConsumption = cancelFn
+
+	return m, func() tea.Msg {
+		return m.reader.ReadRecords(ctx, kadmin.NewCompactedReadDetails(topic))
+	}
+}
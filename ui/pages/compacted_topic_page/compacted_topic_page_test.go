@@ -0,0 +1,254 @@
+package compacted_topic_page
+
+import (
+	"fmt"
+	"ktea/kadmin"
+	"ktea/serdes"
+	"ktea/tests"
+	"ktea/ui/tabs"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactedTopicPage(t *testing.T) {
+	topic := &kadmin.ListedTopic{
+		Name:           "topic1",
+		PartitionCount: 2,
+		Replicas:       1,
+		Cleanup:        "compact",
+	}
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	record := func(key, value string, partition, offset int64) kadmin.ConsumerRecord {
+		return kadmin.ConsumerRecord{
+			Key:       key,
+			Payload:   serdes.DesData{Value: value},
+			Partition: partition,
+			Offset:    offset,
+			Timestamp: now.Add(time.Duration(offset) * time.Second),
+		}
+	}
+
+	tombstone := func(key string, partition, offset int64) kadmin.ConsumerRecord {
+		r := record(key, "", partition, offset)
+		r.Tombstone = true
+		return r
+	}
+
+	t.Run("reads all partitions from the beginning until the end", func(t *testing.T) {
+		_, cmd := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		assert.NotNil(t, cmd)
+		assert.Equal(t, kadmin.ReadDetails{
+			TopicName:       "topic1",
+			PartitionToRead: []int{0, 1},
+			StartPoint:      kadmin.Beginning,
+			Limit:           kadmin.NoLimit,
+		}, kadmin.NewCompactedReadDetails(topic))
+	})
+
+	t.Run("keeps only the latest value per key", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-1", "value-1-old", 0, 1),
+				record("key-2", "value-2", 1, 2),
+				record("key-1", "value-1-new", 0, 3),
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "value-1-new")
+		assert.Contains(t, render, "value-2")
+		assert.NotContains(t, render, "value-1-old")
+		assert.Contains(t, render, "Distinct Keys:  2/2")
+	})
+
+	t.Run("late arriving older records do not override newer ones", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-1", "value-1-new", 0, 3),
+				record("key-1", "value-1-old", 0, 1),
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "value-1-new")
+		assert.NotContains(t, render, "value-1-old")
+	})
+
+	t.Run("drops keys of which the latest record is a tombstone", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-1", "value-1", 0, 1),
+				record("key-2", "value-2", 0, 2),
+				tombstone("key-1", 0, 3),
+				tombstone("key-3", 0, 4),
+				record("key-3", "value-3", 0, 5),
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.NotContains(t, render, "key-1")
+		assert.Contains(t, render, "value-2")
+		assert.Contains(t, render, "value-3")
+		assert.Contains(t, render, "Distinct Keys:  2/2")
+	})
+
+	t.Run("null and empty keys are different keys", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+		empty := record("", "value-empty", 0, 2)
+		empty.RawKey = []byte{}
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("", "value-null", 0, 1),
+				empty,
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Regexp(t, `<null>\s+│?\s*value-null`, render)
+		assert.Regexp(t, `""\s+│?\s*value-empty`, render)
+		assert.Contains(t, render, "Distinct Keys:  2/2")
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{tombstone("", 0, 3)},
+		})
+
+		render = m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.NotContains(t, render, "value-null")
+		assert.Contains(t, render, "value-empty")
+	})
+
+	t.Run("replaces the rows of changed keys in sort order", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+		m.Update(tests.Key(tea.KeyF3))
+		// sort by Value
+		m.Update(tests.Key(tea.KeyRight))
+		m.Update(tests.Key(tea.KeyEnter))
+
+		for offset := int64(0); offset < 200; offset++ {
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: []kadmin.ConsumerRecord{
+					record(fmt.Sprintf("key-%d", offset%50), fmt.Sprintf("value-%d", (offset*37)%101), offset%2, offset),
+				},
+			})
+		}
+
+		incremental := slices.Clone(m.rows)
+		m.rowsFor = rowsCriteria{}
+		m.updateRows()
+
+		assert.Equal(t, "Value", m.sort.Label)
+		assert.Len(t, incremental, 50)
+		assert.Equal(t, m.rows, incremental)
+	})
+
+	t.Run("shows the offset the value came from", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-1", "value-1", 1, 1),
+				record("key-1", "value-1", 1, 1337),
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Regexp(t, `key-1\s+│?\s*value-1\s+│?\s*1\s+│?\s*1337`, render)
+	})
+
+	t.Run("search by key or value", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-1", "apple", 0, 1),
+				record("key-2", "banana", 0, 2),
+				record("key-3", "cherry", 0, 3),
+			},
+		})
+
+		m.Update(tests.Key('/'))
+		tests.UpdateKeys(m, "banana")
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "key-2")
+		assert.NotContains(t, render, "key-1")
+		assert.NotContains(t, render, "key-3")
+		assert.Contains(t, render, "Distinct Keys:  1/3")
+	})
+
+	t.Run("sorted by key by default", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-c", "value", 0, 1),
+				record("key-a", "value", 0, 2),
+				record("key-b", "value", 0, 3),
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Less(t, strings.Index(render, "key-a"), strings.Index(render, "key-b"))
+		assert.Less(t, strings.Index(render, "key-b"), strings.Index(render, "key-c"))
+	})
+
+	t.Run("enter loads record details of the selected key", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				record("key-b", "value-b", 0, 1),
+				record("key-a", "value-a", 0, 2),
+			},
+		})
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		m.Update(tests.Key(tea.KeyDown))
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+
+		msg := cmd().(tabs.ToRecordDetailsPageCalledMsg).Msg
+		assert.Equal(t, "key-b", msg.Record.Key)
+		assert.Equal(t, 1, msg.Index)
+		assert.Len(t, msg.Records, 2)
+	})
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		cmd := m.Update(tests.Key(tea.KeyEsc))
+
+		assert.IsType(t, tabs.ToTopicsPageCalledMsg{}, cmd())
+	})
+
+	t.Run("empty topic", func(t *testing.T) {
+		m, _ := New(kadmin.NewMockKadmin(), topic, tabs.NewMockTopicsTabNavigator())
+
+		m.Update(kadmin.EmptyTopicMsg{})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "Empty topic")
+	})
+}
@@ -31,6 +31,7 @@ type Model struct {
 	valueFilterSelectionState selectionState
 	startPointRelativeDate    selectionState
 	startPointAbsoluteDate    selectionState
+	customLimitSelectionState selectionState
 	ktx                       *kontext.ProgramKtx
 	availableHeight           int
 	topic                     *kadmin.ListedTopic
@@ -46,11 +47,15 @@ const (
 	absoluteDate
 )
 
+// customLimit is the limit option that lets the limit be typed in
+const customLimit = -2
+
 type formValues struct {
 	startFrom          startPoint
 	relativeStartPoint kadmin.StartPoint
 	absoluteStartPoint string
 	limit              int
+	customLimit        string
 	partitions         []int
 	keyFilter          kadmin.FilterType
 	keyFilterTerm      string
@@ -103,6 +108,18 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.form = m.newForm(m.topic.PartitionCount, m.ktx)
 	}
 
+	if m.formValues.limit == customLimit && m.customLimitSelectionState == notSelected {
+		// if custom limit is selected and previously not selected
+		m.customLimitSelectionState = selected
+		m.form = m.newForm(m.topic.PartitionCount, m.ktx)
+		m.NextField(m.limitFieldIndex())
+	} else if m.formValues.limit != customLimit && m.customLimitSelectionState == selected {
+		// if no custom limit is selected and previously selected
+		m.customLimitSelectionState = notSelected
+		m.form = m.newForm(m.topic.PartitionCount, m.ktx)
+		m.NextField(m.limitFieldIndex())
+	}
+
 	if m.formValues.keyFilter != kadmin.NoFilterType && m.keyFilterSelectionState == notSelected {
 		// if key filter type is selected and previously not selected
 		m.keyFilterSelectionState = selected
@@ -177,12 +194,32 @@ func (m *Model) submit(filter kadmin.Filter) tea.Cmd {
 			TopicName:       m.topic.Name,
 			PartitionToRead: partToConsume,
 			StartPoint:      m.toStartPoint(),
-			Limit:           m.formValues.limit,
+			Limit:           m.toLimit(),
 			Filter:          &filter,
 		},
 	})
 }
 
+func (m *Model) toLimit() int {
+	if m.formValues.limit == customLimit {
+		limit, _ := strconv.Atoi(m.formValues.customLimit)
+		return limit
+	}
+	return m.formValues.limit
+}
+
+// limitFieldIndex returns the position of the limit field within the topic group
+func (m *Model) limitFieldIndex() int {
+	index := 2
+	if m.startPointRelativeDate == selected {
+		index++
+	}
+	if m.startPointAbsoluteDate == selected {
+		index++
+	}
+	return index
+}
+
 func (m *Model) toStartPoint() kadmin.StartPoint {
 	switch m.formValues.startFrom {
 	case beginning:
@@ -231,6 +268,10 @@ func (m *Model) newForm(partitions int, ktx *kontext.ProgramKtx) *huh.Form {
 		optionsHeight += 4
 	}
 
+	if m.customLimitSelectionState == selected {
+		optionsHeight += 3
+	}
+
 	if len(partOptions) < 13 {
 		optionsHeight = len(partOptions) + 2 // 2 for field title + padding
 	} else {
@@ -309,7 +350,23 @@ func (m *Model) createTopicGroup(
 			Options(
 				huh.NewOption("50", 50),
 				huh.NewOption("500", 500),
-				huh.NewOption("5000", 5000)))
+				huh.NewOption("5000", 5000),
+				huh.NewOption("No Limit", kadmin.NoLimit),
+				huh.NewOption("Custom", customLimit)))
+
+	if m.formValues.limit == customLimit {
+		fields = append(
+			fields,
+			huh.NewInput().
+				Value(&m.formValues.customLimit).
+				Validate(func(v string) error {
+					if limit, e := strconv.Atoi(v); e != nil || limit < 1 {
+						return fmt.Errorf("limit must be a positive number")
+					}
+					return nil
+				}).
+				Title("Custom Limit"))
+	}
 	return fields
 }
 
@@ -388,15 +445,23 @@ func NewWithDetails(
 	if topic.PartitionCount != len(details.PartitionToRead) {
 		partitionsToRead = details.PartitionToRead
 	}
+	limit, limitSelectionState, custom := details.Limit, notSelected, ""
+	switch details.Limit {
+	case 50, 500, 5000, kadmin.NoLimit:
+	default:
+		limit, limitSelectionState, custom = customLimit, selected, strconv.Itoa(details.Limit)
+	}
 	return &Model{
-		ktx:       ktx,
-		navigator: navigator,
-		topic:     topic,
+		ktx:                       ktx,
+		navigator:                 navigator,
+		topic:                     topic,
+		customLimitSelectionState: limitSelectionState,
 		formValues: &formValues{
 			startFrom:          toFormStartPoint(details.StartPoint),
 			absoluteStartPoint: toAbsoluteStartPoint(details.StartPoint),
 			relativeStartPoint: details.StartPoint,
-			limit:              details.Limit,
+			limit:              limit,
+			customLimit:        custom,
 			partitions:         partitionsToRead,
 			keyFilter:          details.Filter.KeyFilter,
 			keyFilterTerm:      details.Filter.KeySearchTerm,
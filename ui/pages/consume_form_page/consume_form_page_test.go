@@ -377,6 +377,98 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		}, msgs[0].(tabs.ToConsumePageCalledMsg).Details)
 	})
 
+	t.Run("Limit", func(t *testing.T) {
+		topic := &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
+		}
+		toLimitField := func(m *Model) {
+			// start from beginning
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// select no partitions
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+		}
+		submitFilters := func(m *Model, cmd tea.Cmd) []tea.Msg {
+			// next field
+			cmd = m.Update(cmd())
+			// next group
+			m.Update(cmd())
+			// no key filter
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// no value filter
+			return tests.Submit(m)
+		}
+
+		t.Run("no limit", func(t *testing.T) {
+			m := New(topic, tabs.NewMockTopicsTabNavigator(), tests.NewKontext())
+			m.View(tests.NewKontext(), tests.Renderer)
+			toLimitField(m)
+
+			for i := 0; i < 3; i++ {
+				m.Update(tests.Key(tea.KeyDown))
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			msgs := submitFilters(m, cmd)
+
+			assert.Equal(t, kadmin.NoLimit, msgs[0].(tabs.ToConsumePageCalledMsg).Details.ReadDetails.Limit)
+		})
+
+		t.Run("custom limit", func(t *testing.T) {
+			m := New(topic, tabs.NewMockTopicsTabNavigator(), tests.NewKontext())
+			m.View(tests.NewKontext(), tests.Renderer)
+			toLimitField(m)
+
+			assert.NotContains(t, m.View(tests.NewKontext(), tests.Renderer), "Custom Limit")
+			for i := 0; i < 4; i++ {
+				m.Update(tests.Key(tea.KeyDown))
+			}
+			assert.Contains(t, m.View(tests.NewKontext(), tests.Renderer), "Custom Limit")
+
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			tests.UpdateKeys(m, "12345")
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			msgs := submitFilters(m, cmd)
+
+			assert.Equal(t, 12345, msgs[0].(tabs.ToConsumePageCalledMsg).Details.ReadDetails.Limit)
+		})
+
+		t.Run("custom limit must be a positive number", func(t *testing.T) {
+			m := New(topic, tabs.NewMockTopicsTabNavigator(), tests.NewKontext())
+			m.View(tests.NewKontext(), tests.Renderer)
+			toLimitField(m)
+
+			for i := 0; i < 4; i++ {
+				m.Update(tests.Key(tea.KeyDown))
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			tests.UpdateKeys(m, "-5")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			assert.Contains(t, m.View(tests.NewKontext(), tests.Renderer), "limit must be a positive number")
+		})
+
+		t.Run("custom limit is restored from previous ReadDetails", func(t *testing.T) {
+			m := NewWithDetails(&kadmin.ReadDetails{
+				TopicName:       "topic1",
+				PartitionToRead: []int{0},
+				StartPoint:      kadmin.Beginning,
+				Limit:           1234,
+				Filter:          &kadmin.Filter{},
+			}, topic, tabs.NewMockTopicsTabNavigator(), tests.NewKontext())
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "Custom Limit")
+			assert.Contains(t, render, "1234")
+		})
+	})
+
 	t.Run("selecting key filter type starts-with displays key filter value field", func(t *testing.T) {
 		m := New(
 			&kadmin.ListedTopic{
@@ -70,7 +70,7 @@ func (c *ConsumptionCmdBar) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	switch msg := msg.(type) {
-	case *kadmin.ReadingStartedMsg:
+	case *kadmin.ReadingStartedMsg, noMoreRecordsMsg:
 		c.active = c.notifierWidget
 		_, _, cmd := c.active.Update(msg)
 		return cmd
@@ -94,11 +94,15 @@ func (c *ConsumptionCmdBar) GetSearchTerm() string {
 	return c.searchCBar.GetSearchTerm()
 }
 
+func (c *ConsumptionCmdBar) IsSearching() bool {
+	return c.active == c.searchCBar && c.searchCBar.IsFocussed()
+}
+
 func (c *ConsumptionCmdBar) IsSorting() bool {
 	return c.active == c.sortByCBar
 }
 
-func NewConsumptionCmdbar() *ConsumptionCmdBar {
+func NewConsumptionCmdbar(initialSort cmdbar.SortByCmdBarOption) *ConsumptionCmdBar {
 	readingStartedNotifier := func(msg *kadmin.ReadingStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
 		return true, m.SpinWithLoadingMsg("Consuming")
 	}
@@ -117,12 +121,25 @@ func NewConsumptionCmdbar() *ConsumptionCmdBar {
 		m.Idle()
 		return false, nil
 	}
+	noMoreRecordsHandler := func(msg noMoreRecordsMsg, m *notifier.Model) (bool, tea.Cmd) {
+		if msg.direction == previousPage {
+			m.ShowInfoMsg("No older records")
+		} else {
+			m.ShowInfoMsg("No newer records")
+		}
+		return true, m.AutoHideCmd("consumption-bar")
+	}
+	readingFailedHandler := func(msg readingFailedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.ShowErrorMsg("Failed to read records", msg.err)
+	}
 	notifierCmdBar := cmdbar.NewNotifierCmdBar("consumption-bar")
 	cmdbar.BindNotificationHandler(notifierCmdBar, readingStartedNotifier)
 	cmdbar.BindNotificationHandler(notifierCmdBar, consumptionEndedNotifier)
 	cmdbar.BindNotificationHandler(notifierCmdBar, emptyTopicMsgHandler)
 	cmdbar.BindNotificationHandler(notifierCmdBar, noRecordFoundMsgHandler)
 	cmdbar.BindNotificationHandler(notifierCmdBar, c)
+	cmdbar.BindNotificationHandler(notifierCmdBar, noMoreRecordsHandler)
+	cmdbar.BindNotificationHandler(notifierCmdBar, readingFailedHandler)
 
 	sortByCmdBar := cmdbar.NewSortByCmdBar(
 		[]cmdbar.SortLabel{
@@ -143,7 +160,7 @@ func NewConsumptionCmdbar() *ConsumptionCmdBar {
 				Direction: cmdbar.Desc,
 			},
 		},
-		cmdbar.WithInitialSortColumn("Timestamp", cmdbar.Desc),
+		initialSort,
 	)
 
 	return &ConsumptionCmdBar{
@@ -1,6 +1,7 @@
 package consume_page
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"ktea/kadmin"
@@ -11,24 +12,38 @@ import (
 	"ktea/ui/components/cmdbar"
 	"ktea/ui/components/statusbar"
 	"ktea/ui/pages"
+	"ktea/ui/pages/nav"
 	"ktea/ui/tabs"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type Model struct {
-	table              *table.Model
-	border             *border.Model
-	cmdBar             *ConsumptionCmdBar
-	cancelConsumption  context.CancelFunc
-	reader             kadmin.RecordReader
-	rows               []table.Row
-	records            []kadmin.ConsumerRecord
+	table             *table.Model
+	border            *border.Model
+	cmdBar            *ConsumptionCmdBar
+	cancelConsumption context.CancelFunc
+	reader            kadmin.RecordReader
+	records           []kadmin.ConsumerRecord
+	// index holds the positions in records of the searched and sorted records,
+	// only the rows of the visible window are materialized
+	index      []int
+	indexedFor indexCriteria
+	// indexed is the number of records the index covered, prepended and dropped count the records
+	// added before or evicted from the front of records since, so only the new ones have to be indexed
+	indexed            int
+	prepended          int
+	dropped            int
+	cursor             int
+	windowStart        int
+	windowSize         int
 	readDetails        kadmin.ReadDetails
 	consuming          bool
 	noRecordsAvailable bool
@@ -36,6 +51,42 @@ type Model struct {
 	topic              *kadmin.ListedTopic
 	origin             tabs.Origin
 	navigator          tabs.TopicsTabNavigator
+	paging             pageDirection
+	// read holds the offsets read so far per partition, pages continue from its bounds
+	read map[int]kadmin.OffsetRange
+	// cursorRecord is the record the cursor has to stay on while a page is being loaded
+	cursorRecord *kadmin.ConsumerRecord
+	// paused live consumption keeps receiving records in pending without updating the table
+	paused  bool
+	pending []kadmin.ConsumerRecord
+	// follow keeps the cursor on the newest record while live consuming
+	follow bool
+	rate   *rateMeter
+	// failedRecords is the number of records that could not be deserialized
+	failedRecords int
+}
+
+// indexCriteria are the search and sort settings the index was built for
+type indexCriteria struct {
+	searchTerm string
+	sort       cmdbar.SortLabel
+}
+
+type pageDirection int
+
+const (
+	noPaging pageDirection = iota
+	previousPage
+	nextPage
+)
+
+// noMoreRecordsMsg indicates that there are no records before or after the loaded ones
+type noMoreRecordsMsg struct {
+	direction pageDirection
+}
+
+type readingFailedMsg struct {
+	err error
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -59,9 +110,11 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 			{Title: m.cmdBar.sortByCBar.PrefixSortIcon("Partition"), Width: PCol},
 			{Title: m.cmdBar.sortByCBar.PrefixSortIcon("Offset"), Width: oCol},
 		})
-		m.table.SetRows(m.rows)
 		m.table.SetWidth(ktx.WindowWidth - 2)
 		m.table.SetHeight(ktx.AvailableTableHeight())
+		m.windowSize = max(m.table.Height(), 1)
+		m.table.SetRows(m.visibleRows())
+		m.table.SetCursor(m.cursor - m.windowStart)
 
 		views = append(views, m.border.View(m.table.View()))
 	}
@@ -77,6 +130,13 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		if msg.String() == "esc" {
 			m.cancelConsumption()
 
+			if m.origin == tabs.OriginKeyLookupPage {
+				return ui.PublishMsg(nav.LoadKeyLookupPageMsg{
+					Topic: m.topic,
+					Key:   m.readDetails.Filter.KeySearchTerm,
+				})
+			}
+
 			if m.readDetails.StartPoint == kadmin.Live || m.origin == tabs.OriginTopicsPage {
 				return m.navigator.ToTopicsPage()
 			}
@@ -91,13 +151,28 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			m.cancelConsumption()
 			m.consuming = false
 			cmds = append(cmds, ui.PublishMsg(kadmin.ConsumptionEndedMsg{}))
+		} else if msg.String() == "ctrl+p" || msg.String() == "ctrl+n" {
+			if !m.cmdBar.IsFocussed() && m.canPage() {
+				direction := nextPage
+				if msg.String() == "ctrl+p" {
+					direction = previousPage
+				}
+				return m.loadPage(direction)
+			}
+		} else if msg.String() == "p" {
+			if !m.cmdBar.IsSearching() && m.isLive() {
+				m.togglePause()
+			}
+		} else if msg.String() == "F" {
+			if !m.cmdBar.IsSearching() && m.isLive() {
+				m.follow = !m.follow
+			}
 		} else if msg.String() == "enter" {
 			if !m.cmdBar.IsFocussed() {
-				if len(m.records) > 0 {
-					selectedRow := m.rows[m.table.Cursor()]
-					selectedRecord := m.recordForRow(selectedRow)
+				if len(m.index) > 0 {
+					recordIndex := m.index[m.cursor]
+					selectedRecord := m.records[recordIndex]
 					m.consuming = false
-					recordIndex := m.recordIndexForRow(selectedRow)
 					return m.navigator.ToRecordDetailsPage(
 						tabs.LoadRecordDetailPageMsg{
 							Record:    &selectedRecord,
@@ -108,124 +183,415 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 				}
 			}
 		}
-	case kadmin.EmptyTopicMsg:
-		m.noRecordsAvailable = true
-		m.consuming = false
-	case kadmin.NoRecordsFound:
-		m.noRecordsFound = true
+	case kadmin.EmptyTopicMsg, kadmin.NoRecordsFound:
 		m.consuming = false
+		if m.paging != noPaging {
+			cmds = append(cmds, ui.PublishMsg(noMoreRecordsMsg{m.paging}))
+			m.stopPaging()
+		} else if _, ok := msg.(kadmin.EmptyTopicMsg); ok {
+			m.noRecordsAvailable = true
+		} else {
+			m.noRecordsFound = true
+		}
 	case *kadmin.ReadingStartedMsg:
 		m.consuming = true
 		cmds = append(cmds, msg.AwaitRecord)
+	case error:
+		// reading stops at the first error
+		m.cancelConsumption()
+		m.consuming = false
+		m.stopPaging()
+		cmds = append(cmds, ui.PublishMsg(readingFailedMsg{msg}))
 	case kadmin.ConsumptionEndedMsg:
 		m.consuming = false
+		m.stopPaging()
+		m.extendRead(msg.ReadRanges)
+		// a key lookup reads until the end of the partition, nothing read means the key was not found
+		if m.readDetails.IsKeyLookup() && len(m.records) == 0 {
+			m.noRecordsFound = true
+		}
 	case kadmin.ConsumerRecordReceived:
-		m.records = append(m.records, msg.Records...)
+		if m.isLive() {
+			m.rate.record(len(msg.Records))
+			if m.paused {
+				m.pending = m.retainNewest(append(m.pending, msg.Records...))
+			} else {
+				m.appendNewest(msg.Records)
+			}
+		} else if m.paging == previousPage {
+			m.records = append(msg.Records, m.records...)
+			m.prepended += len(msg.Records)
+			m.failedRecords += countFailed(msg.Records)
+		} else {
+			m.records = append(m.records, msg.Records...)
+			m.failedRecords += countFailed(msg.Records)
+		}
 		cmds = append(cmds, msg.AwaitNextRecord)
 	}
 
 	cmd := m.cmdBar.Update(msg)
 	cmds = append(cmds, cmd)
 
-	m.rows = m.createRows()
+	m.updateIndex()
+	m.restoreCursor()
+	if m.follow && !m.paused && len(m.records) > 0 {
+		m.moveCursorTo(len(m.records) - 1)
+	}
 
 	// make sure table navigation is off when the cmdbar is focussed
-	if !m.cmdBar.IsFocussed() {
-		t, cmd := m.table.Update(msg)
-		m.table = &t
-		cmds = append(cmds, cmd)
+	if msg, ok := msg.(tea.KeyMsg); ok && !m.cmdBar.IsFocussed() {
+		m.navigate(msg)
 	}
 
 	return tea.Batch(cmds...)
 }
 
-func (m *Model) recordForRow(row table.Row) kadmin.ConsumerRecord {
-	offset, _ := strconv.ParseInt(row[3], 10, 64)
-	partition, _ := strconv.ParseInt(row[2], 10, 32)
+func (m *Model) isLive() bool {
+	return m.readDetails.StartPoint == kadmin.Live
+}
+
+// retainNewest drops the oldest records once the live buffer size is exceeded.
+// The records are resliced rather than copied, the evicted ones are released
+// once appending outgrows the underlying array and moves the retained ones to a new one.
+func (m *Model) retainNewest(records []kadmin.ConsumerRecord) []kadmin.ConsumerRecord {
+	if m.readDetails.Limit <= 0 || len(records) <= m.readDetails.Limit {
+		return records
+	}
+	return records[len(records)-m.readDetails.Limit:]
+}
+
+// appendNewest appends live records to the loaded ones, keeping only the newest.
+func (m *Model) appendNewest(records []kadmin.ConsumerRecord) {
+	all := append(m.records, records...)
+	m.records = m.retainNewest(all)
+	dropped := len(all) - len(m.records)
+	m.failedRecords += countFailed(records) - countFailed(all[:dropped])
+	m.dropped += dropped
+}
+
+func countFailed(records []kadmin.ConsumerRecord) int {
+	failed := 0
+	for _, rec := range records {
+		if rec.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (m *Model) togglePause() {
+	if m.paused {
+		m.appendNewest(m.pending)
+		m.pending = nil
+	}
+	m.paused = !m.paused
+}
+
+func (m *Model) canPage() bool {
+	return !m.consuming &&
+		len(m.records) > 0 &&
+		m.readDetails.StartPoint != kadmin.Live &&
+		m.readDetails.Limit != kadmin.NoLimit
+}
+
+// extendRead adds the ranges read by a consumption to the ones read before,
+// a range that does not connect to the one read before, e.g. of a cancelled page, is ignored.
+func (m *Model) extendRead(ranges map[int]kadmin.OffsetRange) {
+	for partition, r := range ranges {
+		read, ok := m.read[partition]
+		if !ok {
+			m.read[partition] = r
+		} else if r.Start <= read.End+1 && r.End+1 >= read.Start {
+			m.read[partition] = kadmin.OffsetRange{
+				Start: min(read.Start, r.Start),
+				End:   max(read.End, r.End),
+			}
+		}
+	}
+}
+
+// loadPage reads the records adjacent to the lowest or highest read offset of each partition,
+// partitions without loaded records included.
+func (m *Model) loadPage(direction pageDirection) tea.Cmd {
+	lowest := make(map[int]int64)
+	highest := make(map[int]int64)
+	for partition, r := range m.read {
+		lowest[partition] = r.Start
+		highest[partition] = r.End
+	}
 	for _, rec := range m.records {
-		if rec.Partition == partition &&
-			rec.Offset == offset {
-			return rec
+		p := int(rec.Partition)
+		if o, ok := lowest[p]; !ok || rec.Offset < o {
+			lowest[p] = rec.Offset
 		}
+		if o, ok := highest[p]; !ok || rec.Offset > o {
+			highest[p] = rec.Offset
+		}
+	}
+
+	pageSize := max(int64(m.readDetails.Limit/len(lowest)), 1)
+	var rd kadmin.ReadDetails
+	if direction == previousPage {
+		rd = m.readDetails.PreviousPage(lowest, pageSize)
+	} else {
+		rd = m.readDetails.NextPage(highest, pageSize)
 	}
-	panic(fmt.Sprintf("Record not found for row: %v", row))
+
+	if len(m.index) > 0 {
+		rec := m.records[m.index[m.cursor]]
+		m.cursorRecord = &rec
+	}
+	m.paging = direction
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	m.cancelConsumption = cancelFn
+	return func() tea.Msg {
+		return m.reader.ReadRecords(ctx, rd)
+	}
+}
+
+func (m *Model) stopPaging() {
+	m.paging = noPaging
+	m.cursorRecord = nil
 }
 
-func (m *Model) recordIndexForRow(row table.Row) int {
-	offset, _ := strconv.ParseInt(row[3], 10, 64)
-	partition, _ := strconv.ParseInt(row[2], 10, 32)
+// restoreCursor keeps the cursor on the same record while records are being added by paging.
+func (m *Model) restoreCursor() {
+	if m.cursorRecord == nil {
+		return
+	}
 	for i, rec := range m.records {
-		if rec.Partition == partition &&
-			rec.Offset == offset {
-			return i
+		if rec.Partition == m.cursorRecord.Partition && rec.Offset == m.cursorRecord.Offset {
+			m.moveCursorTo(i)
+			return
 		}
 	}
-	panic(fmt.Sprintf("Record not found for row: %v", row))
 }
 
-func (m *Model) createRows() []table.Row {
-	var rows []table.Row
-	for _, rec := range m.records {
+// moveCursorTo moves the cursor to the given position in records when it is part of the index.
+func (m *Model) moveCursorTo(recordIndex int) {
+	for i, idx := range m.index {
+		if idx == recordIndex {
+			m.setCursor(i)
+			return
+		}
+	}
+}
 
-		var key string
-		if rec.Key == "" {
-			key = "<null>"
-		} else {
-			key = rec.Key
+func (m *Model) setCursor(cursor int) {
+	m.cursor = max(min(cursor, len(m.index)-1), 0)
+	if m.cursor < m.windowStart {
+		m.windowStart = m.cursor
+	} else if m.cursor >= m.windowStart+m.windowSize {
+		m.windowStart = m.cursor - m.windowSize + 1
+	}
+}
+
+func (m *Model) navigate(msg tea.KeyMsg) {
+	keys := m.table.KeyMap
+	switch {
+	case key.Matches(msg, keys.LineUp):
+		m.setCursor(m.cursor - 1)
+	case key.Matches(msg, keys.LineDown):
+		m.setCursor(m.cursor + 1)
+	case key.Matches(msg, keys.PageUp):
+		m.setCursor(m.cursor - m.windowSize)
+	case key.Matches(msg, keys.PageDown):
+		m.setCursor(m.cursor + m.windowSize)
+	case key.Matches(msg, keys.HalfPageUp):
+		m.setCursor(m.cursor - m.windowSize/2)
+	case key.Matches(msg, keys.HalfPageDown):
+		m.setCursor(m.cursor + m.windowSize/2)
+	case key.Matches(msg, keys.GotoTop):
+		m.setCursor(0)
+	case key.Matches(msg, keys.GotoBottom):
+		m.setCursor(len(m.index) - 1)
+	}
+}
+
+// updateIndex rebuilds the index when the search term or sorting changed,
+// records added since the last update are sorted on their own and merged into the index.
+func (m *Model) updateIndex() {
+	criteria := indexCriteria{
+		searchTerm: strings.ToLower(m.cmdBar.GetSearchTerm()),
+		sort:       m.cmdBar.sortByCBar.SortedBy(),
+	}
+	if criteria != m.indexedFor {
+		m.rebuildIndex(criteria)
+		return
+	}
+	if m.prepended == 0 && m.dropped == 0 && m.indexed == len(m.records) {
+		return
+	}
+
+	// records are either prepended while paging or dropped while live consuming, never both
+	shift := m.prepended - m.dropped
+
+	// keep the cursor on the selected record when records are added
+	selected := -1
+	if len(m.index) > 0 && m.paging == noPaging {
+		selected = m.index[m.cursor] + shift
+	}
+
+	index := m.index[:0]
+	for _, idx := range m.index {
+		if idx+shift >= 0 {
+			index = append(index, idx+shift)
 		}
+	}
 
-		if m.cmdBar.GetSearchTerm() != "" {
-			if strings.Contains(strings.ToLower(rec.Key), strings.ToLower(m.cmdBar.GetSearchTerm())) ||
-				strings.Contains(strings.ToLower(rec.Payload.Value), strings.ToLower(m.cmdBar.GetSearchTerm())) {
-				rows = append(
-					rows,
-					table.Row{
-						key,
-						rec.Timestamp.Format("2006-01-02 15:04:05"),
-						strconv.FormatInt(rec.Partition, 10),
-						strconv.FormatInt(rec.Offset, 10),
-					},
-				)
-			}
-		} else {
-			rows = append(
-				rows,
-				table.Row{
-					key,
-					rec.Timestamp.Format("2006-01-02 15:04:05"),
-					strconv.FormatInt(rec.Partition, 10),
-					strconv.FormatInt(rec.Offset, 10),
-				},
-			)
+	var added []int
+	for i := 0; i < m.prepended; i++ {
+		if m.matches(i, criteria) {
+			added = append(added, i)
+		}
+	}
+	for i := max(m.indexed+shift, m.prepended); i < len(m.records); i++ {
+		if m.matches(i, criteria) {
+			added = append(added, i)
+		}
+	}
+	if len(added) > 0 {
+		order := m.order(criteria)
+		slices.SortFunc(added, order)
+		index = merge(index, added, order)
+	}
+
+	m.index = index
+	m.indexed = len(m.records)
+	m.prepended = 0
+	m.dropped = 0
+
+	if selected >= 0 && !m.isLive() {
+		m.moveCursorTo(selected)
+	} else {
+		m.setCursor(m.cursor)
+	}
+}
+
+func (m *Model) rebuildIndex(criteria indexCriteria) {
+	index := make([]int, 0, len(m.records))
+	for i := range m.records {
+		if m.matches(i, criteria) {
+			index = append(index, i)
+		}
+	}
+	slices.SortFunc(index, m.order(criteria))
+
+	m.index = index
+	m.indexedFor = criteria
+	m.indexed = len(m.records)
+	m.prepended = 0
+	m.dropped = 0
+	m.setCursor(m.cursor)
+}
+
+func (m *Model) matches(i int, criteria indexCriteria) bool {
+	rec := &m.records[i]
+	return criteria.searchTerm == "" ||
+		strings.Contains(strings.ToLower(rec.Key), criteria.searchTerm) ||
+		strings.Contains(strings.ToLower(rec.Payload.Value), criteria.searchTerm)
+}
+
+// order compares the positions of two records by the sorted column,
+// records that compare equal keep the order in which they were loaded.
+func (m *Model) order(criteria indexCriteria) func(a, b int) int {
+	compare := comparator(criteria.sort.Label)
+	return func(a, b int) int {
+		c := compare(&m.records[a], &m.records[b])
+		if criteria.sort.Direction == cmdbar.Desc {
+			c = -c
+		}
+		if c == 0 {
+			return cmp.Compare(a, b)
 		}
+		return c
 	}
+}
 
-	sort.SliceStable(rows, func(i, j int) bool {
-		var col int
-		switch m.cmdBar.sortByCBar.SortedBy().Label {
-		case "Key":
-			col = 0
-		case "Timestamp":
-			col = 1
-		case "Partition":
-			col = 2
-		case "Offset":
-			col = 3
-		default:
-			panic(fmt.Sprintf("unexpected sort label: %s", m.cmdBar.sortByCBar.SortedBy().Label))
+// merge merges two sorted indexes into a new one.
+func merge(a, b []int, order func(a, b int) int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if order(a[0], b[0]) <= 0 {
+			merged = append(merged, a[0])
+			a = a[1:]
+		} else {
+			merged = append(merged, b[0])
+			b = b[1:]
 		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
 
-		if m.cmdBar.sortByCBar.SortedBy().Direction == cmdbar.Asc {
-			return rows[i][col] < rows[j][col]
+func comparator(label string) func(a, b *kadmin.ConsumerRecord) int {
+	switch label {
+	case "Key":
+		return func(a, b *kadmin.ConsumerRecord) int {
+			return strings.Compare(a.Key, b.Key)
+		}
+	case "Timestamp":
+		return func(a, b *kadmin.ConsumerRecord) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		}
+	case "Partition":
+		return func(a, b *kadmin.ConsumerRecord) int {
+			return cmp.Compare(a.Partition, b.Partition)
 		}
-		return rows[i][col] > rows[j][col]
-	})
+	case "Offset":
+		return func(a, b *kadmin.ConsumerRecord) int {
+			return cmp.Compare(a.Offset, b.Offset)
+		}
+	default:
+		panic(fmt.Sprintf("unexpected sort label: %s", label))
+	}
+}
+
+// visibleRows materializes the rows of the window the cursor is in.
+func (m *Model) visibleRows() []table.Row {
+	m.setCursor(m.cursor)
+	end := min(m.windowStart+m.windowSize, len(m.index))
+	m.windowStart = max(min(m.windowStart, end-m.windowSize), 0)
 
+	rows := make([]table.Row, 0, end-m.windowStart)
+	for _, idx := range m.index[m.windowStart:end] {
+		rec := m.records[idx]
+		key := rec.Key
+		if key == "" {
+			key = "<null>"
+		}
+		if rec.Err != nil {
+			key = "⚠ " + key
+		}
+		rows = append(rows, table.Row{
+			key,
+			rec.Timestamp.Format("2006-01-02 15:04:05"),
+			strconv.FormatInt(rec.Partition, 10),
+			strconv.FormatInt(rec.Offset, 10),
+		})
+	}
 	return rows
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
-	if m.consuming {
+	if m.consuming && m.isLive() {
+		pause := statusbar.Shortcut{Name: "Pause", Keybinding: "p"}
+		if m.paused {
+			pause = statusbar.Shortcut{Name: "Resume", Keybinding: "p"}
+		}
+		follow := statusbar.Shortcut{Name: "Follow", Keybinding: "F"}
+		if m.follow {
+			follow = statusbar.Shortcut{Name: "Unfollow", Keybinding: "F"}
+		}
+		return []statusbar.Shortcut{
+			{"View Record", "enter"},
+			pause,
+			follow,
+			{"Stop consuming", "F2"},
+			{"Go Back", "esc"},
+		}
+	} else if m.consuming {
 		return []statusbar.Shortcut{
 			{"View Record", "enter"},
 			{"Stop consuming", "F2"},
@@ -243,14 +609,23 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 		}
 	}
 
-	return []statusbar.Shortcut{
+	shortcuts := []statusbar.Shortcut{
 		{"View Record", "enter"},
 		{"Sort", "F3"},
-		{"Go Back", "esc"},
 	}
+	if m.canPage() {
+		shortcuts = append(shortcuts,
+			statusbar.Shortcut{Name: "Older Records", Keybinding: "C-p"},
+			statusbar.Shortcut{Name: "Newer Records", Keybinding: "C-n"},
+		)
+	}
+	return append(shortcuts, statusbar.Shortcut{Name: "Go Back", Keybinding: "esc"})
 }
 
 func (m *Model) Title() string {
+	if m.readDetails.IsKeyLookup() {
+		return "Topics / " + m.readDetails.TopicName + " / Key " + m.readDetails.Filter.KeySearchTerm
+	}
 	return "Topics / " + m.readDetails.TopicName + " / Records"
 }
 
@@ -269,11 +644,19 @@ func New(
 	)
 	m.table = &t
 	m.reader = reader
-	m.cmdBar = NewConsumptionCmdbar()
+	if readDetails.IsKeyLookup() {
+		// list the history of a key chronologically
+		m.cmdBar = NewConsumptionCmdbar(cmdbar.WithInitialSortColumn("Offset", cmdbar.Asc))
+	} else {
+		m.cmdBar = NewConsumptionCmdbar(cmdbar.WithInitialSortColumn("Timestamp", cmdbar.Desc))
+	}
 	m.readDetails = readDetails
 	m.topic = topic
 	m.navigator = navigator
 	m.origin = origin
+	m.rate = newRateMeter(time.Now)
+	m.windowSize = 1
+	m.read = make(map[int]kadmin.OffsetRange)
 
 	ctx, cancelFn := context.WithCancel(context.Background())
 	m.cancelConsumption = cancelFn
@@ -281,7 +664,21 @@ func New(
 	m.border = border.New(
 		border.WithInnerPaddingTop(),
 		border.WithTitleFn(func() string {
-			return border.KeyValueTitle("Records", fmt.Sprintf(" %d", len(m.rows)), true)
+			title := border.KeyValueTitle("Records", fmt.Sprintf(" %d", len(m.index)), true)
+			if m.failedRecords > 0 {
+				title += border.KeyValueTitle("Deserialization Errors", fmt.Sprintf(" %d", m.failedRecords), true)
+			}
+			if !m.isLive() {
+				return title
+			}
+			title += border.KeyValueTitle("Rate", fmt.Sprintf(" %.1f msg/s", m.rate.perSecond()), true)
+			if m.paused {
+				title += border.KeyValueTitle("Paused", fmt.Sprintf(" %d new", len(m.pending)), true)
+			}
+			if m.follow {
+				title += border.KeyValueTitle("Following", "", true)
+			}
+			return title
 		}))
 	return m, func() tea.Msg {
 		return m.reader.ReadRecords(ctx, readDetails)
@@ -1,12 +1,16 @@
 package consume_page
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"ktea/kadmin"
 	"ktea/serdes"
 	"ktea/tests"
 	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
 	"ktea/ui/tabs"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +19,15 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type recordingReader struct {
+	readDetails []kadmin.ReadDetails
+}
+
+func (r *recordingReader) ReadRecords(_ context.Context, rd kadmin.ReadDetails) tea.Msg {
+	r.readDetails = append(r.readDetails, rd)
+	return nil
+}
+
 func TestConsumptionPage(t *testing.T) {
 	t.Run("Display empty topic message and adjusted shortcuts", func(t *testing.T) {
 		m, _ := New(
@@ -124,6 +137,32 @@ func TestConsumptionPage(t *testing.T) {
 		assert.Equal(t, 10, count, "expected <null> to appear 10 times")
 	})
 
+	t.Run("records that failed to deserialize are marked", func(t *testing.T) {
+		m, _ := New(
+			kadmin.NewMockKadmin(),
+			kadmin.ReadDetails{},
+			&kadmin.ListedTopic{},
+			tabs.OriginTopicsPage,
+			tabs.NewMockTopicsTabNavigator(),
+		)
+
+		now := time.Now()
+		m.Update(kadmin.ConsumerRecordReceived{
+			Records: []kadmin.ConsumerRecord{
+				{Key: "k1", Offset: 0, Timestamp: now},
+				{Key: "k2", Offset: 1, Timestamp: now.Add(time.Second), Err: fmt.Errorf("protobuf deserialization failed")},
+				{Key: "k3", Offset: 2, Timestamp: now.Add(2 * time.Second)},
+			},
+		})
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "⚠ k2")
+		assert.NotContains(t, render, "⚠ k1")
+		assert.NotContains(t, render, "⚠ k3")
+		assert.Regexp(t, `Deserialization Errors:?\s+1`, render)
+	})
+
 	t.Run("Default sort by Timestamp Desc", func(t *testing.T) {
 		m, _ := New(
 			kadmin.NewMockKadmin(),
@@ -352,6 +391,89 @@ func TestConsumptionPage(t *testing.T) {
 			assert.Len(t, msgs, 1)
 			assert.IsType(t, tabs.ToConsumeFormPageCalledMsg{}, msgs[0])
 		})
+
+		t.Run("goes back to key lookup page when origin was key lookup page", func(t *testing.T) {
+			topic := &kadmin.ListedTopic{Name: "topic1", PartitionCount: 3}
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.NewKeyLookupReadDetails(topic, "key-1", 2),
+				topic,
+				tabs.OriginKeyLookupPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			cmd := m.Update(tests.Key(tea.KeyEsc))
+
+			msgs := tests.ExecuteBatchCmd(cmd)
+
+			assert.Len(t, msgs, 1)
+			assert.Equal(t, nav.LoadKeyLookupPageMsg{Topic: topic, Key: "key-1"}, msgs[0])
+		})
+	})
+
+	t.Run("Key lookup", func(t *testing.T) {
+		topic := &kadmin.ListedTopic{Name: "topic1", PartitionCount: 3}
+
+		t.Run("lists records chronologically", func(t *testing.T) {
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.NewKeyLookupReadDetails(topic, "key-1", 2),
+				topic,
+				tabs.OriginKeyLookupPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			var records []kadmin.ConsumerRecord
+			now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+			for _, offset := range []int64{100, 9, 20} {
+				records = append(records, kadmin.ConsumerRecord{
+					Key:       "key-1",
+					Partition: 2,
+					Offset:    offset,
+					Timestamp: now.Add(time.Duration(offset) * time.Second),
+				})
+			}
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: records,
+			})
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "▲ Offset")
+			o9Idx := strings.Index(render, "2024-01-01 00:00:09")
+			o20Idx := strings.Index(render, "2024-01-01 00:00:20")
+			o100Idx := strings.Index(render, "2024-01-01 00:01:40")
+			assert.Less(t, o9Idx, o20Idx)
+			assert.Less(t, o20Idx, o100Idx)
+		})
+
+		t.Run("displays no records found when key does not exist", func(t *testing.T) {
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.NewKeyLookupReadDetails(topic, "key-1", 2),
+				topic,
+				tabs.OriginKeyLookupPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			m.Update(kadmin.ConsumptionEndedMsg{})
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "No records found for the given criteria")
+		})
+
+		t.Run("title contains key", func(t *testing.T) {
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.NewKeyLookupReadDetails(topic, "key-1", 2),
+				topic,
+				tabs.OriginKeyLookupPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			assert.Equal(t, "Topics / topic1 / Key key-1", m.Title())
+		})
 	})
 
 	t.Run("F3 shows sort bar", func(t *testing.T) {
@@ -606,6 +728,8 @@ func TestConsumptionPage(t *testing.T) {
 		assert.Equal(t, []statusbar.Shortcut{
 			{"View Record", "enter"},
 			{"Sort", "F3"},
+			{"Older Records", "C-p"},
+			{"Newer Records", "C-n"},
 			{"Go Back", "esc"},
 		}, m.Shortcuts())
 
@@ -765,4 +889,393 @@ func TestConsumptionPage(t *testing.T) {
 
 	})
 
+	t.Run("Paging", func(t *testing.T) {
+		now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		readDetails := kadmin.ReadDetails{
+			TopicName:       "topic1",
+			PartitionToRead: []int{0, 1},
+			StartPoint:      kadmin.MostRecent,
+			Limit:           4,
+			Filter:          &kadmin.Filter{},
+		}
+		record := func(partition, offset int64) kadmin.ConsumerRecord {
+			return kadmin.ConsumerRecord{
+				Key:       fmt.Sprintf("key-%d-%d", partition, offset),
+				Partition: partition,
+				Offset:    offset,
+				Timestamp: now.Add(time.Duration(offset) * time.Second),
+			}
+		}
+		loadedPage := func(reader kadmin.RecordReader) *Model {
+			m, _ := New(
+				reader,
+				readDetails,
+				&kadmin.ListedTopic{Name: "topic1", PartitionCount: 2},
+				tabs.OriginConsumeFormPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: []kadmin.ConsumerRecord{
+					record(0, 10), record(0, 11),
+					record(1, 20), record(1, 21),
+				},
+			})
+			m.Update(kadmin.ConsumptionEndedMsg{})
+			m.View(tests.NewKontext(), tests.Renderer)
+			return m.(*Model)
+		}
+
+		t.Run("C-p reads the records before the lowest loaded offset per partition", func(t *testing.T) {
+			reader := &recordingReader{}
+			m := loadedPage(reader)
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlP))
+			cmd()
+
+			assert.Equal(t, map[int]kadmin.OffsetRange{
+				0: {Start: 8, End: 9},
+				1: {Start: 18, End: 19},
+			}, reader.readDetails[0].OffsetRanges)
+			assert.Equal(t, readDetails.Filter, reader.readDetails[0].Filter)
+		})
+
+		t.Run("C-n reads the records after the highest loaded offset per partition", func(t *testing.T) {
+			reader := &recordingReader{}
+			m := loadedPage(reader)
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlN))
+			cmd()
+
+			assert.Equal(t, map[int]kadmin.OffsetRange{
+				0: {Start: 12, End: 13},
+				1: {Start: 22, End: 23},
+			}, reader.readDetails[0].OffsetRanges)
+		})
+
+		t.Run("pages partitions without loaded records from the offsets that were read", func(t *testing.T) {
+			reader := &recordingReader{}
+			m, _ := New(
+				reader,
+				readDetails,
+				&kadmin.ListedTopic{Name: "topic1", PartitionCount: 2},
+				tabs.OriginConsumeFormPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: []kadmin.ConsumerRecord{record(0, 10), record(0, 11)},
+			})
+			// none of the records read from partition 1 matched the filter
+			m.Update(kadmin.ConsumptionEndedMsg{ReadRanges: map[int]kadmin.OffsetRange{
+				0: {Start: 10, End: 11},
+				1: {Start: 30, End: 31},
+			}})
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlP))
+			cmd()
+			m.Update(kadmin.ConsumptionEndedMsg{ReadRanges: map[int]kadmin.OffsetRange{
+				0: {Start: 8, End: 9},
+				1: {Start: 28, End: 29},
+			}})
+			cmd = m.Update(tests.Key(tea.KeyCtrlN))
+			cmd()
+
+			assert.Equal(t, map[int]kadmin.OffsetRange{
+				0: {Start: 8, End: 9},
+				1: {Start: 28, End: 29},
+			}, reader.readDetails[0].OffsetRanges)
+			assert.Equal(t, map[int]kadmin.OffsetRange{
+				0: {Start: 12, End: 13},
+				1: {Start: 32, End: 33},
+			}, reader.readDetails[1].OffsetRanges)
+		})
+
+		t.Run("an error stops paging", func(t *testing.T) {
+			reader := &recordingReader{}
+			m := loadedPage(reader)
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlP))
+			cmd()
+			m.Update(&kadmin.ReadingStartedMsg{})
+			cmd = m.Update(errors.New("broker unavailable"))
+			for _, msg := range tests.ExecuteBatchCmd(cmd) {
+				m.Update(msg)
+			}
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+			assert.Contains(t, render, "Failed to read records")
+			assert.Equal(t, noPaging, m.paging)
+
+			cmd = m.Update(tests.Key(tea.KeyCtrlP))
+			cmd()
+			assert.Len(t, reader.readDetails, 2)
+		})
+
+		t.Run("loaded records are added while the cursor stays on the same record", func(t *testing.T) {
+			m := loadedPage(&recordingReader{})
+			// sorted by timestamp desc, move to key-0-11
+			m.Update(tests.Key(tea.KeyDown))
+			m.Update(tests.Key(tea.KeyDown))
+			assert.Equal(t, "key-0-11", m.records[m.index[m.cursor]].Key)
+
+			m.Update(tests.Key(tea.KeyCtrlN))
+			m.Update(&kadmin.ReadingStartedMsg{})
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: []kadmin.ConsumerRecord{record(0, 12), record(1, 22)},
+			})
+			m.Update(kadmin.ConsumptionEndedMsg{})
+
+			assert.Len(t, m.records, 6)
+			assert.Equal(t, "key-0-11", m.records[m.index[m.cursor]].Key)
+		})
+
+		t.Run("notifies when there are no older records", func(t *testing.T) {
+			m := loadedPage(&recordingReader{})
+
+			m.Update(tests.Key(tea.KeyCtrlP))
+			m.Update(&kadmin.ReadingStartedMsg{})
+			cmd := m.Update(kadmin.NoRecordsFound{})
+			for _, msg := range tests.ExecuteBatchCmd(cmd) {
+				if _, ok := msg.(noMoreRecordsMsg); ok {
+					m.Update(msg)
+				}
+			}
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "No older records")
+			assert.Contains(t, render, "key-0-10")
+			assert.NotContains(t, render, "No records found for the given criteria")
+		})
+
+		t.Run("is not available when live consuming", func(t *testing.T) {
+			reader := &recordingReader{}
+			m, _ := New(
+				reader,
+				kadmin.ReadDetails{TopicName: "topic1", StartPoint: kadmin.Live, Limit: 500},
+				&kadmin.ListedTopic{},
+				tabs.OriginTopicsPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(0, 1)}})
+			m.Update(kadmin.ConsumptionEndedMsg{})
+
+			m.Update(tests.Key(tea.KeyCtrlP))
+
+			assert.Empty(t, reader.readDetails)
+		})
+	})
+
+	t.Run("Live", func(t *testing.T) {
+		now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		record := func(offset int64) kadmin.ConsumerRecord {
+			return kadmin.ConsumerRecord{
+				Key:       fmt.Sprintf("key-%d", offset),
+				Partition: 0,
+				Offset:    offset,
+				Timestamp: now.Add(time.Duration(offset) * time.Second),
+			}
+		}
+		livePage := func() *Model {
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.ReadDetails{TopicName: "topic1", StartPoint: kadmin.Live, Limit: 3},
+				&kadmin.ListedTopic{Name: "topic1"},
+				tabs.OriginTopicsPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+			m.Update(&kadmin.ReadingStartedMsg{})
+			m.View(tests.NewKontext(), tests.Renderer)
+			return m.(*Model)
+		}
+
+		t.Run("retains only the newest records", func(t *testing.T) {
+			m := livePage()
+
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(1), record(2)}})
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(3), record(4), record(5)}})
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Len(t, m.records, 3)
+			assert.Equal(t, []int{2, 1, 0}, m.index)
+			assert.Contains(t, render, "key-3")
+			assert.Contains(t, render, "key-5")
+			assert.NotContains(t, render, "key-2")
+		})
+
+		t.Run("evicts records without copying the retained ones", func(t *testing.T) {
+			m := livePage()
+			records := []kadmin.ConsumerRecord{record(1), record(2), record(3), record(4), record(5)}
+
+			allocs := testing.AllocsPerRun(10, func() {
+				m.retainNewest(records)
+			})
+
+			assert.Zero(t, allocs)
+			assert.Equal(t, []kadmin.ConsumerRecord{record(3), record(4), record(5)}, m.retainNewest(records))
+		})
+
+		t.Run("pause buffers records without updating the table", func(t *testing.T) {
+			m := livePage()
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(1)}})
+
+			m.Update(tests.Key('p'))
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(2), record(3)}})
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+			assert.NotContains(t, render, "key-2")
+			assert.Regexp(t, `Paused:?\s+2 new`, render)
+			assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{Name: "Resume", Keybinding: "p"})
+
+			m.Update(tests.Key('p'))
+
+			render = m.View(tests.NewKontext(), tests.Renderer)
+			assert.Contains(t, render, "key-2")
+			assert.Contains(t, render, "key-3")
+			assert.NotContains(t, render, "Paused")
+		})
+
+		t.Run("records buffered while paused are bounded", func(t *testing.T) {
+			m := livePage()
+
+			m.Update(tests.Key('p'))
+			m.Update(kadmin.ConsumerRecordReceived{
+				Records: []kadmin.ConsumerRecord{record(1), record(2), record(3), record(4)},
+			})
+			m.Update(tests.Key('p'))
+
+			assert.Equal(t, []kadmin.ConsumerRecord{record(2), record(3), record(4)}, m.records)
+		})
+
+		t.Run("follow keeps the cursor on the newest record", func(t *testing.T) {
+			m := livePage()
+			m.Update(tests.Key('F'))
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(1), record(2)}})
+			// a producer supplied timestamp sorts the newest record last
+			late := record(3)
+			late.Timestamp = now
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{late}})
+
+			assert.Equal(t, "key-3", m.records[m.index[m.cursor]].Key)
+		})
+
+		t.Run("shows the incoming rate", func(t *testing.T) {
+			m := livePage()
+			clock := now
+			m.rate = newRateMeter(func() time.Time { return clock })
+
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(1), record(2)}})
+			clock = clock.Add(time.Second)
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{record(3)}})
+
+			assert.Regexp(t, `Rate:?\s+0.6 msg/s`, m.View(tests.NewKontext(), tests.Renderer))
+
+			clock = clock.Add(10 * time.Second)
+
+			assert.Regexp(t, `Rate:?\s+0.0 msg/s`, m.View(tests.NewKontext(), tests.Renderer))
+		})
+
+		t.Run("shortcuts", func(t *testing.T) {
+			m := livePage()
+
+			assert.Equal(t, []statusbar.Shortcut{
+				{"View Record", "enter"},
+				{"Pause", "p"},
+				{"Follow", "F"},
+				{"Stop consuming", "F2"},
+				{"Go Back", "esc"},
+			}, m.Shortcuts())
+		})
+	})
+
+	t.Run("Large reads", func(t *testing.T) {
+		now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		loaded := func(count int) *Model {
+			m, _ := New(
+				kadmin.NewMockKadmin(),
+				kadmin.ReadDetails{TopicName: "topic1", Limit: kadmin.NoLimit},
+				&kadmin.ListedTopic{Name: "topic1"},
+				tabs.OriginConsumeFormPage,
+				tabs.NewMockTopicsTabNavigator(),
+			)
+			var records []kadmin.ConsumerRecord
+			for i := 0; i < count; i++ {
+				records = append(records, kadmin.ConsumerRecord{
+					Key:       fmt.Sprintf("key-%d", i),
+					Offset:    int64(i),
+					Timestamp: now.Add(time.Duration(i) * time.Millisecond),
+				})
+			}
+			m.Update(kadmin.ConsumerRecordReceived{Records: records})
+			m.Update(kadmin.ConsumptionEndedMsg{})
+			m.View(tests.NewKontext(), tests.Renderer)
+			return m.(*Model)
+		}
+
+		t.Run("only materializes the visible rows", func(t *testing.T) {
+			m := loaded(10_000)
+
+			render := m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "Records:  10000")
+			assert.Contains(t, render, "key-9999")
+			assert.NotContains(t, render, "key-5000")
+			assert.LessOrEqual(t, len(m.table.Rows()), tests.NewKontext().AvailableTableHeight())
+		})
+
+		t.Run("navigates through the whole index", func(t *testing.T) {
+			m := loaded(10_000)
+
+			m.Update(tests.Key(tea.KeyEnd))
+			render := m.View(tests.NewKontext(), tests.Renderer)
+			assert.Contains(t, render, "key-0 ")
+			assert.NotContains(t, render, "key-9999")
+
+			m.Update(tests.Key(tea.KeyHome))
+			m.Update(tests.Key(tea.KeyPgDown))
+			m.View(tests.NewKontext(), tests.Renderer)
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+
+			msg := cmd().(tabs.ToRecordDetailsPageCalledMsg).Msg
+			expected := 9999 - m.windowSize
+			assert.Equal(t, fmt.Sprintf("key-%d", expected), msg.Record.Key)
+			assert.Equal(t, expected, msg.Index)
+		})
+
+		t.Run("keeps the cursor on the selected record when records are added", func(t *testing.T) {
+			m := loaded(100)
+			m.Update(tests.Key(tea.KeyDown))
+			m.Update(tests.Key(tea.KeyDown))
+
+			m.Update(kadmin.ConsumerRecordReceived{Records: []kadmin.ConsumerRecord{
+				{Key: "newest", Offset: 100, Timestamp: now.Add(time.Hour)},
+			}})
+
+			assert.Equal(t, "key-97", m.records[m.index[m.cursor]].Key)
+		})
+
+		t.Run("merges records received in batches into the sorted index", func(t *testing.T) {
+			m := loaded(0)
+
+			// timestamps of consecutive batches interleave
+			for batch := 0; batch < 10; batch++ {
+				var records []kadmin.ConsumerRecord
+				for i := 0; i < 100; i++ {
+					offset := batch*100 + i
+					records = append(records, kadmin.ConsumerRecord{
+						Key:       fmt.Sprintf("key-%d", offset),
+						Offset:    int64(offset),
+						Timestamp: now.Add(time.Duration((i*7919+batch*31)%1000) * time.Millisecond),
+					})
+				}
+				m.Update(kadmin.ConsumerRecordReceived{Records: records})
+			}
+
+			merged := slices.Clone(m.index)
+			m.rebuildIndex(m.indexedFor)
+
+			assert.Len(t, merged, 1000)
+			assert.Equal(t, m.index, merged)
+		})
+	})
 }
@@ -0,0 +1,44 @@
+package consume_page
+
+import "time"
+
+// rateWindow is the period over which the incoming rate is averaged
+const rateWindow = 5 * time.Second
+
+type rateSample struct {
+	at    time.Time
+	count int
+}
+
+// rateMeter measures the number of records received per second over the last rateWindow.
+type rateMeter struct {
+	now     func() time.Time
+	samples []rateSample
+}
+
+func (r *rateMeter) record(count int) {
+	r.samples = append(r.samples, rateSample{r.now(), count})
+	r.evict()
+}
+
+func (r *rateMeter) perSecond() float64 {
+	r.evict()
+	total := 0
+	for _, s := range r.samples {
+		total += s.count
+	}
+	return float64(total) / rateWindow.Seconds()
+}
+
+func (r *rateMeter) evict() {
+	cutoff := r.now().Add(-rateWindow)
+	i := 0
+	for i < len(r.samples) && r.samples[i].at.Before(cutoff) {
+		i++
+	}
+	r.samples = r.samples[i:]
+}
+
+func newRateMeter(now func() time.Time) *rateMeter {
+	return &rateMeter{now: now}
+}
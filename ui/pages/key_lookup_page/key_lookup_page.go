@@ -0,0 +1,162 @@
+package key_lookup_page
+
+import (
+	"errors"
+	"fmt"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"ktea/ui/tabs"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+type Model struct {
+	form       *huh.Form
+	formValues *formValues
+	topic      *kadmin.ListedTopic
+	navigator  tabs.TopicsTabNavigator
+}
+
+type formValues struct {
+	key       string
+	hasher    kadmin.KeyHasher
+	partition string
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	if m.form == nil {
+		m.form = m.newForm(ktx)
+	}
+	return renderer.RenderWithStyle(m.form.View(), styles.Form)
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.form == nil {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
+		}
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	if m.form.State == huh.StateCompleted {
+		return m.submit()
+	}
+	return cmd
+}
+
+func (m *Model) submit() tea.Cmd {
+	partition, err := m.partitionToRead()
+	if err != nil {
+		// validation should have prevented this, reset the form to allow correction
+		m.form = nil
+		return nil
+	}
+
+	return m.navigator.ToConsumePage(tabs.ConsumePageDetails{
+		Origin:      tabs.OriginKeyLookupPage,
+		Topic:       m.topic,
+		ReadDetails: kadmin.NewKeyLookupReadDetails(m.topic, m.formValues.key, partition),
+	})
+}
+
+// partitionToRead returns the explicitly overridden partition
+// or the partition the key hashes to using the selected hasher.
+func (m *Model) partitionToRead() (int, error) {
+	if m.formValues.partition != "" {
+		return strconv.Atoi(m.formValues.partition)
+	}
+	return kadmin.PartitionForKey(m.formValues.key, m.topic.PartitionCount, m.formValues.hasher)
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	return []statusbar.Shortcut{
+		{"Confirm", "enter"},
+		{"Next Field", "tab"},
+		{"Prev. Field", "s-tab"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Find by Key"
+}
+
+func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
+	key := huh.NewInput().
+		Title("Key").
+		Description("Only records with exactly this key are listed.").
+		Value(&m.formValues.key).
+		Validate(func(str string) error {
+			if str == "" {
+				return errors.New("key cannot be empty")
+			}
+			return nil
+		})
+	hasher := huh.NewSelect[kadmin.KeyHasher]().
+		Title("Partitioner").
+		Description("The partitioner the producer used to assign the key to a partition.").
+		Value(&m.formValues.hasher).
+		Options(
+			huh.NewOption("murmur2 (Java clients, ktea)", kadmin.Murmur2KeyHasher),
+			huh.NewOption("FNV-1a (sarama)", kadmin.FNV1aKeyHasher),
+			huh.NewOption("CRC32 (librdkafka)", kadmin.CRC32KeyHasher))
+	partition := huh.NewInput().
+		Title("Partition").
+		Description("Leave empty to derive the partition from the key.").
+		Value(&m.formValues.partition).
+		Validate(func(str string) error {
+			if str == "" {
+				return nil
+			}
+			if n, e := strconv.Atoi(str); e != nil {
+				return fmt.Errorf("'%s' is not a valid numeric partition value", str)
+			} else if n < 0 {
+				return errors.New("value must be at least zero")
+			} else if n > m.topic.PartitionCount-1 {
+				return fmt.Errorf("partition index %s is invalid, valid range is 0-%d", str, m.topic.PartitionCount-1)
+			}
+			return nil
+		})
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			key,
+			hasher,
+			partition,
+		).WithWidth(ktx.WindowWidth / 2),
+	)
+	form.QuitAfterSubmit = false
+	form.Init()
+	return form
+}
+
+func New(
+	topic *kadmin.ListedTopic,
+	key string,
+	navigator tabs.TopicsTabNavigator,
+) *Model {
+	return &Model{
+		topic:     topic,
+		navigator: navigator,
+		formValues: &formValues{
+			key:    key,
+			hasher: kadmin.Murmur2KeyHasher,
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package key_lookup_page
+
+import (
+	"ktea/kadmin"
+	"ktea/tests"
+	"ktea/ui/pages/nav"
+	"ktea/ui/tabs"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLookupPage(t *testing.T) {
+	topic := &kadmin.ListedTopic{
+		Name:           "topic1",
+		PartitionCount: 12,
+		Replicas:       1,
+	}
+
+	submit := func(m *Model) []tea.Msg {
+		// key
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		// partitioner
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		// partition
+		return tests.Submit(m)
+	}
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m := New(topic, "", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		cmd := m.Update(tests.Key(tea.KeyEsc))
+
+		assert.IsType(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+
+	t.Run("pre-fills the key", func(t *testing.T) {
+		m := New(topic, "order-123", tabs.NewMockTopicsTabNavigator())
+
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "order-123")
+	})
+
+	t.Run("key is required", func(t *testing.T) {
+		m := New(topic, "", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		m.Update(tests.Key(tea.KeyEnter))
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "key cannot be empty")
+	})
+
+	t.Run("reads only the partition the key hashes to", func(t *testing.T) {
+		m := New(topic, "", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		tests.UpdateKeys(m, "order-123")
+		msgs := submit(m)
+
+		expectedPartition, _ := kadmin.PartitionForKey("order-123", 12, kadmin.Murmur2KeyHasher)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, tabs.ConsumePageDetails{
+			Origin:      tabs.OriginKeyLookupPage,
+			Topic:       topic,
+			ReadDetails: kadmin.NewKeyLookupReadDetails(topic, "order-123", expectedPartition),
+		}, msgs[0].(tabs.ToConsumePageCalledMsg).Details)
+	})
+
+	t.Run("uses the selected partitioner", func(t *testing.T) {
+		m := New(topic, "", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		tests.UpdateKeys(m, "order-123")
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		// select CRC32
+		m.Update(tests.Key(tea.KeyDown))
+		m.Update(tests.Key(tea.KeyDown))
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		msgs := tests.Submit(m)
+
+		expectedPartition, _ := kadmin.PartitionForKey("order-123", 12, kadmin.CRC32KeyHasher)
+		assert.Equal(t,
+			[]int{expectedPartition},
+			msgs[0].(tabs.ToConsumePageCalledMsg).Details.ReadDetails.PartitionToRead,
+		)
+	})
+
+	t.Run("explicit partition overrides the hashing", func(t *testing.T) {
+		m := New(topic, "order-123", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		tests.UpdateKeys(m, "7")
+		msgs := tests.Submit(m)
+
+		details := msgs[0].(tabs.ToConsumePageCalledMsg).Details
+		assert.Equal(t, []int{7}, details.ReadDetails.PartitionToRead)
+		assert.Equal(t, "order-123", details.ReadDetails.Filter.KeySearchTerm)
+		assert.Equal(t, kadmin.ExactFilterType, details.ReadDetails.Filter.KeyFilter)
+		assert.Equal(t, kadmin.Beginning, details.ReadDetails.StartPoint)
+		assert.Equal(t, kadmin.NoLimit, details.ReadDetails.Limit)
+	})
+
+	t.Run("partition must be within range", func(t *testing.T) {
+		m := New(topic, "order-123", tabs.NewMockTopicsTabNavigator())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+		tests.UpdateKeys(m, "12")
+		m.Update(tests.Key(tea.KeyEnter))
+		render := m.View(tests.NewKontext(), tests.Renderer)
+
+		assert.Contains(t, render, "partition index 12 is invalid, valid range is 0-11")
+	})
+}
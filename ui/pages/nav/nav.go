@@ -17,6 +17,8 @@ type LoadTopicConfigPageMsg struct {
 
 type LoadPublishPageMsg struct {
 	Topic *kadmin.ListedTopic
+	// Record pre-fills the form to republish it, nil to publish a new record
+	Record *kadmin.ConsumerRecord
 }
 
 type LoadLiveConsumePageMsg struct {
@@ -26,6 +28,9 @@ type LoadLiveConsumePageMsg struct {
 type LoadCachedConsumptionPageMsg struct {
 }
 
+type LoadCachedRecordDetailsPageMsg struct {
+}
+
 type LoadCGroupsPageMsg struct {
 }
 
@@ -37,8 +42,22 @@ type LoadCreateSubjectPageMsg struct{}
 
 type LoadSubjectsPageMsg struct {
 	Refresh bool
+	// Subject is selected once the subjects are listed, none when empty
+	Subject string
 }
 
 type LoadSchemaDetailsPageMsg struct {
 	Subject sradmin.Subject
+	// Version to open, the latest version when zero
+	Version int
+}
+
+type LoadKeyLookupPageMsg struct {
+	Topic *kadmin.ListedTopic
+	// Key pre-fills the key to look up, can be empty
+	Key string
+}
+
+type LoadCompactedTopicPageMsg struct {
+	Topic *kadmin.ListedTopic
 }
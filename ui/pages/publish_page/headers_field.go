@@ -0,0 +1,393 @@
+package publish_page
+
+import (
+	"errors"
+	"fmt"
+	"ktea/config"
+	"ktea/kadmin"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// header is a header as entered, its value is encoded as its type when published
+type header struct {
+	Key   string
+	Type  config.HeaderType
+	Value string
+}
+
+// encode encodes the value, with its placeholders expanded, as the type of the header
+func (h header) encode(placeholders *placeholders) (kadmin.Header, error) {
+	if strings.TrimSpace(h.Key) == "" {
+		return kadmin.Header{}, errors.New("header key cannot be empty")
+	}
+	expanded, err := placeholders.expand(h.Value)
+	if err != nil {
+		return kadmin.Header{}, fmt.Errorf("header %s: %w", h.Key, err)
+	}
+	value, err := kadmin.EncodeHeaderValue(expanded, h.Type)
+	if err != nil {
+		return kadmin.Header{}, fmt.Errorf("header %s: %w", h.Key, err)
+	}
+	return kadmin.Header{Key: h.Key, Value: value}, nil
+}
+
+// validateHeaders validates every header can be encoded as its type,
+// the prefilled ones are validated without expanding their placeholders.
+func validateHeaders(headers []header, prefilled []header) error {
+	for _, h := range headers {
+		p := newPlaceholders()
+		if slices.Contains(prefilled, h) {
+			p = nil
+		}
+		if _, err := h.encode(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headerTypes are the types a header value can be entered as
+var headerTypes = slices.DeleteFunc(slices.Clone(config.HeaderTypes), func(t config.HeaderType) bool {
+	return t == config.HeaderTypeAuto
+})
+
+// headerColumn is a column of a row of the headers field
+type headerColumn int
+
+const (
+	keyColumn headerColumn = iota
+	typeColumn
+	valueColumn
+)
+
+type headersKeyMap struct {
+	Add        key.Binding
+	Remove     key.Binding
+	ChangeType key.Binding
+	Next       key.Binding
+	Prev       key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Done       key.Binding
+}
+
+var defaultHeadersKeyMap = headersKeyMap{
+	Add:        key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("C-n", "add header")),
+	Remove:     key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("C-d", "remove header")),
+	ChangeType: key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "change type")),
+	Next:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next")),
+	Prev:       key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous")),
+	Up:         key.NewBinding(key.WithKeys("up")),
+	Down:       key.NewBinding(key.WithKeys("down")),
+	Done:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "done")),
+}
+
+// headersField is a form field that edits the headers as rows of a key, a type and a value.
+// Rows are added and removed with C-n and C-d, tab moves between the columns.
+type headersField struct {
+	headers *[]header
+	// prefilled are the headers of the republished record, see validateHeaders
+	prefilled []header
+	keys      []textinput.Model
+	values    []textinput.Model
+	row       int
+	column    headerColumn
+	focused   bool
+	err       error
+	width     int
+	theme     *huh.Theme
+	keymap    headersKeyMap
+}
+
+func newHeadersField(headers *[]header, prefilled []header) *headersField {
+	f := &headersField{headers: headers, prefilled: prefilled, keymap: defaultHeadersKeyMap}
+	for _, h := range *headers {
+		f.keys = append(f.keys, newHeaderInput("key", h.Key))
+		f.values = append(f.values, newHeaderInput("value", h.Value))
+	}
+	return f
+}
+
+func newHeaderInput(placeholder string, value string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = placeholder
+	input.SetValue(value)
+	return input
+}
+
+func (f *headersField) Init() tea.Cmd {
+	return nil
+}
+
+func (f *headersField) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !f.focused {
+		return f, nil
+	}
+	f.err = nil
+
+	switch {
+	case key.Matches(keyMsg, f.keymap.Add):
+		return f, f.add()
+	case key.Matches(keyMsg, f.keymap.Remove):
+		return f, f.remove()
+	case key.Matches(keyMsg, f.keymap.Done):
+		return f, f.done()
+	case key.Matches(keyMsg, f.keymap.Next):
+		if len(*f.headers) == 0 || (f.row == len(*f.headers)-1 && f.column == valueColumn) {
+			return f, f.done()
+		}
+		if f.column == valueColumn {
+			return f, f.focusCell(f.row+1, keyColumn)
+		}
+		return f, f.focusCell(f.row, f.column+1)
+	case key.Matches(keyMsg, f.keymap.Prev):
+		if len(*f.headers) == 0 || (f.row == 0 && f.column == keyColumn) {
+			return f, huh.PrevField
+		}
+		if f.column == keyColumn {
+			return f, f.focusCell(f.row-1, valueColumn)
+		}
+		return f, f.focusCell(f.row, f.column-1)
+	case key.Matches(keyMsg, f.keymap.Up):
+		if f.row > 0 {
+			return f, f.focusCell(f.row-1, f.column)
+		}
+		return f, nil
+	case key.Matches(keyMsg, f.keymap.Down):
+		if f.row < len(*f.headers)-1 {
+			return f, f.focusCell(f.row+1, f.column)
+		}
+		return f, nil
+	}
+
+	if len(*f.headers) == 0 {
+		return f, nil
+	}
+
+	var cmd tea.Cmd
+	switch f.column {
+	case keyColumn:
+		f.keys[f.row], cmd = f.keys[f.row].Update(msg)
+		(*f.headers)[f.row].Key = f.keys[f.row].Value()
+	case typeColumn:
+		if key.Matches(keyMsg, f.keymap.ChangeType) {
+			f.cycleType(keyMsg.String() == "right")
+		}
+	case valueColumn:
+		f.values[f.row], cmd = f.values[f.row].Update(msg)
+		(*f.headers)[f.row].Value = f.values[f.row].Value()
+	}
+	return f, cmd
+}
+
+// add adds a string header below the focused one
+func (f *headersField) add() tea.Cmd {
+	row := 0
+	if len(*f.headers) > 0 {
+		row = f.row + 1
+	}
+	*f.headers = slices.Insert(*f.headers, row, header{Type: config.HeaderTypeString})
+	f.keys = slices.Insert(f.keys, row, newHeaderInput("key", ""))
+	f.values = slices.Insert(f.values, row, newHeaderInput("value", ""))
+	f.setWidths()
+	return f.focusCell(row, keyColumn)
+}
+
+// remove removes the focused header
+func (f *headersField) remove() tea.Cmd {
+	if len(*f.headers) == 0 {
+		return nil
+	}
+	*f.headers = slices.Delete(*f.headers, f.row, f.row+1)
+	f.keys = slices.Delete(f.keys, f.row, f.row+1)
+	f.values = slices.Delete(f.values, f.row, f.row+1)
+	return f.focusCell(min(f.row, len(*f.headers)-1), f.column)
+}
+
+// done moves on to the next field when all headers are valid
+func (f *headersField) done() tea.Cmd {
+	if f.err = validateHeaders(*f.headers, f.prefilled); f.err != nil {
+		return nil
+	}
+	return huh.NextField
+}
+
+func (f *headersField) cycleType(forward bool) {
+	h := &(*f.headers)[f.row]
+	idx := slices.Index(headerTypes, h.Type)
+	if forward {
+		idx = (idx + 1) % len(headerTypes)
+	} else if idx <= 0 {
+		idx = len(headerTypes) - 1
+	} else {
+		idx--
+	}
+	h.Type = headerTypes[idx]
+}
+
+func (f *headersField) focusCell(row int, column headerColumn) tea.Cmd {
+	for i := range f.keys {
+		f.keys[i].Blur()
+		f.values[i].Blur()
+	}
+	f.row, f.column = max(row, 0), column
+	if !f.focused || len(*f.headers) == 0 {
+		return nil
+	}
+	switch column {
+	case keyColumn:
+		return f.keys[f.row].Focus()
+	case valueColumn:
+		return f.values[f.row].Focus()
+	}
+	return nil
+}
+
+func (f *headersField) View() string {
+	styles := f.activeStyles()
+
+	var sb strings.Builder
+	sb.WriteString(styles.Title.Render("Headers"))
+	sb.WriteString("\n")
+	if len(*f.headers) == 0 {
+		sb.WriteString(styles.Description.Render("No headers, press C-n to add one."))
+		return styles.Base.Render(sb.String())
+	}
+	sb.WriteString(styles.Description.Render("C-n to add and C-d to remove a header, ←/→ to change its type."))
+
+	typeWidth := 0
+	for _, t := range headerTypes {
+		typeWidth = max(typeWidth, len(t))
+	}
+	for i, h := range *f.headers {
+		f.keys[i].TextStyle = styles.TextInput.Text
+		f.keys[i].PlaceholderStyle = styles.TextInput.Placeholder
+		f.keys[i].Cursor.Style = styles.TextInput.Cursor
+		f.values[i].TextStyle = styles.TextInput.Text
+		f.values[i].PlaceholderStyle = styles.TextInput.Placeholder
+		f.values[i].Cursor.Style = styles.TextInput.Cursor
+
+		indicator := "  "
+		if f.focused && i == f.row {
+			indicator = styles.SelectSelector.Render("> ")
+		}
+		headerType := styles.Option.Render(fmt.Sprintf(" %-*s ", typeWidth, h.Type))
+		if f.focused && i == f.row && f.column == typeColumn {
+			headerType = styles.PrevIndicator.Render("←") +
+				styles.SelectedOption.Render(fmt.Sprintf("%-*s", typeWidth, h.Type)) +
+				styles.NextIndicator.Render("→")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			indicator,
+			f.keys[i].View(),
+			" ",
+			headerType,
+			" ",
+			f.values[i].View(),
+		))
+	}
+	return styles.Base.Render(sb.String())
+}
+
+func (f *headersField) activeStyles() *huh.FieldStyles {
+	theme := f.theme
+	if theme == nil {
+		theme = huh.ThemeCharm()
+	}
+	if f.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+func (f *headersField) Blur() tea.Cmd {
+	f.focused = false
+	f.err = validateHeaders(*f.headers, f.prefilled)
+	return f.focusCell(f.row, f.column)
+}
+
+func (f *headersField) Focus() tea.Cmd {
+	f.focused = true
+	f.err = nil
+	return f.focusCell(f.row, f.column)
+}
+
+func (f *headersField) Error() error {
+	return f.err
+}
+
+func (f *headersField) Run() error {
+	return huh.Run(f)
+}
+
+func (f *headersField) Skip() bool {
+	return false
+}
+
+func (f *headersField) Zoom() bool {
+	return false
+}
+
+func (f *headersField) KeyBinds() []key.Binding {
+	return []key.Binding{f.keymap.Add, f.keymap.Remove, f.keymap.ChangeType, f.keymap.Next, f.keymap.Done}
+}
+
+func (f *headersField) WithTheme(theme *huh.Theme) huh.Field {
+	if f.theme == nil {
+		f.theme = theme
+	}
+	return f
+}
+
+func (f *headersField) WithAccessible(bool) huh.Field {
+	return f
+}
+
+func (f *headersField) WithKeyMap(*huh.KeyMap) huh.Field {
+	return f
+}
+
+func (f *headersField) WithWidth(width int) huh.Field {
+	f.width = width
+	f.setWidths()
+	return f
+}
+
+// setWidths divides the width of the field over the key and value of every row
+func (f *headersField) setWidths() {
+	// the indicator, the type with its arrows and the spaces in between
+	available := f.width - f.activeStyles().Base.GetHorizontalFrameSize() - 16
+	if available <= 0 {
+		return
+	}
+	for i := range f.keys {
+		f.keys[i].Width = available / 3
+		f.values[i].Width = available - available/3 - 1
+	}
+}
+
+func (f *headersField) WithHeight(int) huh.Field {
+	return f
+}
+
+func (f *headersField) WithPosition(huh.FieldPosition) huh.Field {
+	return f
+}
+
+func (f *headersField) GetKey() string {
+	return "headers"
+}
+
+func (f *headersField) GetValue() any {
+	return *f.headers
+}
@@ -0,0 +1,71 @@
+package publish_page
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// placeholders expands the placeholder functions in the key, header values and payload of a record:
+//
+//	{{uuid}}              a random UUID
+//	{{now}}               the current time in RFC 3339 with milliseconds
+//	{{seq}}               the sequence number of the record, incremented for every expanded record
+//	{{randInt 1 100}}     a random number between both bounds, inclusive
+//	{{pick "a" "b"}}      one of the given values at random
+type placeholders struct {
+	seq   int
+	funcs template.FuncMap
+}
+
+func newPlaceholders() *placeholders {
+	p := &placeholders{}
+	p.funcs = template.FuncMap{
+		"uuid": uuid.NewString,
+		"now": func() string {
+			return time.Now().Format("2006-01-02T15:04:05.000Z07:00")
+		},
+		"seq": func() int {
+			return p.seq
+		},
+		"randInt": func(min int, max int) (int, error) {
+			if max < min {
+				return 0, fmt.Errorf("randInt minimum %d is larger than maximum %d", min, max)
+			}
+			return min + rand.IntN(max-min+1), nil
+		},
+		"pick": func(values ...string) (string, error) {
+			if len(values) == 0 {
+				return "", errors.New("pick requires at least one value")
+			}
+			return values[rand.IntN(len(values))], nil
+		},
+	}
+	return p
+}
+
+// next moves on to the next record, all placeholders expanded until the next call share its sequence number
+func (p *placeholders) next() {
+	p.seq++
+}
+
+// expand expands the placeholders of the input, nil placeholders keep the input as is
+func (p *placeholders) expand(input string) (string, error) {
+	if p == nil || !strings.Contains(input, "{{") {
+		return input, nil
+	}
+	tmpl, err := template.New("").Funcs(p.funcs).Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid placeholder: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, nil); err != nil {
+		return "", fmt.Errorf("invalid placeholder: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,75 @@
+package publish_page
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholders(t *testing.T) {
+	t.Run("input without placeholders is unchanged", func(t *testing.T) {
+		expanded, err := newPlaceholders().expand(`{"id": 1}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id": 1}`, expanded)
+	})
+
+	t.Run("seq is shared until the next record", func(t *testing.T) {
+		p := newPlaceholders()
+
+		p.next()
+		first, _ := p.expand("{{seq}}-{{seq}}")
+		p.next()
+		second, _ := p.expand("{{seq}}")
+
+		assert.Equal(t, "1-1", first)
+		assert.Equal(t, "2", second)
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		expanded, err := newPlaceholders().expand("{{uuid}}")
+
+		assert.NoError(t, err)
+		assert.NoError(t, uuid.Validate(expanded))
+	})
+
+	t.Run("randInt is within its bounds", func(t *testing.T) {
+		p := newPlaceholders()
+
+		for i := 0; i < 100; i++ {
+			expanded, err := p.expand("{{randInt 1 3}}")
+			assert.NoError(t, err)
+			n, _ := strconv.Atoi(expanded)
+			assert.GreaterOrEqual(t, n, 1)
+			assert.LessOrEqual(t, n, 3)
+		}
+	})
+
+	t.Run("pick one of the values", func(t *testing.T) {
+		expanded, err := newPlaceholders().expand(`{{pick "a" "b"}}`)
+
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"a", "b"}, expanded)
+	})
+
+	t.Run("now", func(t *testing.T) {
+		expanded, err := newPlaceholders().expand("{{now}}")
+
+		assert.NoError(t, err)
+		assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}`, expanded)
+	})
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		_, err := newPlaceholders().expand("{{unknown}}")
+
+		assert.ErrorContains(t, err, `invalid placeholder: template: :1: function "unknown" not defined`)
+	})
+
+	t.Run("randInt with inverted bounds", func(t *testing.T) {
+		_, err := newPlaceholders().expand("{{randInt 5 1}}")
+
+		assert.ErrorContains(t, err, "randInt minimum 5 is larger than maximum 1")
+	})
+}
@@ -0,0 +1,150 @@
+package publish_page
+
+import (
+	"fmt"
+	"ktea/kadmin"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// producerSettings are the settings of the producer records are published with
+type producerSettings struct {
+	kadmin.ProducerSettings
+	// Timestamp is the timestamp of the published records in RFC 3339 or epoch milliseconds, now when empty
+	Timestamp string
+}
+
+// summary lists the settings that differ from the defaults, empty when none do
+func (s producerSettings) summary() string {
+	settings := s.Normalized()
+	defaults := kadmin.DefaultProducerSettings
+	var changed []string
+	if settings.Acks != defaults.Acks {
+		changed = append(changed, "acks "+string(settings.Acks))
+	}
+	if settings.Compression != defaults.Compression {
+		changed = append(changed, string(settings.Compression)+" compressed")
+	}
+	if settings.Partitioner != defaults.Partitioner {
+		changed = append(changed, string(settings.Partitioner)+" partitioner")
+	}
+	if settings.Transactional {
+		changed = append(changed, "transactional")
+	} else if settings.Idempotent {
+		changed = append(changed, "idempotent")
+	}
+	if s.Timestamp != "" {
+		changed = append(changed, "timestamp "+s.Timestamp)
+	}
+	return strings.Join(changed, ", ")
+}
+
+// parseTimestamp parses a timestamp in RFC 3339 or epoch milliseconds, the zero time when empty
+func parseTimestamp(timestamp string) (time.Time, error) {
+	timestamp = strings.TrimSpace(timestamp)
+	if timestamp == "" {
+		return time.Time{}, nil
+	}
+	if millis, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		return time.UnixMilli(millis), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not a valid timestamp, use RFC 3339 or epoch milliseconds", timestamp)
+}
+
+// openSettings opens the form to change the producer settings, applied once confirmed
+func (m *Model) openSettings() tea.Cmd {
+	m.syncFocusedField()
+
+	m.editedSettings = m.settings
+	m.editedSettings.ProducerSettings = m.settings.Normalized()
+	edited := &m.editedSettings
+
+	m.settingsForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[kadmin.Acks]().
+				Inline(true).
+				Title("Acks: ").
+				Options(
+					huh.NewOption(string(kadmin.AcksAll), kadmin.AcksAll),
+					huh.NewOption(string(kadmin.AcksLeader), kadmin.AcksLeader),
+					huh.NewOption(string(kadmin.AcksNone), kadmin.AcksNone),
+				).
+				Value(&edited.Acks),
+			huh.NewSelect[kadmin.Compression]().
+				Inline(true).
+				Title("Compression: ").
+				Options(
+					huh.NewOption(string(kadmin.NoCompression), kadmin.NoCompression),
+					huh.NewOption(string(kadmin.GzipCompression), kadmin.GzipCompression),
+					huh.NewOption(string(kadmin.SnappyCompression), kadmin.SnappyCompression),
+					huh.NewOption(string(kadmin.LZ4Compression), kadmin.LZ4Compression),
+					huh.NewOption(string(kadmin.ZstdCompression), kadmin.ZstdCompression),
+				).
+				Value(&edited.Compression),
+			huh.NewSelect[kadmin.KeyHasher]().
+				Inline(true).
+				Title("Partitioner: ").
+				Options(
+					huh.NewOption(string(kadmin.Murmur2KeyHasher), kadmin.Murmur2KeyHasher),
+					huh.NewOption(string(kadmin.FNV1aKeyHasher), kadmin.FNV1aKeyHasher),
+					huh.NewOption(string(kadmin.CRC32KeyHasher), kadmin.CRC32KeyHasher),
+				).
+				Value(&edited.Partitioner),
+			huh.NewConfirm().
+				Inline(true).
+				Title("Idempotent: ").
+				Value(&edited.Idempotent).
+				Validate(func(bool) error {
+					return edited.Validate()
+				}),
+			huh.NewConfirm().
+				Inline(true).
+				Title("Transactional: ").
+				Value(&edited.Transactional).
+				Validate(func(bool) error {
+					return edited.Validate()
+				}),
+			huh.NewInput().
+				Title("Timestamp").
+				Description("RFC 3339 or epoch milliseconds, leave empty to use the time of publication.").
+				Value(&edited.Timestamp).
+				Validate(func(str string) error {
+					expanded, err := newPlaceholders().expand(str)
+					if err != nil {
+						return err
+					}
+					_, err = parseTimestamp(expanded)
+					return err
+				}),
+		),
+	)
+	m.settingsForm.QuitAfterSubmit = false
+	return m.settingsForm.Init()
+}
+
+// updateSettings updates the settings form, applying the settings once completed and discarding them on esc
+func (m *Model) updateSettings(msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyEsc {
+		m.settingsForm = nil
+		return nil
+	}
+
+	form, cmd := m.settingsForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.settingsForm = f
+	}
+	if m.settingsForm.State == huh.StateCompleted {
+		m.settingsForm = nil
+		m.settings = m.editedSettings
+		m.settings.ProducerSettings = m.settings.Normalized()
+		return m.notifier.ShowInfoMsg("Producer settings applied")
+	}
+	return cmd
+}
@@ -1,6 +1,9 @@
 package publish_page
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -8,15 +11,19 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/serdes"
 	"ktea/styles"
 	"ktea/ui"
 	"ktea/ui/components/cmdbar"
 	"ktea/ui/components/notifier"
 	"ktea/ui/components/statusbar"
+	"ktea/ui/editor"
 	"ktea/ui/pages/nav"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,12 +37,70 @@ const (
 )
 
 type Model struct {
-	state      state
-	topicForm  *huh.Form
-	publisher  kadmin.Publisher
-	topic      *kadmin.ListedTopic
-	notifier   *notifier.Model
-	formValues *formValues
+	state        state
+	topicForm    *huh.Form
+	publisher    kadmin.Publisher
+	topic        *kadmin.ListedTopic
+	notifier     *notifier.Model
+	formValues   *formValues
+	placeholders *placeholders
+	// config is nil when templates can't be loaded or saved
+	config *config.Config
+	// templateIdx is the index of the last loaded template of the topic, -1 when none was loaded
+	templateIdx  int
+	templateName string
+	// templateNameInput prompts for the name to save a template under, nil when not saving
+	templateNameInput *huh.Input
+	editor            editor.Editor
+	keyInput          *huh.Input
+	partitionInput    *huh.Input
+	keyFormatSelect   *huh.Select[inputFormat]
+	headersField      *headersField
+	// rejectedEdits holds edited content that didn't validate by tag, it is opened again when editing once more
+	rejectedEdits map[string]string
+	// record is the consumed record being republished, nil when publishing a new one
+	record *kadmin.ConsumerRecord
+	// originPartition is whether the partition is still the one pre-filled from the republished record
+	originPartition bool
+	// prefilled holds the values pre-filled from the republished record, see placeholdersFor
+	prefilled formValues
+	// clusters holds the clusters a record can be republished to, nil to only publish to the active cluster
+	clusters     *config.Config
+	instantiator kadmin.Instantiator
+	// publishers holds the publishers of the other clusters published to so far by name
+	publishers map[string]kadmin.Kadmin
+	serializer *serdes.AvroSerializer
+	settings   producerSettings
+	// settingsForm changes editedSettings, nil when the producer settings are not being changed
+	settingsForm   *huh.Form
+	editedSettings producerSettings
+}
+
+const (
+	payloadEditTag    = "payload"
+	keyHeadersEditTag = "key-headers"
+)
+
+// keyHeadersDocument is the structured document the key and headers are edited as
+type keyHeadersDocument struct {
+	Key     string           `json:"key"`
+	Headers []headerDocument `json:"headers"`
+}
+
+type headerDocument struct {
+	Key   string            `json:"key"`
+	Type  config.HeaderType `json:"type,omitempty"`
+	Value string            `json:"value"`
+}
+
+type Option func(m *Model)
+
+// bulkPublishedMsg reports the publication of multiple copies of a record
+type bulkPublishedMsg struct {
+	published int
+	failed    int
+	// err is the first error that occurred
+	err error
 }
 
 type LoadPageMsg struct {
@@ -43,10 +108,72 @@ type LoadPageMsg struct {
 }
 
 type formValues struct {
-	Key       string
-	Partition string
-	Payload   string
-	Headers   string
+	Cluster       string
+	Topic         string
+	Key           string
+	KeyFormat     inputFormat
+	Partition     string
+	Payload       string
+	PayloadFormat inputFormat
+	Headers       []header
+	Copies        string
+}
+
+// inputFormat determines how the key or payload as entered is turned into bytes
+type inputFormat string
+
+const (
+	textFormat   inputFormat = "Text"
+	base64Format inputFormat = "Base64"
+	hexFormat    inputFormat = "Hex"
+	nullFormat   inputFormat = "Null"
+	// avroFormat serializes the payload with the schema of the republished record
+	avroFormat inputFormat = "Avro"
+)
+
+// bytes returns the input decoded in the given format, nil when null
+// and an empty non-nil slice when the input is empty.
+func (f inputFormat) bytes(input string) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	switch f {
+	case nullFormat:
+		return nil, nil
+	case base64Format:
+		b, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(input), ""))
+		if err != nil {
+			return nil, errors.New("not valid base64")
+		}
+	case hexFormat:
+		b, err = hex.DecodeString(strings.Join(strings.Fields(input), ""))
+		if err != nil {
+			return nil, errors.New("not valid hex")
+		}
+	default:
+		b = []byte(input)
+	}
+	if b == nil {
+		b = []byte{}
+	}
+	return b, nil
+}
+
+// validateInFormat validates the input with its placeholders expanded can be decoded in the given format
+func validateInFormat(input string, format inputFormat, placeholders *placeholders) error {
+	if format == nullFormat {
+		return nil
+	}
+	expanded, err := placeholders.expand(input)
+	if err != nil {
+		return err
+	}
+	if format == avroFormat {
+		return validateJson(expanded)
+	}
+	_, err = format.bytes(expanded)
+	return err
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -59,9 +186,38 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 		m.topicForm = m.newForm(ktx)
 	}
 
+	var warningView string
+	if m.formValues.PayloadFormat == nullFormat && m.targetsOrigin() && !m.isCompacted() {
+		warningView = styles.CmdBarWithWidth(ktx.WindowWidth - cmdbar.BorderedPadding).
+			Render(styles.FG(styles.ColorOrange).Render("⚠ " + m.tombstoneWarning()))
+	}
+
+	var settingsView string
+	if summary := m.settings.summary(); summary != "" {
+		settingsView = styles.CmdBarWithWidth(ktx.WindowWidth - cmdbar.BorderedPadding).
+			Render("Producer: " + summary)
+	}
+
+	formView := m.topicForm.View()
+	if m.settingsForm != nil {
+		formView = m.settingsForm.View()
+	}
+
+	var templateNameView string
+	if m.templateNameInput != nil {
+		templateNameView = renderer.RenderWithStyle(
+			m.templateNameInput.View(),
+			styles.CmdBarWithWidth(ktx.WindowWidth-cmdbar.BorderedPadding).
+				BorderForeground(lipgloss.Color(styles.ColorFocusBorder)),
+		)
+	}
+
 	return ui.JoinVertical(lipgloss.Top,
 		notifierView,
-		renderer.RenderWithStyle(m.topicForm.View(), styles.Form),
+		templateNameView,
+		warningView,
+		settingsView,
+		renderer.RenderWithStyle(formView, styles.Form),
 	)
 }
 
@@ -80,106 +236,610 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case kadmin.PublicationFailed:
 		m.state = none
 		m.topicForm.Init()
-		return m.notifier.ShowErrorMsg("Publication failed!", fmt.Errorf("TODO"))
+		return m.notifier.ShowErrorMsg("Publication failed!", msg.Err)
+	case publisherCreatedMsg:
+		return m.publishWithCreatedPublisher(msg)
+	case bulkPublishedMsg:
+		m.state = none
+		if msg.failed > 0 {
+			m.topicForm.Init()
+			return m.notifier.ShowErrorMsg(
+				fmt.Sprintf("%d of %d records published, %d failed", msg.published, msg.published+msg.failed, msg.failed),
+				msg.err,
+			)
+		}
+		m.resetForm()
+		return tea.Batch(
+			m.notifier.ShowSuccessMsg(fmt.Sprintf("%d records published!", msg.published)),
+			func() tea.Msg {
+				time.Sleep(5 * time.Second)
+				return notifier.HideNotificationMsg{}
+			})
 	case kadmin.PublicationSucceeded:
+		published := "Record published!"
+		if m.formValues.PayloadFormat == nullFormat {
+			published = "Tombstone published!"
+			if m.targetsOrigin() && !m.isCompacted() {
+				published += " " + m.tombstoneWarning()
+			}
+		}
 		m.resetForm()
 		return tea.Batch(
-			m.notifier.ShowSuccessMsg("Record published!"),
+			m.notifier.ShowSuccessMsg(published),
 			func() tea.Msg {
 				time.Sleep(5 * time.Second)
 				return notifier.HideNotificationMsg{}
 			})
 	case tea.KeyMsg:
+		if m.templateNameInput != nil {
+			return m.updateTemplateName(msg)
+		}
+		if m.settingsForm != nil {
+			return m.updateSettings(msg)
+		}
 		switch msg.Type {
 		case tea.KeyEsc:
 			if m.state == publishing {
 				return nil
 			}
+			if m.record != nil {
+				return tea.Batch(m.closePublishers(), ui.PublishMsg(nav.LoadCachedRecordDetailsPageMsg{}))
+			}
 			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
 		case tea.KeyCtrlR:
 			m.resetForm()
+		case tea.KeyCtrlT:
+			if m.config != nil && m.state != publishing {
+				return m.loadNextTemplate()
+			}
+		case tea.KeyCtrlS:
+			if m.config != nil && m.state != publishing && m.topicForm != nil {
+				return m.promptTemplateName()
+			}
+		case tea.KeyCtrlE:
+			if m.state != publishing && m.topicForm != nil {
+				return m.openEditor()
+			}
+		case tea.KeyCtrlO:
+			if m.state != publishing && m.topicForm != nil {
+				return m.openSettings()
+			}
 		}
+	case editor.EditedMsg:
+		return m.applyEdit(msg)
+	}
+	if m.settingsForm != nil {
+		return m.updateSettings(msg)
 	}
 	if m.topicForm != nil && m.state != publishing {
 		form, cmd := m.topicForm.Update(msg)
 		if f, ok := form.(*huh.Form); ok {
 			m.topicForm = f
 		}
+		m.clearOriginPartition()
 		if m.topicForm != nil && m.topicForm.State == huh.StateCompleted {
-			m.state = publishing
 			m.topicForm.State = huh.StateNormal
-			return tea.Batch(
-				m.notifier.SpinWithRocketMsg("Publishing record"),
-				func() tea.Msg {
-					var part *int
-					if m.formValues.Partition != "" {
-						if p, err := strconv.Atoi(m.formValues.Partition); err == nil {
-							part = &p
-						}
+			return m.publish()
+		}
+		return cmd
+	}
+	return nil
+}
+
+// publish publishes the record as many times as requested, expanding its placeholders for every copy
+func (m *Model) publish() tea.Cmd {
+	copies := 1
+	if m.formValues.Copies != "" {
+		copies, _ = strconv.Atoi(m.formValues.Copies)
+	}
+
+	var records []*kadmin.ProducerRecord
+	for i := 0; i < copies; i++ {
+		record, err := m.newRecord()
+		if err != nil {
+			m.topicForm.Init()
+			return m.notifier.ShowErrorMsg("Publication failed!", err)
+		}
+		records = append(records, record)
+	}
+
+	m.state = publishing
+	publisher, ok := m.targetPublisher()
+	if !ok {
+		return m.createPublisher(records)
+	}
+	return m.publishRecords(publisher, records)
+}
+
+// publishRecords publishes a single record or reports the outcome of publishing all of them at once,
+// in a single transaction when the producer is transactional.
+func (m *Model) publishRecords(publisher kadmin.Publisher, records []*kadmin.ProducerRecord) tea.Cmd {
+	copies := len(records)
+	settings := m.settings.ProducerSettings
+	if copies == 1 {
+		return tea.Batch(
+			m.notifier.SpinWithRocketMsg("Publishing record"),
+			func() tea.Msg {
+				return publisher.PublishRecords(records, settings)
+			})
+	}
+
+	if settings.Transactional {
+		return tea.Batch(
+			m.notifier.SpinWithRocketMsg(fmt.Sprintf("Publishing %d records in a transaction", copies)),
+			func() tea.Msg {
+				started := publisher.PublishRecords(records, settings)
+				if failed, ok := started.AwaitCompletion().(kadmin.PublicationFailed); ok {
+					return bulkPublishedMsg{failed: copies, err: failed.Err}
+				}
+				return bulkPublishedMsg{published: copies}
+			})
+	}
+
+	return tea.Batch(
+		m.notifier.SpinWithRocketMsg(fmt.Sprintf("Publishing %d records", copies)),
+		func() tea.Msg {
+			var msg bulkPublishedMsg
+			for _, record := range records {
+				started := publisher.PublishRecords([]*kadmin.ProducerRecord{record}, settings)
+				switch result := started.AwaitCompletion().(type) {
+				case kadmin.PublicationSucceeded:
+					msg.published++
+				case kadmin.PublicationFailed:
+					msg.failed++
+					if msg.err == nil {
+						msg.err = result.Err
 					}
+				}
+			}
+			return msg
+		})
+}
 
-					return m.publisher.PublishRecord(&kadmin.ProducerRecord{
-						Key:       m.formValues.Key,
-						Value:     []byte(m.formValues.Payload),
-						Topic:     m.topic.Name,
-						Headers:   m.formValues.parsedHeaders(),
-						Partition: part,
-					})
-				})
+// newRecord creates a record of the form values with its placeholders expanded
+func (m *Model) newRecord() (*kadmin.ProducerRecord, error) {
+	m.placeholders.next()
+
+	var part *int
+	if m.formValues.Partition != "" {
+		if p, err := strconv.Atoi(m.formValues.Partition); err == nil {
+			part = &p
 		}
-		return cmd
 	}
+
+	key, err := m.expandInFormat(m.formValues.Key, m.formValues.KeyFormat, m.prefilled.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []kadmin.Header
+	for _, h := range m.formValues.Headers {
+		placeholders := m.placeholders
+		if m.record != nil && slices.Contains(m.prefilled.Headers, h) {
+			placeholders = nil
+		}
+		encoded, err := h.encode(placeholders)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, encoded)
+	}
+
+	var payload []byte
+	if m.formValues.PayloadFormat == avroFormat {
+		payload, err = m.serializePayload()
+	} else {
+		payload, err = m.expandInFormat(m.formValues.Payload, m.formValues.PayloadFormat, m.prefilled.Payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp, err := m.placeholders.expand(m.settings.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := parseTimestamp(timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kadmin.ProducerRecord{
+		Key:       key,
+		Value:     payload,
+		Topic:     m.targetTopic(),
+		Timestamp: ts,
+		Headers:   headers,
+		Partition: part,
+	}, nil
+}
+
+func (m *Model) expandInFormat(input string, format inputFormat, prefilled string) ([]byte, error) {
+	if format == nullFormat {
+		return nil, nil
+	}
+	expanded, err := m.placeholdersFor(m.placeholders, input, prefilled).expand(input)
+	if err != nil {
+		return nil, err
+	}
+	return format.bytes(expanded)
+}
+
+// loadNextTemplate fills in the form with the template of the topic following the last loaded one
+func (m *Model) loadNextTemplate() tea.Cmd {
+	templates := m.config.ActiveCluster().TopicPublishTemplates(m.topic.Name)
+	if len(templates) == 0 {
+		return m.notifier.ShowInfoMsg("No templates saved for " + m.topic.Name)
+	}
+
+	m.templateIdx = (m.templateIdx + 1) % len(templates)
+	template := templates[m.templateIdx]
+
+	var headers []header
+	for _, h := range template.Headers {
+		headers = append(headers, header{Key: h.Key, Type: templateHeaderType(h.Type), Value: h.Value})
+	}
+
+	m.formValues.Key = template.Key
+	m.formValues.KeyFormat = templateInputFormat(template.KeyFormat)
+	m.formValues.Headers = headers
+	m.formValues.Payload = template.Payload
+	m.formValues.PayloadFormat = templateInputFormat(template.PayloadFormat)
+	// the schema of an Avro payload is only known when republishing a record of it
+	if m.formValues.PayloadFormat == avroFormat && !m.canSerializeAvro() {
+		m.formValues.PayloadFormat = textFormat
+	}
+	m.templateName = template.Name
+	// recreate the form to show the values of the template
+	m.topicForm = nil
+
+	return m.notifier.ShowInfoMsg(fmt.Sprintf("Template %s loaded (%d/%d)", template.Name, m.templateIdx+1, len(templates)))
+}
+
+// syncFocusedField makes sure the form values reflect the focused field,
+// as text fields only update their value when losing focus.
+func (m *Model) syncFocusedField() {
+	if field := m.topicForm.GetFocusedField(); field != nil {
+		field.Blur()
+		field.Focus()
+	}
+}
+
+// openEditor opens the key and headers when either is focussed, the payload otherwise
+func (m *Model) openEditor() tea.Cmd {
+	m.syncFocusedField()
+
+	tag := payloadEditTag
+	switch m.topicForm.GetFocusedField() {
+	case m.keyInput, m.keyFormatSelect, m.headersField:
+		tag = keyHeadersEditTag
+	}
+
+	content, ok := m.rejectedEdits[tag]
+	if !ok {
+		if tag == payloadEditTag {
+			content = m.formValues.Payload
+		} else {
+			content = m.keyHeadersDocument()
+		}
+	}
+
+	extension := "txt"
+	if tag == keyHeadersEditTag || looksLikeJson(content) {
+		extension = "json"
+	}
+	return m.editor.Edit(tag, content, extension)
+}
+
+func (m *Model) keyHeadersDocument() string {
+	doc := keyHeadersDocument{Key: m.formValues.Key, Headers: []headerDocument{}}
+	for _, h := range m.formValues.Headers {
+		doc.Headers = append(doc.Headers, headerDocument{Key: h.Key, Type: h.Type, Value: h.Value})
+	}
+	b, _ := json.MarshalIndent(doc, "", "  ")
+	return string(b)
+}
+
+// applyEdit loads the edited content into the form when it is valid
+func (m *Model) applyEdit(msg editor.EditedMsg) tea.Cmd {
+	if msg.Err != nil {
+		return m.notifier.ShowErrorMsg("Unable to open editor", msg.Err)
+	}
+
+	var err error
+	if msg.Tag == keyHeadersEditTag {
+		err = m.applyKeyHeadersDocument(msg.Content)
+	} else if m.formValues.PayloadFormat == avroFormat {
+		if err = m.validateAvro(msg.Content); err == nil {
+			m.formValues.Payload = msg.Content
+		}
+	} else if looksLikeJson(msg.Content) {
+		if err = validateJson(msg.Content); err == nil {
+			m.formValues.Payload = msg.Content
+		}
+	} else {
+		m.formValues.Payload = msg.Content
+	}
+
+	if err != nil {
+		if m.rejectedEdits == nil {
+			m.rejectedEdits = make(map[string]string)
+		}
+		m.rejectedEdits[msg.Tag] = msg.Content
+		return m.notifier.ShowErrorMsg("Edit not applied, press C-e to fix it", err)
+	}
+
+	delete(m.rejectedEdits, msg.Tag)
+	// recreate the form to show the edited values
+	m.topicForm = nil
 	return nil
 }
 
-func (v *formValues) parsedHeaders() map[string]string {
-	if v.Headers == "" {
-		return map[string]string{}
+func (m *Model) applyKeyHeadersDocument(content string) error {
+	if err := validateJson(content); err != nil {
+		return err
+	}
+
+	var doc keyHeadersDocument
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return err
 	}
-	headers := map[string]string{}
-	for _, line := range strings.Split(v.Headers, "\n") {
-		if strings.Contains(line, "=") {
-			split := strings.Split(line, "=")
-			key := split[0]
-			value := split[1]
-			headers[key] = value
+
+	var headers []header
+	for _, h := range doc.Headers {
+		headerType := templateHeaderType(h.Type)
+		if !slices.Contains(headerTypes, headerType) {
+			return fmt.Errorf("unknown type %q of header %s", h.Type, h.Key)
 		}
+		headers = append(headers, header{Key: h.Key, Type: headerType, Value: h.Value})
+	}
+	if err := validateHeaders(headers, m.prefilled.Headers); err != nil {
+		return err
+	}
+
+	m.formValues.Key = doc.Key
+	m.formValues.Headers = headers
+	return nil
+}
+
+// validateAvro validates the content with its placeholders expanded can be serialized
+// with the schema of the republished record
+func (m *Model) validateAvro(content string) error {
+	if err := validateJson(content); err != nil {
+		return err
+	}
+	expanded, err := m.placeholdersFor(newPlaceholders(), content, m.prefilled.Payload).expand(content)
+	if err != nil {
+		return err
+	}
+	_, err = m.serializer.Serialize(m.record.Payload.SchemaId, expanded)
+	return err
+}
+
+func looksLikeJson(content string) bool {
+	trimmed := strings.TrimSpace(content)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
+
+// validateJson returns an error pointing to the line of the first syntax error
+func validateJson(content string) error {
+	var v any
+	err := json.Unmarshal([]byte(content), &v)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := strings.Count(content[:syntaxErr.Offset], "\n") + 1
+		return fmt.Errorf("invalid JSON on line %d: %w", line, err)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+func (m *Model) promptTemplateName() tea.Cmd {
+	m.syncFocusedField()
+
+	m.templateNameInput = huh.NewInput().
+		Inline(true).
+		Prompt("Template Name: ").
+		Value(&m.templateName)
+	return m.templateNameInput.Focus()
+}
+
+func (m *Model) updateTemplateName(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.templateNameInput = nil
+		return nil
+	case tea.KeyEnter:
+		name := strings.TrimSpace(m.templateName)
+		if name == "" {
+			return nil
+		}
+		m.templateNameInput = nil
+		m.config.SavePublishTemplate(m.config.ActiveCluster().Name, m.newTemplate(name))
+		return tea.Batch(
+			m.notifier.ShowSuccessMsg("Template "+name+" saved"),
+			m.notifier.AutoHideCmd("publish-page"),
+		)
+	}
+
+	input, cmd := m.templateNameInput.Update(msg)
+	if i, ok := input.(*huh.Input); ok {
+		m.templateNameInput = i
+	}
+	return cmd
+}
+
+func (m *Model) newTemplate(name string) config.PublishTemplate {
+	var headers []config.PublishTemplateHeader
+	for _, h := range m.formValues.Headers {
+		headerType := h.Type
+		if headerType == config.HeaderTypeString {
+			headerType = config.HeaderTypeAuto
+		}
+		headers = append(headers, config.PublishTemplateHeader{Key: h.Key, Type: headerType, Value: h.Value})
+	}
+	return config.PublishTemplate{
+		Name:          name,
+		Topic:         m.topic.Name,
+		Key:           m.formValues.Key,
+		Headers:       headers,
+		Payload:       m.formValues.Payload,
+		KeyFormat:     templateFormat(m.formValues.KeyFormat),
+		PayloadFormat: templateFormat(m.formValues.PayloadFormat),
 	}
-	return headers
+}
+
+// templateFormat returns the format as saved in a template, empty for text
+func templateFormat(format inputFormat) string {
+	if format == textFormat {
+		return ""
+	}
+	return strings.ToLower(string(format))
+}
+
+// templateInputFormat returns the format a template's key or payload is entered in, text when it has none
+func templateInputFormat(format string) inputFormat {
+	for _, f := range []inputFormat{base64Format, hexFormat, nullFormat, avroFormat} {
+		if strings.EqualFold(format, string(f)) {
+			return f
+		}
+	}
+	return textFormat
+}
+
+// templateHeaderType returns the type a header is entered as, a string when it has none
+func templateHeaderType(headerType config.HeaderType) config.HeaderType {
+	if headerType == config.HeaderTypeAuto {
+		return config.HeaderTypeString
+	}
+	return headerType
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
-	return []statusbar.Shortcut{
+	if m.templateNameInput != nil {
+		return []statusbar.Shortcut{
+			{"Save Template", "enter"},
+			{"Cancel", "esc"},
+		}
+	}
+	if m.settingsForm != nil {
+		return []statusbar.Shortcut{
+			{"Apply Settings", "enter"},
+			{"Cancel", "esc"},
+		}
+	}
+	shortcuts := []statusbar.Shortcut{
 		{"Confirm", "enter"},
 		{"Reset Form", "C-r"},
-		{"Go Back", "esc"},
+		{"Open In Editor", "C-e"},
+		{"Producer Settings", "C-o"},
 	}
+	if m.config != nil {
+		shortcuts = append(shortcuts,
+			statusbar.Shortcut{Name: "Load Next Template", Keybinding: "C-t"},
+			statusbar.Shortcut{Name: "Save Template", Keybinding: "C-s"},
+		)
+	}
+	return append(shortcuts, statusbar.Shortcut{Name: "Go Back", Keybinding: "esc"})
 }
 
 func (m *Model) Title() string {
+	if m.record != nil {
+		return "Topics / " + m.topic.Name + " / Republish"
+	}
 	return "Topics / " + m.topic.Name + " / Produce"
 }
 
 func (m *Model) resetForm() {
 	m.state = none
+	if m.record != nil {
+		m.prefill()
+		m.topicForm = nil
+		return
+	}
 	m.formValues.Key = ""
+	m.formValues.KeyFormat = textFormat
 	m.formValues.Partition = ""
 	m.formValues.Payload = ""
-	m.formValues.Headers = ""
+	m.formValues.PayloadFormat = textFormat
+	m.formValues.Headers = nil
+	m.formValues.Copies = "1"
 	m.topicForm = nil
 }
 
+func (m *Model) isCompacted() bool {
+	return strings.Contains(m.topic.Cleanup, "compact")
+}
+
+func (m *Model) tombstoneWarning() string {
+	return fmt.Sprintf("%s is not compacted, the tombstone won't remove earlier records of its key.", m.topic.Name)
+}
+
+// newFormatSelect creates a select of the input formats, validating the input when it is entered before the format
+func newFormatSelect(
+	title string,
+	format *inputFormat,
+	validate func(inputFormat) error,
+	extra ...inputFormat,
+) *huh.Select[inputFormat] {
+	options := []huh.Option[inputFormat]{
+		huh.NewOption(string(textFormat), textFormat),
+		huh.NewOption(string(base64Format), base64Format),
+		huh.NewOption(string(hexFormat), hexFormat),
+		huh.NewOption(string(nullFormat), nullFormat),
+	}
+	for _, f := range extra {
+		options = append(options, huh.NewOption(string(f), f))
+	}
+	return huh.NewSelect[inputFormat]().
+		Inline(true).
+		Title(title).
+		Options(options...).
+		Value(format).
+		Validate(func(f inputFormat) error {
+			if validate == nil {
+				return nil
+			}
+			return validate(f)
+		})
+}
+
 func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
 	payload := huh.NewText().
 		ShowLineNumbers(true).
 		Value(&m.formValues.Payload).
 		Title("Payload").
+		Validate(func(str string) error {
+			return validateInFormat(str, m.formValues.PayloadFormat, m.placeholdersFor(newPlaceholders(), str, m.prefilled.Payload))
+		}).
 		WithHeight(ktx.AvailableHeight - 10)
-	key := huh.NewInput().
+	m.keyInput = huh.NewInput().
 		Title("Key").
-		Description("Leave empty to use a null key for the message.").
+		Description("Choose the Null format to use a null key for the message.").
 		Value(&m.formValues.Key)
-	partition := huh.NewInput().
+	m.keyFormatSelect = newFormatSelect("Key Format: ", &m.formValues.KeyFormat, func(f inputFormat) error {
+		return validateInFormat(m.formValues.Key, f, m.placeholdersFor(newPlaceholders(), m.formValues.Key, m.prefilled.Key))
+	})
+	var payloadFormats []inputFormat
+	if m.canSerializeAvro() {
+		payloadFormats = append(payloadFormats, avroFormat)
+	}
+	payloadFormat := newFormatSelect("Payload Format: ", &m.formValues.PayloadFormat, nil, payloadFormats...)
+	copies := huh.NewInput().
+		Value(&m.formValues.Copies).
+		Title("Copies").
+		Description("Number of records to publish, placeholders are expanded for each of them.").
+		Validate(func(str string) error {
+			if n, e := strconv.Atoi(str); e != nil || n < 1 {
+				return fmt.Errorf("'%s' is not a valid number of copies", str)
+			}
+			return nil
+		})
+	m.partitionInput = huh.NewInput().
 		Value(&m.formValues.Partition).
 		Description("Leave empty to use murmur2 key based partitioner (identical to JVM clients).").
 		Title("Partition").
@@ -191,24 +851,24 @@ func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
 				return errors.New(fmt.Sprintf("'%s' is not a valid numeric partition value", str))
 			} else if n < 0 {
 				return errors.New("value must be at least zero")
-			} else if n > m.topic.PartitionCount-1 {
+			} else if m.targetsOrigin() && n > m.topic.PartitionCount-1 {
 				return errors.New(fmt.Sprintf("partition index %s is invalid, valid range is 0-%d", str, m.topic.PartitionCount-1))
 			}
 			return nil
 		})
-	headers := huh.NewText().
-		Description("Enter headers in the format key=value, one per line.").
-		ShowLineNumbers(true).
-		Value(&m.formValues.Headers).
-		Title("Headers").
-		WithHeight(10)
+	m.headersField = newHeadersField(&m.formValues.Headers, m.prefilled.Headers)
+
+	fields := append(m.targetFields(),
+		m.keyInput,
+		m.keyFormatSelect,
+		m.partitionInput,
+		m.headersField,
+		payloadFormat,
+		copies,
+	)
 
 	form := huh.NewForm(
-		huh.NewGroup(
-			key,
-			partition,
-			headers,
-		).WithWidth(ktx.WindowWidth/2),
+		huh.NewGroup(fields...).WithWidth(ktx.WindowWidth/2),
 		huh.NewGroup(
 			payload,
 		),
@@ -224,11 +884,57 @@ func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
 	return form
 }
 
-func New(p kadmin.Publisher, topic *kadmin.ListedTopic) *Model {
-	return &Model{
-		topic:      topic,
-		publisher:  p,
-		notifier:   notifier.New(),
-		formValues: &formValues{},
+// WithTemplates enables loading and saving the publish templates of the topic
+func WithTemplates(config *config.Config) Option {
+	return func(m *Model) {
+		m.config = config
+	}
+}
+
+// WithEditor opens the key, headers and payload in the given editor
+func WithEditor(editor editor.Editor) Option {
+	return func(m *Model) {
+		m.editor = editor
+	}
+}
+
+// WithRecord pre-fills the form with the consumed record to publish it again
+func WithRecord(record *kadmin.ConsumerRecord) Option {
+	return func(m *Model) {
+		m.record = record
+	}
+}
+
+// WithClusters allows republishing a record to another cluster of the config, connecting to it with the instantiator
+func WithClusters(config *config.Config, instantiator kadmin.Instantiator) Option {
+	return func(m *Model) {
+		m.clusters = config
+		m.instantiator = instantiator
+	}
+}
+
+// WithAvroSerializer serializes the payload of a republished Avro record with its schema again
+func WithAvroSerializer(serializer *serdes.AvroSerializer) Option {
+	return func(m *Model) {
+		m.serializer = serializer
+	}
+}
+
+func New(p kadmin.Publisher, topic *kadmin.ListedTopic, options ...Option) *Model {
+	m := &Model{
+		topic:        topic,
+		publisher:    p,
+		notifier:     notifier.New(),
+		formValues:   &formValues{KeyFormat: textFormat, PayloadFormat: textFormat, Copies: "1"},
+		placeholders: newPlaceholders(),
+		templateIdx:  -1,
+		editor:       editor.New(),
+	}
+	for _, option := range options {
+		option(m)
+	}
+	if m.record != nil {
+		m.prefill()
 	}
+	return m
 }
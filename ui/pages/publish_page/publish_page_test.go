@@ -1,18 +1,27 @@
 package publish_page
 
 import (
+	"errors"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linkedin/goavro/v2"
 	"github.com/stretchr/testify/assert"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/serdes"
+	"ktea/sradmin"
 	"ktea/tests"
 	"ktea/ui/components/notifier"
+	"ktea/ui/editor"
 	"ktea/ui/pages/nav"
+	"strconv"
 	"testing"
+	"time"
 )
 
 type MockPublisher struct {
-	PublishRecordFunc func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg
+	PublishRecordFunc  func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg
+	PublishRecordsFunc func(records []*kadmin.ProducerRecord, settings kadmin.ProducerSettings) kadmin.PublicationStartedMsg
 }
 
 func (m *MockPublisher) PublishRecord(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
@@ -22,28 +31,81 @@ func (m *MockPublisher) PublishRecord(p *kadmin.ProducerRecord) kadmin.Publicati
 	return kadmin.PublicationStartedMsg{}
 }
 
-func TestParseHeaders(t *testing.T) {
-	t.Run("header format is key=value", func(t *testing.T) {
-		fv := formValues{
-			Headers: "key1=value1\n\nkey2=value2\n",
-		}
+// PublishRecords publishes every record with PublishRecordFunc unless PublishRecordsFunc is set
+func (m *MockPublisher) PublishRecords(records []*kadmin.ProducerRecord, settings kadmin.ProducerSettings) kadmin.PublicationStartedMsg {
+	if m.PublishRecordsFunc != nil {
+		return m.PublishRecordsFunc(records, settings)
+	}
+	var started kadmin.PublicationStartedMsg
+	for _, record := range records {
+		started = m.PublishRecord(record)
+	}
+	return started
+}
+
+// clusterKadmin is the admin of another cluster a record is republished to
+type clusterKadmin struct {
+	kadmin.MockKadmin
+	publisher *MockPublisher
+	closed    bool
+}
+
+func (k *clusterKadmin) Close() error {
+	k.closed = true
+	return nil
+}
 
-		headers := fv.parsedHeaders()
+func (k *clusterKadmin) PublishRecord(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+	return k.publisher.PublishRecord(p)
+}
+
+func (k *clusterKadmin) PublishRecords(records []*kadmin.ProducerRecord, settings kadmin.ProducerSettings) kadmin.PublicationStartedMsg {
+	return k.publisher.PublishRecords(records, settings)
+}
+
+// addHeader adds a string header to the focused headers field
+func addHeader(m *Model, key string, value string) {
+	m.Update(tests.Key(tea.KeyCtrlN))
+	tests.UpdateKeys(m, key)
+	m.Update(tests.Key(tea.KeyTab))
+	m.Update(tests.Key(tea.KeyTab))
+	tests.UpdateKeys(m, value)
+}
+
+func TestInputFormat(t *testing.T) {
+	t.Run("empty text is not null", func(t *testing.T) {
+		b, err := textFormat.bytes("")
 
-		assert.Equal(t, map[string]string{
-			"key1": "value1",
-			"key2": "value2",
-		}, headers)
+		assert.NoError(t, err)
+		assert.NotNil(t, b)
+		assert.Empty(t, b)
 	})
 
-	t.Run("no headers filled in", func(t *testing.T) {
-		formValues := formValues{
-			Headers: "",
-		}
+	t.Run("null ignores the input", func(t *testing.T) {
+		b, err := nullFormat.bytes("ignored")
+
+		assert.NoError(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("base64 spanning multiple lines", func(t *testing.T) {
+		b, err := base64Format.bytes("AAEC\nAw==")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0, 1, 2, 3}, b)
+	})
 
-		headers := formValues.parsedHeaders()
+	t.Run("hex separated by spaces", func(t *testing.T) {
+		b, err := hexFormat.bytes("de ad be ef")
 
-		assert.Equal(t, map[string]string{}, headers)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+	})
+
+	t.Run("invalid hex", func(t *testing.T) {
+		_, err := hexFormat.bytes("xyz")
+
+		assert.EqualError(t, err, "not valid hex")
 	})
 }
 
@@ -82,15 +144,26 @@ func TestPublish(t *testing.T) {
 		cmd := m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
 		// Partition
 		tests.UpdateKeys(m, "2")
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
 		// headers
-		tests.UpdateKeys(m, "id=123")
-		cmd = m.Update(tests.KeyWithAlt(tea.KeyEnter))
-		tests.UpdateKeys(m, "user=456")
+		addHeader(m, "id", "123")
+		addHeader(m, "user", "456")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		tests.NextGroup(m, cmd)
 
@@ -127,15 +200,26 @@ func TestPublish(t *testing.T) {
 		cmd := m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
 		// Partition
 		tests.UpdateKeys(m, "2")
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
 		// headers
-		tests.UpdateKeys(m, "id=123")
-		cmd = m.Update(tests.KeyWithAlt(tea.KeyEnter))
-		tests.UpdateKeys(m, "user=456")
+		addHeader(m, "id", "123")
+		addHeader(m, "user", "456")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		tests.NextGroup(m, cmd)
 
@@ -145,15 +229,15 @@ func TestPublish(t *testing.T) {
 
 		tests.Submit(m)
 
-		assert.Equal(t, "key", producerRecord.Key)
+		assert.Equal(t, []byte("key"), producerRecord.Key)
 		assert.Equal(t, "topic1", producerRecord.Topic)
 		assert.Equal(t, 2, *producerRecord.Partition)
 		assert.Equal(t, []byte("payload"), producerRecord.Value)
 		assert.Equal(
 			t,
-			map[string]string{
-				"id":   "123",
-				"user": "456",
+			[]kadmin.Header{
+				{Key: "id", Value: kadmin.NewHeaderValue("123")},
+				{Key: "user", Value: kadmin.NewHeaderValue("456")},
 			},
 			producerRecord.Headers,
 		)
@@ -180,14 +264,25 @@ func TestPublish(t *testing.T) {
 		cmd := m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
 		// Partition
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
 		// headers
-		tests.UpdateKeys(m, "id=123")
-		cmd = m.Update(tests.KeyWithAlt(tea.KeyEnter))
-		tests.UpdateKeys(m, "user=456")
+		addHeader(m, "id", "123")
+		addHeader(m, "user", "456")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		tests.NextGroup(m, cmd)
 
@@ -204,7 +299,7 @@ func TestPublish(t *testing.T) {
 
 		assert.Regexp(t, "Key\\W+Payload\\W+\n.*1.*\n\\W+>\\W+\n", render)
 		assert.Regexp(t, "Partition\\W+\n.*\n\\W+>\\W+\n", render)
-		assert.Regexp(t, "Headers\\W+\n.*\\n\\W+1\\W+\n", render)
+		assert.Contains(t, render, "No headers, press C-n to add one.")
 	})
 
 	t.Run("publish without partition info", func(t *testing.T) {
@@ -230,12 +325,24 @@ func TestPublish(t *testing.T) {
 		cmd := m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
 		// Partition
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
 		// headers
 		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
+		cmd = m.Update(tests.Key(tea.KeyEnter))
 		tests.NextGroup(m, cmd)
 
 		// payload
@@ -245,7 +352,7 @@ func TestPublish(t *testing.T) {
 
 		tests.Submit(m)
 
-		assert.Equal(t, "key", producerRecord.Key)
+		assert.Equal(t, []byte("key"), producerRecord.Key)
 		assert.Equal(t, "topic1", producerRecord.Topic)
 		assert.Nil(t, producerRecord.Partition)
 		assert.Equal(t, []byte("payload"), producerRecord.Value)
@@ -301,14 +408,25 @@ func TestPublish(t *testing.T) {
 		cmd := m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
 		// Partition
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		m.Update(cmd())
 
 		// headers
-		tests.UpdateKeys(m, "id=123")
-		cmd = m.Update(tests.KeyWithAlt(tea.KeyEnter))
-		tests.UpdateKeys(m, "user=456")
+		addHeader(m, "id", "123")
+		addHeader(m, "user", "456")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
 		cmd = m.Update(tests.Key(tea.KeyEnter))
 		tests.NextGroup(m, cmd)
 
@@ -323,115 +441,1303 @@ func TestPublish(t *testing.T) {
 
 		assert.Regexp(t, "Key\\W+Payload\\W+\n.*1.*\n\\W+>\\W+\n", render)
 		assert.Regexp(t, "Partition\\W+\n.*\n\\W+>\\W+\n", render)
-		assert.Regexp(t, "Headers\\W+\n.*\\n\\W+1\\W+\n", render)
+		assert.Contains(t, render, "No headers, press C-n to add one.")
 	})
 
-	t.Run("Validate", func(t *testing.T) {
+	t.Run("publish a tombstone with a null key", func(t *testing.T) {
+		var producerRecord *kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+				producerRecord = p
+				return kadmin.PublicationStartedMsg{}
+			},
+		}, &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
+			Cleanup:        "compact",
+		})
 
-		t.Run("When partition is not a number", func(t *testing.T) {
-			m := New(&MockPublisher{}, &kadmin.ListedTopic{
-				Name:           "topic1",
-				PartitionCount: 1,
-				Replicas:       1,
-			})
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, tests.Renderer)
 
-			m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			// Key
-			tests.UpdateKeys(m, "key")
-			cmd := m.Update(tests.Key(tea.KeyEnter))
-			m.Update(cmd())
-			// Partition
-			tests.UpdateKeys(m, "a1")
-			m.Update(tests.Key(tea.KeyEnter))
+		// Key
+		tests.UpdateKeys(m, "ignored")
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
 
-			render := m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			assert.Contains(t, render, "'a1' is not a valid numeric partition value")
-		})
+		// Key Format
+		for i := 0; i < 3; i++ {
+			m.Update(tests.Key(tea.KeyRight))
+		}
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
 
-		t.Run("When partition is negative", func(t *testing.T) {
-			m := New(&MockPublisher{}, &kadmin.ListedTopic{
-				Name:           "topic1",
-				PartitionCount: 1,
-				Replicas:       1,
-			})
+		// Partition
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
 
-			m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			// Key
-			tests.UpdateKeys(m, "key")
-			cmd := m.Update(tests.Key(tea.KeyEnter))
-			m.Update(cmd())
-			// Partition
-			tests.UpdateKeys(m, "-1")
-			m.Update(tests.Key(tea.KeyEnter))
+		// headers
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
 
-			render := m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			assert.Contains(t, render, "value must be at least zero")
+		// payload format
+		for i := 0; i < 3; i++ {
+			m.Update(tests.Key(tea.KeyRight))
+		}
+		render := m.View(tests.Kontext, tests.Renderer)
+		assert.NotContains(t, render, "is not compacted")
+
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		// payload
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		tests.Submit(m)
+
+		assert.Nil(t, producerRecord.Key)
+		assert.Nil(t, producerRecord.Value)
+
+		m.Update(kadmin.PublicationSucceeded{})
+		render = m.View(tests.Kontext, tests.Renderer)
+		assert.Contains(t, render, "Tombstone published!")
+	})
+
+	t.Run("warn when publishing a tombstone to a non-compacted topic", func(t *testing.T) {
+		m := New(&MockPublisher{}, &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
+			Cleanup:        "delete",
 		})
 
-		t.Run("When partition is zero, should be allowed", func(t *testing.T) {
-			m := New(&MockPublisher{}, &kadmin.ListedTopic{
-				Name:           "topic1",
-				PartitionCount: 1,
-				Replicas:       1,
-			})
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, tests.Renderer)
 
-			m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			// Key
-			tests.UpdateKeys(m, "key")
+		// Key, Key Format, Partition and headers
+		tests.UpdateKeys(m, "key")
+		for i := 0; i < 4; i++ {
 			cmd := m.Update(tests.Key(tea.KeyEnter))
 			m.Update(cmd())
-			// Partition
-			tests.UpdateKeys(m, "0")
-			m.Update(tests.Key(tea.KeyEnter))
+		}
 
-			render := m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
+		// payload format
+		for i := 0; i < 3; i++ {
+			m.Update(tests.Key(tea.KeyRight))
+		}
 
-			assert.Regexp(t, "┃ Partition.\\W+\n.*\n\\W+┃ > 0", render)
-			assert.NotContains(t, render, "value must be at least zero")
+		render := m.View(&kontext.ProgramKtx{
+			WindowWidth:  200,
+			WindowHeight: 100,
+		}, tests.Renderer)
+		assert.Contains(t, render, "⚠ topic1 is not compacted, the tombstone won't remove earlier records of its key.")
+	})
+
+	t.Run("publish a hex key and base64 payload", func(t *testing.T) {
+		var producerRecord *kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+				producerRecord = p
+				return kadmin.PublicationStartedMsg{}
+			},
+		}, &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
 		})
 
-		t.Run("When partition exceeds number of partitions", func(t *testing.T) {
-			m := New(&MockPublisher{}, &kadmin.ListedTopic{
-				Name:           "topic1",
-				PartitionCount: 5,
-				Replicas:       1,
-			})
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, tests.Renderer)
 
-			m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			// Key
-			tests.UpdateKeys(m, "key")
-			cmd := m.Update(tests.Key(tea.KeyEnter))
-			m.Update(cmd())
-			// Partition
-			tests.UpdateKeys(m, "10")
-			m.Update(tests.Key(tea.KeyEnter))
+		// Key
+		tests.UpdateKeys(m, "00ff")
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
 
-			render := m.View(&kontext.ProgramKtx{
-				WindowWidth:  100,
-				WindowHeight: 100,
-			}, tests.Renderer)
-			assert.Contains(t, render, "partition index 10 is invalid, valid range is 0-4")
+		// Key Format
+		m.Update(tests.Key(tea.KeyRight))
+		m.Update(tests.Key(tea.KeyRight))
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// Partition
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// headers
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		m.Update(tests.Key(tea.KeyRight))
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		// payload
+		tests.UpdateKeys(m, "AAEC")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		tests.Submit(m)
+
+		assert.Equal(t, []byte{0x00, 0xff}, producerRecord.Key)
+		assert.Equal(t, []byte{0, 1, 2}, producerRecord.Value)
+	})
+
+	t.Run("publish copies with expanded placeholders", func(t *testing.T) {
+		var producerRecords []*kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+				producerRecords = append(producerRecords, p)
+				started := kadmin.PublicationStartedMsg{
+					Err:       make(chan error, 1),
+					Published: make(chan bool, 1),
+				}
+				if len(producerRecords) == 2 {
+					started.Err <- errors.New("broker down")
+				} else {
+					started.Published <- true
+				}
+				return started
+			},
+		}, &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
+		})
+
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, tests.Renderer)
+
+		// Key
+		tests.UpdateKeys(m, "order-{{seq}}")
+		cmd := m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// Key Format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// Partition
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// headers
+		addHeader(m, "seq", "{{seq}}")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// payload format
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// copies
+		m.Update(tests.Key(tea.KeyBackspace))
+		tests.UpdateKeys(m, "3")
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		// payload
+		tests.UpdateKeys(m, `{"seq": {{seq}}}`)
+		cmd = m.Update(tests.Key(tea.KeyEnter))
+		tests.NextGroup(m, cmd)
+
+		msgs := tests.Submit(m)
+
+		assert.Len(t, producerRecords, 3)
+		for i, record := range producerRecords {
+			seq := strconv.Itoa(i + 1)
+			assert.Equal(t, []byte("order-"+seq), record.Key)
+			assert.Equal(t, []kadmin.Header{{Key: "seq", Value: kadmin.NewHeaderValue(seq)}}, record.Headers)
+			assert.Equal(t, []byte(`{"seq": `+seq+`}`), record.Value)
+		}
+
+		for _, msg := range msgs {
+			m.Update(msg)
+		}
+		render := m.View(tests.Kontext, tests.Renderer)
+		assert.Contains(t, render, "2 of 3 records published, 1 failed: broker down")
+	})
+
+	t.Run("copies must be a positive number", func(t *testing.T) {
+		m := New(&MockPublisher{}, &kadmin.ListedTopic{
+			Name:           "topic1",
+			PartitionCount: 10,
+			Replicas:       1,
+		})
+
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, tests.Renderer)
+
+		// Key, Key Format, Partition, headers and payload format
+		tests.UpdateKeys(m, "key")
+		for i := 0; i < 5; i++ {
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+		}
+
+		// copies
+		m.Update(tests.Key(tea.KeyBackspace))
+		tests.UpdateKeys(m, "0")
+		m.Update(tests.Key(tea.KeyEnter))
+
+		render := m.View(&kontext.ProgramKtx{
+			WindowWidth:  200,
+			WindowHeight: 100,
+		}, tests.Renderer)
+		assert.Contains(t, render, "'0' is not a valid number of copies")
+	})
+
+	t.Run("Templates", func(t *testing.T) {
+		newConfig := func() *config.Config {
+			cfg := config.New(&config.InMemoryConfigIO{})
+			cfg.RegisterCluster(config.RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: config.AuthMethodNone,
+			})
+			return cfg
+		}
+
+		t.Run("C-t loads the templates of the topic in turn", func(t *testing.T) {
+			cfg := newConfig()
+			cfg.SavePublishTemplate("prd", config.PublishTemplate{
+				Name:  "created",
+				Topic: "topic1",
+				Key:   "order-{{seq}}",
+				Headers: []config.PublishTemplateHeader{
+					{Key: "type", Value: "created"},
+					{Key: "version", Type: config.HeaderTypeInt32, Value: "2"},
+				},
+				Payload: "created-payload",
+			})
+			cfg.SavePublishTemplate("prd", config.PublishTemplate{Name: "other", Topic: "topic2", Key: "other-key"})
+			cfg.SavePublishTemplate("prd", config.PublishTemplate{Name: "shipped", Topic: "topic1", Key: "shipped-key"})
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(cfg))
+			m.View(tests.Kontext, tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+			render := m.View(tests.Kontext, tests.Renderer)
+
+			assert.Contains(t, render, "Template created loaded (1/2)")
+			assert.Contains(t, render, "> order-{{seq}}")
+			assert.Regexp(t, `type\s+string\s+created`, render)
+			assert.Regexp(t, `version\s+int32\s+2`, render)
+			assert.Contains(t, render, "created-payload")
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+			render = m.View(tests.Kontext, tests.Renderer)
+
+			assert.Contains(t, render, "Template shipped loaded (2/2)")
+			assert.Contains(t, render, "> shipped-key")
+			assert.NotContains(t, render, "created-payload")
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+			render = m.View(tests.Kontext, tests.Renderer)
+
+			assert.Contains(t, render, "Template created loaded (1/2)")
+		})
+
+		t.Run("C-t without templates", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(newConfig()))
+			m.View(tests.Kontext, tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+			render := m.View(tests.Kontext, tests.Renderer)
+
+			assert.Contains(t, render, "No templates saved for topic1")
+		})
+
+		t.Run("C-s saves the form as template", func(t *testing.T) {
+			cfg := newConfig()
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(cfg))
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+
+			// Key
+			tests.UpdateKeys(m, "{{uuid}}")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+
+			// Key Format
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+
+			// Partition
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+
+			// headers, still focussed
+			addHeader(m, "type", "created")
+			m.Update(tests.Key(tea.KeyCtrlN))
+			tests.UpdateKeys(m, "version")
+			m.Update(tests.Key(tea.KeyTab))
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyTab))
+			tests.UpdateKeys(m, "2")
+
+			m.Update(tests.Key(tea.KeyCtrlS))
+			tests.UpdateKeys(m, "created")
+			render := m.View(tests.Kontext, tests.Renderer)
+			assert.Contains(t, render, "Template Name: created")
+
+			m.Update(tests.Key(tea.KeyEnter))
+
+			assert.Equal(t, []config.PublishTemplate{
+				{
+					Name:  "created",
+					Topic: "topic1",
+					Key:   "{{uuid}}",
+					Headers: []config.PublishTemplateHeader{
+						{Key: "type", Value: "created"},
+						{Key: "version", Type: config.HeaderTypeInt32, Value: "2"},
+					},
+				},
+			}, cfg.ActiveCluster().TopicPublishTemplates("topic1"))
+			render = m.View(tests.Kontext, tests.Renderer)
+			assert.Contains(t, render, "Template created saved")
+			assert.NotContains(t, render, "Template Name:")
+		})
+
+		t.Run("the key and payload formats are saved and loaded", func(t *testing.T) {
+			cfg := newConfig()
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(cfg))
+			m.formValues.KeyFormat = nullFormat
+			m.formValues.Payload = "0a0b"
+			m.formValues.PayloadFormat = hexFormat
+			m.View(tests.Kontext, tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlS))
+			tests.UpdateKeys(m, "hex")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			templates := cfg.ActiveCluster().TopicPublishTemplates("topic1")
+			assert.Len(t, templates, 1)
+			assert.Equal(t, "null", templates[0].KeyFormat)
+			assert.Equal(t, "hex", templates[0].PayloadFormat)
+
+			m.Update(tests.Key(tea.KeyCtrlR))
+			assert.Equal(t, textFormat, m.formValues.PayloadFormat)
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+			render := m.View(tests.Kontext, tests.Renderer)
+
+			assert.Equal(t, nullFormat, m.formValues.KeyFormat)
+			assert.Equal(t, hexFormat, m.formValues.PayloadFormat)
+			assert.Equal(t, "0a0b", m.formValues.Payload)
+			assert.Contains(t, render, "Template hex loaded (1/1)")
+		})
+
+		t.Run("a template without formats loads as text", func(t *testing.T) {
+			cfg := newConfig()
+			cfg.SavePublishTemplate("prd", config.PublishTemplate{Name: "text", Topic: "topic1", Key: "k", Payload: "p"})
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(cfg))
+			m.formValues.PayloadFormat = hexFormat
+			m.View(tests.Kontext, tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlT))
+
+			assert.Equal(t, textFormat, m.formValues.KeyFormat)
+			assert.Equal(t, textFormat, m.formValues.PayloadFormat)
+		})
+
+		t.Run("esc cancels saving", func(t *testing.T) {
+			cfg := newConfig()
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithTemplates(cfg))
+			m.View(tests.Kontext, tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlS))
+			tests.UpdateKeys(m, "created")
+			cmd := m.Update(tests.Key(tea.KeyEsc))
+
+			assert.Nil(t, cmd)
+			assert.Empty(t, cfg.ActiveCluster().PublishTemplates)
+			render := m.View(tests.Kontext, tests.Renderer)
+			assert.NotContains(t, render, "Template Name:")
+		})
+	})
+
+	t.Run("Editor", func(t *testing.T) {
+		type opened struct {
+			tag       string
+			content   string
+			extension string
+		}
+		newPage := func(edited string, openedIn *opened) *Model {
+			mockEditor := editor.NewMock()
+			mockEditor.EditFunc = func(tag string, content string, extension string) tea.Cmd {
+				*openedIn = opened{tag, content, extension}
+				return func() tea.Msg {
+					return editor.EditedMsg{Tag: tag, Content: edited}
+				}
+			}
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			}, WithEditor(mockEditor))
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			return m
+		}
+
+		t.Run("edit key and headers as a document", func(t *testing.T) {
+			var openedIn opened
+			m := newPage(`{"key": "order-2", "headers": [{"key": "id", "value": "2"}, {"key": "user", "value": "a=b"}, `+
+				`{"key": "id", "type": "int64", "value": "3"}]}`, &openedIn)
+
+			tests.UpdateKeys(m, "order-1")
+			cmd := m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			assert.Equal(t, opened{
+				tag:       "key-headers",
+				content:   "{\n  \"key\": \"order-1\",\n  \"headers\": []\n}",
+				extension: "json",
+			}, openedIn)
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "> order-2")
+			assert.Regexp(t, `id\s+string\s+2\s+\n\s+user\s+string\s+a=b\s+\n\s+id\s+int64\s+3`, render)
+		})
+
+		t.Run("edit payload", func(t *testing.T) {
+			var openedIn opened
+			m := newPage(`{"id": 2}`, &openedIn)
+
+			// Key, Key Format, Partition, headers, payload format and copies
+			tests.UpdateKeys(m, "key")
+			for i := 0; i < 5; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+
+			tests.UpdateKeys(m, `{"id": 1}`)
+			cmd = m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			assert.Equal(t, opened{tag: "payload", content: `{"id": 1}`, extension: "json"}, openedIn)
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, `{"id": 2}`)
+			assert.Contains(t, render, "> key")
+		})
+
+		t.Run("invalid JSON is not applied and opened again", func(t *testing.T) {
+			var openedIn opened
+			m := newPage("{\n  \"id\": \n}", &openedIn)
+
+			tests.UpdateKeys(m, "key")
+			cmd := m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  200,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "Edit not applied, press C-e to fix it: invalid JSON on line 3")
+			assert.Contains(t, render, "> key")
+
+			m.Update(tests.Key(tea.KeyCtrlE))
+
+			assert.Equal(t, "{\n  \"id\": \n}", openedIn.content)
+		})
+
+		t.Run("unknown fields in the key and headers document", func(t *testing.T) {
+			var openedIn opened
+			m := newPage(`{"key": "k", "partition": 1}`, &openedIn)
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  200,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, `json: unknown field "partition"`)
+		})
+
+		t.Run("headers in the document must be valid in their type", func(t *testing.T) {
+			var openedIn opened
+			m := newPage(`{"key": "k", "headers": [{"key": "id", "type": "int32", "value": "abc"}]}`, &openedIn)
+
+			cmd := m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  200,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "header id: 'abc' is not a valid int32")
+		})
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		newPage := func(published *[]*kadmin.ProducerRecord) *Model {
+			m := New(&MockPublisher{
+				PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+					*published = append(*published, p)
+					return kadmin.PublicationStartedMsg{}
+				},
+			}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 10,
+				Replicas:       1,
+			})
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			// Key, Key Format and Partition
+			for i := 0; i < 3; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			return m
+		}
+		// submit moves on from the headers to publish the record
+		submit := func(m *Model) {
+			for i := 0; i < 2; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+			tests.Submit(m)
+		}
+
+		t.Run("typed values are encoded as their type", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			m := newPage(&published)
+
+			addHeader(m, "version", "300")
+			// string to int32
+			m.Update(tests.Key(tea.KeyShiftTab))
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyCtrlN))
+			tests.UpdateKeys(m, "raw")
+			m.Update(tests.Key(tea.KeyTab))
+			// string to base64 in reverse
+			for i := 0; i < 2; i++ {
+				m.Update(tests.Key(tea.KeyLeft))
+			}
+			m.Update(tests.Key(tea.KeyTab))
+			tests.UpdateKeys(m, "AP8=")
+			submit(m)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, []kadmin.Header{
+				{Key: "version", Value: kadmin.NewHeaderValue(string([]byte{0x00, 0x00, 0x01, 0x2c}))},
+				{Key: "raw", Value: kadmin.NewHeaderValue(string([]byte{0x00, 0xff}))},
+			}, published[0].Headers)
+		})
+
+		t.Run("C-d removes the focused header", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			m := newPage(&published)
+
+			addHeader(m, "a", "1")
+			addHeader(m, "b", "2")
+			addHeader(m, "c", "3")
+			m.Update(tests.Key(tea.KeyUp))
+			m.Update(tests.Key(tea.KeyCtrlD))
+			submit(m)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, []kadmin.Header{
+				{Key: "a", Value: kadmin.NewHeaderValue("1")},
+				{Key: "c", Value: kadmin.NewHeaderValue("3")},
+			}, published[0].Headers)
+		})
+
+		t.Run("invalid headers are not published", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			m := newPage(&published)
+
+			addHeader(m, "", "no key")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+
+			assert.Nil(t, cmd)
+			assert.Contains(t, m.View(tests.NewKontext(), tests.Renderer), "header key cannot be empty")
+
+			m.Update(tests.Key(tea.KeyCtrlD))
+			addHeader(m, "id", "abc")
+			m.Update(tests.Key(tea.KeyShiftTab))
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyRight))
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+
+			assert.Nil(t, cmd)
+			assert.Contains(t, m.View(tests.NewKontext(), tests.Renderer), "header id: 'abc' is not a valid int64")
+			assert.Empty(t, published)
+		})
+	})
+
+	t.Run("Republish", func(t *testing.T) {
+		topic := &kadmin.ListedTopic{Name: "orders", PartitionCount: 5, Replicas: 1}
+		record := func() *kadmin.ConsumerRecord {
+			return &kadmin.ConsumerRecord{
+				Key:       "k1",
+				RawKey:    []byte("k1"),
+				Payload:   serdes.DesData{Value: `{"id":1}`},
+				RawValue:  []byte(`{"id":1}`),
+				Partition: 3,
+				Offset:    42,
+				Headers: []kadmin.Header{
+					{Key: "h1", Value: kadmin.NewHeaderValue("v1")},
+					{Key: "h2", Value: kadmin.NewHeaderValue("a=b")},
+					{Key: "h1", Value: kadmin.NewHeaderValue("v2")},
+				},
+			}
+		}
+		capturing := func(records *[]*kadmin.ProducerRecord) *MockPublisher {
+			return &MockPublisher{
+				PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+					*records = append(*records, p)
+					return kadmin.PublicationStartedMsg{}
+				},
+			}
+		}
+		// submit confirms the fields of the first group, the payload and publishes
+		submit := func(m *Model, fields int) []tea.Msg {
+			for i := 0; i < fields-1; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+
+			return tests.Submit(m)
+		}
+
+		t.Run("pre-fills the form with the record", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			m := New(capturing(&published), topic, WithRecord(record()))
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			// Topic, Key, Key Format, Partition, headers, payload format and copies
+			submit(m, 7)
+
+			partition := 3
+			assert.Equal(t, []*kadmin.ProducerRecord{{
+				Key:       []byte("k1"),
+				Value:     []byte(`{"id":1}`),
+				Topic:     "orders",
+				Partition: &partition,
+				Headers: []kadmin.Header{
+					{Key: "h1", Value: kadmin.NewHeaderValue("v1")},
+					{Key: "h2", Value: kadmin.NewHeaderValue("a=b")},
+					{Key: "h1", Value: kadmin.NewHeaderValue("v2")},
+				},
+			}}, published)
+			assert.Equal(t, "Topics / orders / Republish", m.Title())
+		})
+
+		t.Run("decoded binary payloads are pre-filled as base64", func(t *testing.T) {
+			r := record()
+			r.Payload = serdes.DesData{Value: `{"id":1}`, Format: "MessagePack"}
+			r.RawValue = []byte{0x81, 0xa2, 0x69, 0x64, 0x01}
+
+			m := New(&MockPublisher{}, topic, WithRecord(r))
+
+			assert.Equal(t, base64Format, m.formValues.PayloadFormat)
+			assert.Equal(t, "gaJpZAE=", m.formValues.Payload)
+		})
+
+		t.Run("to a retry topic", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			r := record()
+			r.Partition = 9
+			m := New(capturing(&published), topic, WithRecord(r))
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			for range "orders" {
+				m.Update(tests.Key(tea.KeyBackspace))
+			}
+			tests.UpdateKeys(m, "orders-retry")
+			submit(m, 7)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, "orders-retry", published[0].Topic)
+			assert.Nil(t, published[0].Partition)
+		})
+
+		t.Run("keeps a partition chosen for a retry topic", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			m := New(capturing(&published), topic, WithRecord(record()))
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			tests.UpdateKeys(m, "-retry")
+			// Topic, Key and Key Format
+			for i := 0; i < 3; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			assert.Empty(t, m.formValues.Partition)
+			tests.UpdateKeys(m, "3")
+			// Partition, headers, payload format and copies
+			submit(m, 4)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, "orders-retry", published[0].Topic)
+			assert.Equal(t, 3, *published[0].Partition)
+		})
+
+		t.Run("unedited content is published as is", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			r := record()
+			r.RawKey = []byte("{{key}}")
+			r.RawValue = []byte(`{"greeting":"Hello {{name}}"}`)
+			r.Headers = []kadmin.Header{{Key: "h1", Value: kadmin.NewHeaderValue("{{#items}}")}}
+			m := New(capturing(&published), topic, WithRecord(r))
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			// Topic, Key, Key Format, Partition, headers, payload format and copies
+			submit(m, 7)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, []byte("{{key}}"), published[0].Key)
+			assert.Equal(t, []byte(`{"greeting":"Hello {{name}}"}`), published[0].Value)
+			assert.Equal(t, []kadmin.Header{{Key: "h1", Value: kadmin.NewHeaderValue("{{#items}}")}}, published[0].Headers)
+		})
+
+		t.Run("edited content has its placeholders expanded", func(t *testing.T) {
+			var published []*kadmin.ProducerRecord
+			r := record()
+			r.RawValue = []byte(`{"greeting":"Hello {{name}}"}`)
+			m := New(capturing(&published), topic, WithRecord(r))
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(editor.EditedMsg{Tag: payloadEditTag, Content: `{"id":"{{seq}}"}`})
+			m.View(tests.NewKontext(), tests.Renderer)
+			// Topic, Key, Key Format, Partition, headers, payload format and copies
+			submit(m, 7)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, []byte(`{"id":"1"}`), published[0].Value)
+		})
+
+		t.Run("esc goes back to the record", func(t *testing.T) {
+			m := New(&MockPublisher{}, topic, WithRecord(record()))
+
+			cmd := m.Update(tests.Key(tea.KeyEsc))
+
+			assert.IsType(t, nav.LoadCachedRecordDetailsPageMsg{}, cmd())
+		})
+
+		t.Run("Avro records are serialized with their schema", func(t *testing.T) {
+			schema := `{"type":"record","name":"Order","fields":[{"name":"id","type":"int"},{"name":"note","type":["null","string"]}]}`
+			sra := sradmin.NewMock()
+			sra.GetSchemaByIdFunc = func(id int) tea.Msg {
+				return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: schema}}
+			}
+			codec, err := goavro.NewCodec(schema)
+			assert.NoError(t, err)
+			expected, err := codec.BinaryFromNative([]byte{0, 0, 0, 0, 7}, map[string]any{
+				"id":   1,
+				"note": goavro.Union("string", "late"),
+			})
+			assert.NoError(t, err)
+			r := record()
+			r.Payload, err = serdes.NewAvroDeserializer(sra).Deserialize(expected)
+			assert.NoError(t, err)
+			var published []*kadmin.ProducerRecord
+			m := New(capturing(&published), topic,
+				WithRecord(r),
+				WithAvroSerializer(serdes.NewAvroSerializer(serdes.NewAvroCodecCache(sra))),
+			)
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Equal(t, avroFormat, m.formValues.PayloadFormat)
+			assert.Equal(t, "{\n  \"id\": 1,\n  \"note\": {\n    \"string\": \"late\"\n  }\n}", m.formValues.Payload)
+
+			submit(m, 7)
+
+			assert.Len(t, published, 1)
+			assert.Equal(t, expected, published[0].Value)
+		})
+
+		t.Run("edited Avro payloads that do not match the schema are not applied", func(t *testing.T) {
+			schema := `{"type":"record","name":"Order","fields":[{"name":"id","type":"int"}]}`
+			sra := sradmin.NewMock()
+			sra.GetSchemaByIdFunc = func(id int) tea.Msg {
+				return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: schema}}
+			}
+			r := record()
+			r.Payload = serdes.DesData{
+				Value:    `{"id":1}`,
+				Schema:   schema,
+				SchemaId: 7,
+				Format:   "Avro",
+			}
+			var reopened string
+			mockEditor := editor.NewMock()
+			mockEditor.EditFunc = func(tag string, content string, extension string) tea.Cmd {
+				reopened = content
+				return func() tea.Msg {
+					return editor.EditedMsg{Tag: tag, Content: `{"id": "one"}`}
+				}
+			}
+			m := New(&MockPublisher{}, topic,
+				WithRecord(r),
+				WithAvroSerializer(serdes.NewAvroSerializer(serdes.NewAvroCodecCache(sra))),
+				WithEditor(mockEditor),
+			)
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			// Topic, Key, Key Format, Partition, headers, payload format and copies
+			for i := 0; i < 6; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+			cmd = m.Update(tests.Key(tea.KeyCtrlE))
+			m.Update(cmd())
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  200,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "Edit not applied, press C-e to fix it: avro serialization failed")
+			assert.Equal(t, "{\n  \"id\": 1\n}", m.formValues.Payload)
+
+			m.Update(tests.Key(tea.KeyCtrlE))
+
+			assert.Equal(t, `{"id": "one"}`, reopened)
+		})
+
+		t.Run("Other clusters", func(t *testing.T) {
+			newConfig := func(dstRegistry string) *config.Config {
+				cfg := config.New(&config.InMemoryConfigIO{})
+				cfg.RegisterCluster(config.RegistrationDetails{
+					Name:           "prd",
+					Host:           "prd:9092",
+					AuthMethod:     config.AuthMethodNone,
+					SchemaRegistry: &config.SchemaRegistryDetails{Url: "http://sr:8081"},
+				})
+				cfg.RegisterCluster(config.RegistrationDetails{
+					Name:           "dr",
+					Host:           "dr:9092",
+					AuthMethod:     config.AuthMethodNone,
+					SchemaRegistry: &config.SchemaRegistryDetails{Url: dstRegistry},
+				})
+				return cfg
+			}
+
+			t.Run("publishes with a publisher of the chosen cluster", func(t *testing.T) {
+				var (
+					published    []*kadmin.ProducerRecord
+					instantiated []string
+				)
+				instantiator := func(cluster *config.Cluster) (kadmin.Kadmin, error) {
+					instantiated = append(instantiated, cluster.Name)
+					return &clusterKadmin{publisher: capturing(&published)}, nil
+				}
+				m := New(&MockPublisher{}, topic, WithRecord(record()), WithClusters(newConfig("http://sr:8081"), instantiator))
+				m.View(tests.NewKontext(), tests.Renderer)
+
+				// Cluster select
+				m.Update(tests.Key(tea.KeyRight))
+				// Cluster, Topic, Key, Key Format, Partition, headers, payload format and copies
+				msgs := submit(m, 8)
+				for _, msg := range msgs {
+					for _, msg := range tests.ExecuteBatchCmd(m.Update(msg)) {
+						m.Update(msg)
+					}
+				}
+
+				assert.Equal(t, []string{"dr"}, instantiated)
+				assert.Len(t, published, 1)
+				assert.Equal(t, "orders", published[0].Topic)
+				assert.Nil(t, published[0].Partition)
+			})
+
+			t.Run("closes the connections to other clusters when going back", func(t *testing.T) {
+				var published []*kadmin.ProducerRecord
+				ka := &clusterKadmin{publisher: capturing(&published)}
+				m := New(&MockPublisher{}, topic, WithRecord(record()), WithClusters(newConfig("http://sr:8081"),
+					func(cluster *config.Cluster) (kadmin.Kadmin, error) {
+						return ka, nil
+					}))
+				m.View(tests.NewKontext(), tests.Renderer)
+
+				m.Update(tests.Key(tea.KeyRight))
+				for _, msg := range submit(m, 8) {
+					for _, msg := range tests.ExecuteBatchCmd(m.Update(msg)) {
+						m.Update(msg)
+					}
+				}
+				m.Update(kadmin.PublicationSucceeded{})
+				assert.False(t, ka.closed)
+
+				msgs := tests.ExecuteBatchCmd(m.Update(tests.Key(tea.KeyEsc)))
+
+				assert.True(t, ka.closed)
+				assert.Contains(t, msgs, nav.LoadCachedRecordDetailsPageMsg{})
+			})
+
+			t.Run("Avro schema ids are only known to the same registry", func(t *testing.T) {
+				r := record()
+				r.Payload = serdes.DesData{Value: `{"id":1}`, Schema: `"string"`, SchemaId: 7, Format: "Avro"}
+				m := New(&MockPublisher{}, topic,
+					WithRecord(r),
+					WithClusters(newConfig("http://other-sr:8081"), func(cluster *config.Cluster) (kadmin.Kadmin, error) {
+						return &clusterKadmin{publisher: &MockPublisher{}}, nil
+					}),
+					WithAvroSerializer(serdes.NewAvroSerializer(serdes.NewAvroCodecCache(sradmin.NewMock()))),
+				)
+				m.View(tests.NewKontext(), tests.Renderer)
+
+				m.Update(tests.Key(tea.KeyRight))
+				submit(m, 8)
+
+				render := m.View(&kontext.ProgramKtx{WindowWidth: 200, WindowHeight: 100}, tests.Renderer)
+				assert.Contains(t, render, "schema id 7 is unknown to the schema registry of dr")
+			})
+		})
+	})
+
+	t.Run("Producer settings", func(t *testing.T) {
+		topic := &kadmin.ListedTopic{Name: "topic1", PartitionCount: 10, Replicas: 1}
+		// enter confirms the focused field and processes the resulting messages of the form
+		enter := func(m *Model) {
+			cmds := []tea.Cmd{m.Update(tests.Key(tea.KeyEnter))}
+			for i := 0; i < 3; i++ {
+				var next []tea.Cmd
+				for _, cmd := range cmds {
+					for _, msg := range tests.ExecuteBatchCmd(cmd) {
+						next = append(next, m.Update(msg))
+					}
+				}
+				cmds = next
+			}
+		}
+
+		t.Run("C-o changes the settings of the published records", func(t *testing.T) {
+			var (
+				published []*kadmin.ProducerRecord
+				settings  kadmin.ProducerSettings
+			)
+			m := New(&MockPublisher{
+				PublishRecordsFunc: func(records []*kadmin.ProducerRecord, s kadmin.ProducerSettings) kadmin.PublicationStartedMsg {
+					published = append(published, records...)
+					settings = s
+					return kadmin.PublicationStartedMsg{}
+				},
+			}, topic)
+			m.View(tests.NewKontext(), tests.Renderer)
+			tests.UpdateKeys(m, "key")
+
+			m.Update(tests.Key(tea.KeyCtrlO))
+			// Acks
+			m.Update(tests.Key(tea.KeyRight))
+			enter(m)
+			// Compression
+			m.Update(tests.Key(tea.KeyRight))
+			enter(m)
+			// Partitioner, Idempotent and Transactional
+			enter(m)
+			enter(m)
+			enter(m)
+			// Timestamp
+			tests.UpdateKeys(m, "1709298855000")
+			enter(m)
+
+			render := m.View(&kontext.ProgramKtx{WindowWidth: 200, WindowHeight: 100}, tests.Renderer)
+			assert.Contains(t, render, "Producer: acks leader, gzip compressed, timestamp 1709298855000")
+			assert.Contains(t, render, "> key")
+
+			// Key, Key Format, Partition, headers, payload format and copies
+			for i := 0; i < 5; i++ {
+				cmd := m.Update(tests.Key(tea.KeyEnter))
+				m.Update(cmd())
+			}
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			tests.NextGroup(m, cmd)
+			tests.Submit(m)
+
+			assert.Equal(t, kadmin.ProducerSettings{
+				Acks:        kadmin.AcksLeader,
+				Compression: kadmin.GzipCompression,
+				Partitioner: kadmin.Murmur2KeyHasher,
+			}, settings)
+			assert.Len(t, published, 1)
+			assert.Equal(t, time.UnixMilli(1709298855000), published[0].Timestamp)
+		})
+
+		t.Run("esc discards the changed settings", func(t *testing.T) {
+			m := New(&MockPublisher{}, topic)
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlO))
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyEsc))
+
+			assert.Equal(t, producerSettings{}, m.settings)
+			assert.NotContains(t, m.View(tests.NewKontext(), tests.Renderer), "Producer:")
+		})
+
+		t.Run("idempotence requires acks all", func(t *testing.T) {
+			m := New(&MockPublisher{}, topic)
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(tests.Key(tea.KeyCtrlO))
+			m.Update(tests.Key(tea.KeyRight))
+			enter(m)
+			enter(m)
+			enter(m)
+			// Idempotent
+			m.Update(tests.Key(tea.KeyLeft))
+			enter(m)
+
+			render := m.View(&kontext.ProgramKtx{WindowWidth: 200, WindowHeight: 100}, tests.Renderer)
+			assert.Contains(t, render, "an idempotent producer requires acks all")
+		})
+
+		t.Run("transactional copies are published at once", func(t *testing.T) {
+			var calls [][]*kadmin.ProducerRecord
+			m := New(&MockPublisher{
+				PublishRecordsFunc: func(records []*kadmin.ProducerRecord, s kadmin.ProducerSettings) kadmin.PublicationStartedMsg {
+					calls = append(calls, records)
+					started := kadmin.PublicationStartedMsg{Err: make(chan error, 1)}
+					started.Err <- errors.New("transaction aborted: broker down")
+					return started
+				},
+			}, topic)
+			m.settings.Transactional = true
+			m.formValues.Key = "key"
+			m.formValues.Copies = "3"
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			for _, msg := range tests.ExecuteBatchCmd(m.publish()) {
+				m.Update(msg)
+			}
+
+			assert.Len(t, calls, 1)
+			assert.Len(t, calls[0], 3)
+			render := m.View(&kontext.ProgramKtx{WindowWidth: 200, WindowHeight: 100}, tests.Renderer)
+			assert.Contains(t, render, "0 of 3 records published, 3 failed: transaction aborted: broker down")
+		})
+
+		t.Run("timestamps", func(t *testing.T) {
+			ts, err := parseTimestamp("2024-03-01T13:14:15.123Z")
+			assert.NoError(t, err)
+			assert.Equal(t, time.Date(2024, time.March, 1, 13, 14, 15, 123_000_000, time.UTC), ts)
+
+			ts, err = parseTimestamp("")
+			assert.NoError(t, err)
+			assert.True(t, ts.IsZero())
+
+			_, err = parseTimestamp("yesterday")
+			assert.EqualError(t, err, "'yesterday' is not a valid timestamp, use RFC 3339 or epoch milliseconds")
+		})
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+
+		t.Run("When partition is not a number", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 1,
+				Replicas:       1,
+			})
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			// Key
+			tests.UpdateKeys(m, "key")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Key Format
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Partition
+			tests.UpdateKeys(m, "a1")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "'a1' is not a valid numeric partition value")
+		})
+
+		t.Run("When partition is negative", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 1,
+				Replicas:       1,
+			})
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			// Key
+			tests.UpdateKeys(m, "key")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Key Format
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Partition
+			tests.UpdateKeys(m, "-1")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "value must be at least zero")
+		})
+
+		t.Run("When partition is zero, should be allowed", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 1,
+				Replicas:       1,
+			})
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			// Key
+			tests.UpdateKeys(m, "key")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Key Format
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Partition
+			tests.UpdateKeys(m, "0")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+
+			assert.Regexp(t, "┃ Partition.\\W+\n.*\n\\W+┃ > 0", render)
+			assert.NotContains(t, render, "value must be at least zero")
+		})
+
+		t.Run("When partition exceeds number of partitions", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 5,
+				Replicas:       1,
+			})
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			// Key
+			tests.UpdateKeys(m, "key")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Key Format
+			cmd = m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Partition
+			tests.UpdateKeys(m, "10")
+			m.Update(tests.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "partition index 10 is invalid, valid range is 0-4")
+		})
+
+		t.Run("When the key is not valid in its format", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 1,
+				Replicas:       1,
+			})
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			// Key
+			tests.UpdateKeys(m, "xyz")
+			cmd := m.Update(tests.Key(tea.KeyEnter))
+			m.Update(cmd())
+			// Key Format
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyRight))
+			m.Update(tests.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, tests.Renderer)
+			assert.Contains(t, render, "not valid hex")
 		})
 	})
 }
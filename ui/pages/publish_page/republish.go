@@ -0,0 +1,257 @@
+package publish_page
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ktea/config"
+	"ktea/kadmin"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/log"
+)
+
+// publisherCreatedMsg carries the publisher of another cluster, created to publish the given records
+type publisherCreatedMsg struct {
+	cluster   string
+	publisher kadmin.Kadmin
+	records   []*kadmin.ProducerRecord
+	err       error
+}
+
+// prefill fills in the form with the key, headers, payload and partition of the republished record
+func (m *Model) prefill() {
+	r := m.record
+
+	m.formValues.Topic = m.topic.Name
+	if m.clusters != nil {
+		m.formValues.Cluster = m.clusters.ActiveCluster().Name
+	}
+
+	switch {
+	case r.RawKey != nil:
+		m.formValues.Key, m.formValues.KeyFormat = rawInput(r.RawKey)
+	case r.Key == "":
+		m.formValues.Key, m.formValues.KeyFormat = "", nullFormat
+	default:
+		m.formValues.Key, m.formValues.KeyFormat = r.Key, textFormat
+	}
+
+	m.formValues.Headers = nil
+	for _, h := range r.Headers {
+		value, format := rawInput(h.Value.Bytes())
+		headerType := config.HeaderTypeString
+		if format == base64Format {
+			headerType = config.HeaderTypeBase64
+		}
+		m.formValues.Headers = append(m.formValues.Headers, header{Key: h.Key, Type: headerType, Value: value})
+	}
+
+	switch {
+	case r.Tombstone:
+		m.formValues.Payload, m.formValues.PayloadFormat = "", nullFormat
+	case m.canSerializeAvro():
+		value, err := r.Payload.AvroJsonValue()
+		if err != nil {
+			// plain unions are serialized as well, only ambiguous ones fail
+			value = r.Payload.Value
+		}
+		m.formValues.Payload, m.formValues.PayloadFormat = indentJson(value), avroFormat
+	case r.RawValue != nil && (r.Payload.Format != "" || r.Payload.Schema != ""):
+		// only the exact bytes can be published again when the payload was decoded
+		m.formValues.Payload, m.formValues.PayloadFormat = base64.StdEncoding.EncodeToString(r.RawValue), base64Format
+	case r.RawValue != nil:
+		m.formValues.Payload, m.formValues.PayloadFormat = rawInput(r.RawValue)
+	default:
+		m.formValues.Payload, m.formValues.PayloadFormat = r.Payload.Value, textFormat
+	}
+
+	m.formValues.Partition = strconv.FormatInt(r.Partition, 10)
+	m.originPartition = true
+	m.formValues.Copies = "1"
+
+	m.prefilled = *m.formValues
+	m.prefilled.Headers = slices.Clone(m.formValues.Headers)
+}
+
+// placeholdersFor returns the placeholders to expand the input with, nil to keep it as is when it is
+// the unedited content pre-filled from the republished record, as consumed content can contain {{ by itself.
+func (m *Model) placeholdersFor(p *placeholders, input string, prefilled string) *placeholders {
+	if m.record != nil && input == prefilled {
+		return nil
+	}
+	return p
+}
+
+// clearOriginPartition clears the pre-filled partition once another topic or cluster is targeted,
+// as the partition of the republished record might not exist there.
+func (m *Model) clearOriginPartition() {
+	if !m.originPartition || m.targetsOrigin() {
+		return
+	}
+	m.originPartition = false
+	if m.formValues.Partition != strconv.FormatInt(m.record.Partition, 10) {
+		return
+	}
+	m.formValues.Partition = ""
+	if m.partitionInput != nil {
+		m.partitionInput.Value(&m.formValues.Partition)
+	}
+}
+
+// rawInput returns the bytes as text when they are valid UTF-8, base64 encoded otherwise
+func rawInput(b []byte) (string, inputFormat) {
+	if utf8.Valid(b) {
+		return string(b), textFormat
+	}
+	return base64.StdEncoding.EncodeToString(b), base64Format
+}
+
+func indentJson(value string) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(value), "", "  "); err != nil {
+		return value
+	}
+	return indented.String()
+}
+
+// targetFields returns the fields to choose the cluster and topic a record is republished to
+func (m *Model) targetFields() []huh.Field {
+	if m.record == nil {
+		return nil
+	}
+
+	var fields []huh.Field
+	if m.clusters != nil && len(m.clusters.Clusters) > 1 {
+		var options []huh.Option[string]
+		for _, cluster := range m.clusters.Clusters {
+			options = append(options, huh.NewOption(cluster.Name, cluster.Name))
+		}
+		fields = append(fields, huh.NewSelect[string]().
+			Inline(true).
+			Title("Cluster: ").
+			Options(options...).
+			Value(&m.formValues.Cluster))
+	}
+	return append(fields, huh.NewInput().
+		Title("Topic").
+		Description("Change to republish to another topic, e.g. a retry topic.").
+		Value(&m.formValues.Topic).
+		Validate(func(str string) error {
+			if strings.TrimSpace(str) == "" {
+				return errors.New("topic cannot be empty")
+			}
+			return nil
+		}))
+}
+
+func (m *Model) targetTopic() string {
+	if topic := strings.TrimSpace(m.formValues.Topic); topic != "" {
+		return topic
+	}
+	return m.topic.Name
+}
+
+// targetsOtherCluster returns true when republishing to another cluster than the active one
+func (m *Model) targetsOtherCluster() bool {
+	return m.clusters != nil &&
+		m.formValues.Cluster != "" &&
+		m.formValues.Cluster != m.clusters.ActiveCluster().Name
+}
+
+// targetsOrigin returns true when publishing to the topic the page was opened for
+func (m *Model) targetsOrigin() bool {
+	return m.targetTopic() == m.topic.Name && !m.targetsOtherCluster()
+}
+
+// targetPublisher returns the publisher of the target cluster, false when it has yet to be created
+func (m *Model) targetPublisher() (kadmin.Publisher, bool) {
+	if !m.targetsOtherCluster() {
+		return m.publisher, true
+	}
+	publisher, ok := m.publishers[m.formValues.Cluster]
+	return publisher, ok
+}
+
+// createPublisher connects to the target cluster to publish the records to
+func (m *Model) createPublisher(records []*kadmin.ProducerRecord) tea.Cmd {
+	name := m.formValues.Cluster
+	cluster := m.clusters.FindClusterByName(name)
+	return tea.Batch(
+		m.notifier.SpinWithLoadingMsg("Connecting to "+name),
+		func() tea.Msg {
+			if cluster == nil {
+				return publisherCreatedMsg{cluster: name, err: fmt.Errorf("cluster %s not found", name)}
+			}
+			ka, err := m.instantiator(cluster)
+			return publisherCreatedMsg{cluster: name, publisher: ka, records: records, err: err}
+		})
+}
+
+func (m *Model) publishWithCreatedPublisher(msg publisherCreatedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.state = none
+		m.topicForm.Init()
+		return m.notifier.ShowErrorMsg("Unable to connect to "+msg.cluster, msg.err)
+	}
+	if m.publishers == nil {
+		m.publishers = make(map[string]kadmin.Kadmin)
+	}
+	m.publishers[msg.cluster] = msg.publisher
+	return m.publishRecords(msg.publisher, msg.records)
+}
+
+// closePublishers closes the connections to the other clusters published to, as they are only used by this page
+func (m *Model) closePublishers() tea.Cmd {
+	publishers := m.publishers
+	m.publishers = nil
+	if len(publishers) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		for cluster, publisher := range publishers {
+			if err := publisher.Close(); err != nil {
+				log.Error("Unable to close connection", "cluster", cluster, "err", err)
+			}
+		}
+		return nil
+	}
+}
+
+// canSerializeAvro returns true when the payload of the republished record can be serialized with its schema
+func (m *Model) canSerializeAvro() bool {
+	return m.serializer != nil && m.record != nil && m.record.Payload.SchemaId != 0
+}
+
+// serializePayload serializes the payload with the schema of the republished record, which is only known
+// to the schema registry of the active cluster.
+func (m *Model) serializePayload() ([]byte, error) {
+	schemaId := m.record.Payload.SchemaId
+	if m.targetsOtherCluster() {
+		target := m.clusters.FindClusterByName(m.formValues.Cluster)
+		if !sameSchemaRegistry(m.clusters.ActiveCluster(), target) {
+			return nil, fmt.Errorf("schema id %d is unknown to the schema registry of %s, choose another payload format",
+				schemaId, m.formValues.Cluster)
+		}
+	}
+
+	expanded, err := m.placeholdersFor(m.placeholders, m.formValues.Payload, m.prefilled.Payload).expand(m.formValues.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return m.serializer.Serialize(schemaId, expanded)
+}
+
+func sameSchemaRegistry(a *config.Cluster, b *config.Cluster) bool {
+	if a == nil || b == nil || !a.HasSchemaRegistry() || !b.HasSchemaRegistry() {
+		return false
+	}
+	return strings.TrimSuffix(a.SchemaRegistry.Url, "/") == strings.TrimSuffix(b.SchemaRegistry.Url, "/")
+}
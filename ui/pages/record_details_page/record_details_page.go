@@ -1,10 +1,13 @@
 package record_details_page
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/sradmin"
 	"ktea/styles"
 	"ktea/ui"
 	"ktea/ui/clipper"
@@ -13,17 +16,21 @@ import (
 	"ktea/ui/components/notifier"
 	"ktea/ui/components/statusbar"
 	ktable "ktea/ui/components/table"
+	"ktea/ui/editor"
 	"ktea/ui/pages/nav"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/reflow/wordwrap"
 )
 
 type focus bool
@@ -36,6 +43,26 @@ const (
 	schemaView       state = false
 )
 
+// encoding determines how the key and value of a record are displayed
+type encoding int
+
+const (
+	textEncoding encoding = iota
+	hexEncoding
+	base64Encoding
+)
+
+func (e encoding) String() string {
+	switch e {
+	case hexEncoding:
+		return "Hex"
+	case base64Encoding:
+		return "Base64"
+	default:
+		return "Text"
+	}
+}
+
 type Model struct {
 	notifierCmdbar *cmdbar.NotifierCmdBar
 	record         *kadmin.ConsumerRecord
@@ -46,8 +73,13 @@ type Model struct {
 	topicName      string
 	headerKeyTable *table.Model
 	headerRows     []table.Row
-	focus          focus
-	state          state
+	// headers are the record's headers sorted by key for display, the record keeps their original order
+	headers  []kadmin.Header
+	focus    focus
+	state    state
+	encoding encoding
+	// avroJsonUnions shows unions in the Avro JSON encoding, wrapped in an object keyed by their type
+	avroJsonUnions bool
 	payload        string
 	err            error
 	metaInfo       string
@@ -55,8 +87,21 @@ type Model struct {
 	config         *config.Config
 	schemaVp       *viewport.Model
 	border         *border.Model
+	schemaResolver sradmin.SchemaSubjectResolver
+	// schemaSubjects holds the subject and version of the schema ids resolved so far
+	schemaSubjects map[int]sradmin.SchemaSubjectResolvedMsg
+	// headerTypes holds the header types cycled to that are not saved yet
+	headerTypes map[string]config.HeaderType
+	// headerTypeCycles counts the header type cycles, only the settling of the last one saves them
+	headerTypeCycles int
+	editor           editor.Editor
 }
 
+// headerTypeSettleDelay is how long the header type has to stay unchanged before it is saved
+const headerTypeSettleDelay = time.Second
+
+type Option func(m *Model)
+
 type PayloadCopiedMsg struct {
 }
 
@@ -76,6 +121,10 @@ type NavigateToNextRecordMsg struct {
 type NavigateToPrevRecordMsg struct {
 }
 
+type headerTypeSettledMsg struct {
+	cycle int
+}
+
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 
 	notifierCmdbarView := m.notifierCmdbar.View(ktx, renderer)
@@ -96,6 +145,19 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 }
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case sradmin.SchemaSubjectResolvingStartedMsg:
+		return msg.AwaitCompletion
+	case sradmin.SchemaSubjectResolvedMsg:
+		m.schemaSubjects[msg.SchemaId] = msg
+		return nil
+	case headerTypeSettledMsg:
+		if msg.cycle == m.headerTypeCycles {
+			m.saveHeaderTypes()
+		}
+		return nil
+	}
+
 	if m.recordVp == nil && m.err == nil {
 		return nil
 	}
@@ -107,6 +169,9 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() != "t" {
+			m.saveHeaderTypes()
+		}
 		switch msg.String() {
 		case "esc":
 			return ui.PublishMsg(nav.LoadCachedConsumptionPageMsg{})
@@ -121,6 +186,37 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			}
 		case "c":
 			cmds = m.handleCopy(cmds)
+		case "e":
+			if m.focus == mainViewFocus && (m.err == nil || m.encoding != textEncoding) {
+				return m.openInEditor()
+			}
+		case "p":
+			return ui.PublishMsg(nav.LoadPublishPageMsg{
+				Topic:  &kadmin.ListedTopic{Name: m.topicName},
+				Record: m.record,
+			})
+		case "t":
+			if m.focus == headersViewFocus {
+				cmds = append(cmds, m.cycleHeaderType())
+			}
+		case "s":
+			if subject, ok := m.schemaSubject(); ok {
+				return ui.PublishMsg(nav.LoadSchemaDetailsPageMsg{
+					Subject: subject.Subject,
+					Version: subject.Version,
+				})
+			}
+		case "x":
+			if m.focus == mainViewFocus && m.state == recordView {
+				m.encoding = (m.encoding + 1) % 3
+				m.recordVp = nil
+			}
+		case "u":
+			if m.hasAvroJsonUnions() && m.focus == mainViewFocus && m.state == recordView {
+				m.avroJsonUnions = !m.avroJsonUnions
+				m.payload = m.renderPayload()
+				m.recordVp = nil
+			}
 		case "tab":
 			if m.record.Payload.Schema != "" && m.focus == mainViewFocus {
 				m.state = !m.state
@@ -173,7 +269,7 @@ func (m *Model) sidebarView(ktx *kontext.ProgramKtx, payloadWidth int, height in
 	if len(m.record.Headers) == 0 {
 		headerSideBar = ui.JoinVertical(
 			lipgloss.Top,
-			lipgloss.NewStyle().Padding(1).Render(m.metaInfo),
+			lipgloss.NewStyle().Padding(1).Render(m.metaInfo+m.schemaInfo()),
 			lipgloss.JoinVertical(lipgloss.Center, lipgloss.NewStyle().Padding(1).Render("No headers present")),
 		)
 	} else {
@@ -187,43 +283,132 @@ func (m *Model) sidebarView(ktx *kontext.ProgramKtx, payloadWidth int, height in
 		m.headerKeyTable.SetHeight(headerValueTableHeight)
 		m.headerKeyTable.SetRows(m.headerRows)
 
-		headerValueLine := strings.Builder{}
-		for i := 0; i < sideBarWidth; i++ {
-			headerValueLine.WriteString("─")
-		}
-
-		headerValue := m.selectedHeaderValue()
-		m.headerValueVp.SetContent("Header Value\n" + headerValueLine.String() + "\n" + headerValue)
+		m.headerValueVp.SetContent(m.headerValueContent(sideBarWidth))
 
 		headerSideBar = ui.JoinVertical(
 			lipgloss.Top,
-			lipgloss.NewStyle().Padding(1).Render(m.metaInfo),
+			lipgloss.NewStyle().Padding(1).Render(m.metaInfo+m.schemaInfo()),
 			headersTableStyle.Render(lipgloss.JoinVertical(lipgloss.Top, m.headerKeyTable.View(), m.headerValueVp.View())),
 		)
 	}
 	return headerSideBar
 }
 
-func (m *Model) selectedHeaderValue() string {
+func (m *Model) selectedHeader() *kadmin.Header {
 	selectedRow := m.headerKeyTable.SelectedRow()
 	if selectedRow == nil {
-		if len(m.record.Headers) > 0 {
-			return m.record.Headers[0].Value.String()
+		if len(m.headers) > 0 {
+			return &m.headers[0]
 		}
 	} else {
-		return m.record.Headers[m.headerKeyTable.Cursor()].Value.String()
+		return &m.headers[m.headerKeyTable.Cursor()]
+	}
+	return nil
+}
+
+// selectedHeaderValue returns the decoded value of the selected header, or its guessed value when it can't be decoded
+func (m *Model) selectedHeaderValue() string {
+	header := m.selectedHeader()
+	if header == nil {
+		return ""
+	}
+	value, err := header.Value.Decode(m.headerType(header.Key))
+	if err != nil {
+		return header.Value.String()
+	}
+	return value
+}
+
+// headerValueContent renders the decoded value of the selected header followed by its raw bytes
+func (m *Model) headerValueContent(width int) string {
+	header := m.selectedHeader()
+	if header == nil {
+		return ""
+	}
+
+	line := strings.Repeat("─", width)
+	headerType := m.headerType(header.Key)
+	value, err := header.Value.Decode(headerType)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to decode as %s: %s", headerTypeLabel(headerType), err)
+		value = styles.Notifier.Error.MarginLeft(0).Render(wordwrap.String(msg, width-2))
 	}
-	return ""
+
+	raw := header.Value.Bytes()
+	return fmt.Sprintf("Header Value (%s)\n%s\n%s\n\nRaw (%d bytes)\n%s\n%s",
+		headerTypeLabel(headerType), line, value, len(raw), line, hexBytes(raw, width))
+}
+
+// hexBytes renders the bytes as hex pairs wrapped at the given width
+func hexBytes(data []byte, width int) string {
+	perLine := max((width+1)/3, 1)
+	var lines []string
+	for start := 0; start < len(data); start += perLine {
+		chunk := data[start:min(start+perLine, len(data))]
+		pairs := make([]string, len(chunk))
+		for i, b := range chunk {
+			pairs[i] = fmt.Sprintf("%02x", b)
+		}
+		lines = append(lines, strings.Join(pairs, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// headerType returns how the header with the given key is decoded on the topic of the record
+func (m *Model) headerType(key string) config.HeaderType {
+	if headerType, ok := m.headerTypes[key]; ok {
+		return headerType
+	}
+	cluster := m.config.ActiveCluster()
+	if cluster == nil {
+		return config.HeaderTypeAuto
+	}
+	return cluster.HeaderType(m.topicName, key)
+}
+
+// cycleHeaderType decodes the selected header as the next header type,
+// that choice is saved once no other type is cycled to within headerTypeSettleDelay.
+func (m *Model) cycleHeaderType() tea.Cmd {
+	header := m.selectedHeader()
+	if header == nil || m.config.ActiveCluster() == nil {
+		return nil
+	}
+	current := slices.Index(config.HeaderTypes, m.headerType(header.Key))
+	m.headerTypes[header.Key] = config.HeaderTypes[(current+1)%len(config.HeaderTypes)]
+	m.headerTypeCycles++
+	cycle := m.headerTypeCycles
+	return tea.Tick(headerTypeSettleDelay, func(time.Time) tea.Msg {
+		return headerTypeSettledMsg{cycle}
+	})
+}
+
+// saveHeaderTypes persists the header types cycled to
+func (m *Model) saveHeaderTypes() {
+	cluster := m.config.ActiveCluster()
+	if cluster == nil {
+		return
+	}
+	for key, headerType := range m.headerTypes {
+		m.config.SetHeaderType(cluster.Name, m.topicName, key, headerType)
+	}
+	clear(m.headerTypes)
+}
+
+func headerTypeLabel(headerType config.HeaderType) string {
+	if headerType == config.HeaderTypeAuto {
+		return "auto"
+	}
+	return string(headerType)
 }
 
 func (m *Model) recordView(payloadWidth int, height int) string {
 	if m.recordVp == nil {
 		recordVp := viewport.New(payloadWidth, height)
 		m.recordVp = &recordVp
-		if m.err == nil {
+		if m.err == nil || m.encoding != textEncoding {
 			m.recordVp.SetContent(lipgloss.NewStyle().
 				Padding(0, 1).
-				Render(m.payload))
+				Render(m.recordContent(payloadWidth - 2)))
 		} else {
 			m.recordVp.SetContent(lipgloss.NewStyle().
 				AlignHorizontal(lipgloss.Center).
@@ -243,6 +428,26 @@ func (m *Model) recordView(payloadWidth int, height int) string {
 	return m.recordVp.View()
 }
 
+// recordContent returns the value as text or the raw key and value bytes as hex dump or base64.
+func (m *Model) recordContent(width int) string {
+	var render func([]byte) string
+	switch m.encoding {
+	case hexEncoding:
+		render = func(data []byte) string {
+			return ui.HexDump(data, width)
+		}
+	case base64Encoding:
+		render = func(data []byte) string {
+			return ui.Base64(data, width)
+		}
+	default:
+		return m.payload
+	}
+	return fmt.Sprintf("Key (%d bytes)\n%s\n\nValue (%d bytes)\n%s",
+		len(m.record.RawKey), render(m.record.RawKey),
+		len(m.record.RawValue), render(m.record.RawValue))
+}
+
 func (m *Model) headerStyle() lipgloss.Style {
 	var headersTableStyle lipgloss.Style
 	if m.focus == mainViewFocus {
@@ -267,7 +472,8 @@ func (m *Model) handleCopy(cmds []tea.Cmd) []tea.Cmd {
 		if m.state == schemaView {
 			copiedValue = ansi.Strip(m.record.Payload.Schema)
 		} else {
-			copiedValue = ansi.Strip(m.payload)
+			// base64 is copied without line wrapping
+			copiedValue = ansi.Strip(m.recordContent(0))
 		}
 
 		err := m.clipWriter.Write(copiedValue)
@@ -290,6 +496,22 @@ func (m *Model) handleCopy(cmds []tea.Cmd) []tea.Cmd {
 	return cmds
 }
 
+// openInEditor opens the payload or schema read-only in the editor of the user
+func (m *Model) openInEditor() tea.Cmd {
+	var content string
+	if m.state == schemaView {
+		content = ansi.Strip(ui.PrettyPrintJson(m.record.Payload.Schema))
+	} else {
+		content = ansi.Strip(m.recordContent(0))
+	}
+
+	extension := "txt"
+	if json.Valid([]byte(content)) {
+		extension = "json"
+	}
+	return m.editor.View(content, extension)
+}
+
 func (m *Model) handleNavigateToNext(cmds []tea.Cmd) []tea.Cmd {
 	if m.recordIndex >= len(m.records)-1 {
 		m.notifierCmdbar.Notifier.ShowError(fmt.Errorf("no more records"))
@@ -314,45 +536,100 @@ func (m *Model) loadRecordAtIndex(index int, cmds []tea.Cmd) []tea.Cmd {
 	}
 	m.record = &m.records[index]
 	m.recordIndex = index
+	m.encoding = initialEncoding(m.record)
 	m.resetViews()
 	m.rebuildHeaderRows()
 	m.updateMetaInfo()
-	return cmds
+	return append(cmds, m.Init())
+}
+
+// Init resolves the subject and version of the schema the record was written with
+func (m *Model) Init() tea.Cmd {
+	schemaId := m.record.Payload.SchemaId
+	if m.schemaResolver == nil || schemaId == 0 {
+		return nil
+	}
+	if _, ok := m.schemaSubjects[schemaId]; ok {
+		return nil
+	}
+	return func() tea.Msg {
+		return m.schemaResolver.ResolveSchemaSubject(schemaId, m.topicName)
+	}
+}
+
+func (m *Model) schemaSubject() (sradmin.SchemaSubjectResolvedMsg, bool) {
+	if m.record.Payload.SchemaId == 0 {
+		return sradmin.SchemaSubjectResolvedMsg{}, false
+	}
+	subject, ok := m.schemaSubjects[m.record.Payload.SchemaId]
+	return subject, ok
+}
+
+// schemaInfo renders the subject, version and id of the schema, the id only as long as it isn't resolved
+func (m *Model) schemaInfo() string {
+	schemaId := m.record.Payload.SchemaId
+	if schemaId == 0 {
+		return ""
+	}
+	if subject, ok := m.schemaSubject(); ok {
+		return fmt.Sprintf("\nschema: %s v%d (id %d)", subject.Subject.Name, subject.Version, schemaId)
+	}
+	return fmt.Sprintf("\nschema: id %d", schemaId)
 }
 
 func (m *Model) rebuildHeaderRows() {
-	sort.SliceStable(m.record.Headers, func(i, j int) bool {
-		return m.record.Headers[i].Key < m.record.Headers[j].Key
-	})
+	m.headers = sortedHeaders(m.record.Headers)
 	m.headerRows = nil
-	for _, header := range m.record.Headers {
+	for _, header := range m.headers {
 		m.headerRows = append(m.headerRows, table.Row{header.Key})
 	}
 }
 
+// sortedHeaders returns a copy of the headers sorted by key, duplicate keys keep their order
+func sortedHeaders(headers []kadmin.Header) []kadmin.Header {
+	sorted := slices.Clone(headers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+	return sorted
+}
+
 func (m *Model) resetViews() {
 	m.recordVp = nil
 	m.schemaVp = nil
 }
 
 func (m *Model) updateMetaInfo() {
-	key := m.record.Key
-	if key == "" {
-		key = "<null>"
-	}
-	m.metaInfo = fmt.Sprintf("key: %s\ntimestamp: %s", key, m.record.Timestamp.Format(time.UnixDate))
+	m.metaInfo = metaInfo(m.record)
 	if m.record.Err != nil {
 		m.err = m.record.Err
 		m.notifierCmdbar.Notifier.ShowError(m.record.Err)
 	} else {
 		m.err = nil
-		m.payload = ui.PrettyPrintJson(m.record.Payload.Value)
+		m.payload = m.renderPayload()
 	}
 }
 
+// hasAvroJsonUnions reports whether the record was decoded with a schema containing unions, which render differently in the Avro JSON encoding
+func (m *Model) hasAvroJsonUnions() bool {
+	return m.err == nil && m.record.Payload.HasAvroJsonUnions()
+}
+
+// renderPayload renders only the payload in the encoding shown
+func (m *Model) renderPayload() string {
+	if m.avroJsonUnions && m.hasAvroJsonUnions() {
+		avroJson, err := m.record.Payload.AvroJsonValue()
+		if err == nil {
+			return ui.PrettyPrintJson(avroJson)
+		}
+		m.notifierCmdbar.Notifier.ShowError(err)
+	}
+	return ui.PrettyPrintJson(m.record.Payload.Value)
+}
+
 func (m *Model) updatedFocussedArea(msg tea.Msg, cmds []tea.Cmd) []tea.Cmd {
-	// only update the component if no error is present
-	if m.err != nil {
+	// only update the component if no error is present or the raw bytes are shown
+	if m.err != nil && (m.focus != mainViewFocus || m.encoding == textEncoding) {
 		return cmds
 	}
 
@@ -388,6 +665,34 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 			{"Toggle Headers/Content", "h/left/right"},
 			{"Go Back", "esc"},
 			{"Copy " + whatToCopy, "c"},
+			{"Republish", "p"},
+		}
+
+		if m.focus == mainViewFocus {
+			shortcuts = append(shortcuts, statusbar.Shortcut{
+				Name:       "Open In Editor",
+				Keybinding: "e",
+			})
+		}
+
+		if m.focus == mainViewFocus && m.state == recordView {
+			shortcuts = append(shortcuts, statusbar.Shortcut{
+				Name:       "Cycle Text/Hex/Base64",
+				Keybinding: "x",
+			})
+			if m.hasAvroJsonUnions() && m.encoding == textEncoding {
+				shortcuts = append(shortcuts, statusbar.Shortcut{
+					Name:       "Toggle Plain/Avro JSON Unions",
+					Keybinding: "u",
+				})
+			}
+		}
+
+		if m.focus == headersViewFocus {
+			shortcuts = append(shortcuts, statusbar.Shortcut{
+				Name:       "Cycle Header Type",
+				Keybinding: "t",
+			})
 		}
 
 		if len(m.records) > 1 {
@@ -404,11 +709,20 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 			})
 		}
 
+		if _, ok := m.schemaSubject(); ok {
+			shortcuts = append(shortcuts, statusbar.Shortcut{
+				Name:       "Go To Schema Version",
+				Keybinding: "s",
+			})
+		}
+
 		return shortcuts
 	}
 
 	return []statusbar.Shortcut{
 		{"Go Back", "esc"},
+		{"Cycle Text/Hex/Base64", "x"},
+		{"Republish", "p"},
 	}
 }
 
@@ -424,14 +738,13 @@ func New(
 	recordIndex int,
 	clipWriter clipper.Writer,
 	ktx *kontext.ProgramKtx,
+	options ...Option,
 ) *Model {
 	headersTable := ktable.NewDefaultTable()
 
 	var headerRows []table.Row
-	sort.SliceStable(record.Headers, func(i, j int) bool {
-		return record.Headers[i].Key < record.Headers[j].Key
-	})
-	for _, header := range record.Headers {
+	headers := sortedHeaders(record.Headers)
+	for _, header := range headers {
 		headerRows = append(headerRows, table.Row{header.Key})
 	}
 
@@ -448,13 +761,6 @@ func New(
 		notifierCmdBar.Notifier.ShowError(record.Err)
 	}
 
-	key := record.Key
-	if key == "" {
-		key = "<null>"
-	}
-
-	metaInfo := fmt.Sprintf("key: %s\ntimestamp: %s", key, record.Timestamp.Format(time.UnixDate))
-
 	cmdbar.BindNotificationHandler(notifierCmdBar, func(msg PayloadCopiedMsg, m *notifier.Model) (bool, tea.Cmd) {
 		m.ShowSuccessMsg("Payload copied")
 		return true, m.AutoHideCmd("record-details-page")
@@ -471,6 +777,13 @@ func New(
 		m.ShowErrorMsg("Copy failed", msg.Err)
 		return true, m.AutoHideCmd("record-details-page")
 	})
+	cmdbar.BindNotificationHandler(notifierCmdBar, func(msg editor.ViewedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		if msg.Err == nil {
+			return false, nil
+		}
+		m.ShowErrorMsg("Unable to open editor", msg.Err)
+		return true, m.AutoHideCmd("record-details-page")
+	})
 
 	var tabs []border.Tab
 	if record.Payload.Schema != "" {
@@ -480,12 +793,7 @@ func New(
 		}
 	}
 
-	borderTitle := record.PayloadType()
-	b := border.New(
-		border.WithTabs(tabs...),
-		border.WithTitle("[ "+borderTitle+" ]"))
-
-	return &Model{
+	m := &Model{
 		record:         record,
 		records:        records,
 		recordIndex:    recordIndex,
@@ -493,13 +801,66 @@ func New(
 		headerKeyTable: &headersTable,
 		focus:          mainViewFocus,
 		headerRows:     headerRows,
+		headers:        headers,
 		payload:        payload,
 		err:            err,
-		metaInfo:       metaInfo,
+		metaInfo:       metaInfo(record),
 		clipWriter:     clipWriter,
 		notifierCmdbar: notifierCmdBar,
 		config:         ktx.Config(),
 		state:          recordView,
-		border:         b,
+		encoding:       initialEncoding(record),
+		schemaSubjects: make(map[int]sradmin.SchemaSubjectResolvedMsg),
+		headerTypes:    make(map[string]config.HeaderType),
+		editor:         editor.New(),
+	}
+	for _, option := range options {
+		option(m)
+	}
+
+	m.border = border.New(
+		border.WithTabs(tabs...),
+		border.WithTitleFn(func() string {
+			title := "[ " + m.record.PayloadType() + " ]"
+			if m.encoding != textEncoding && m.state == recordView {
+				title += "[ " + m.encoding.String() + " ]"
+			} else if m.avroJsonUnions && m.hasAvroJsonUnions() && m.state == recordView {
+				title += "[ Avro JSON ]"
+			}
+			return title
+		}))
+
+	return m
+}
+
+// WithEditor opens the payload in the given editor
+func WithEditor(editor editor.Editor) Option {
+	return func(m *Model) {
+		m.editor = editor
+	}
+}
+
+// WithSchemaResolver resolves the subject and version of the schema ids of the records
+func WithSchemaResolver(resolver sradmin.SchemaSubjectResolver) Option {
+	return func(m *Model) {
+		m.schemaResolver = resolver
+	}
+}
+
+// initialEncoding shows records that are not valid UTF-8 as hex dump
+func initialEncoding(record *kadmin.ConsumerRecord) encoding {
+	if record.IsBinary() {
+		return hexEncoding
+	}
+	return textEncoding
+}
+
+func metaInfo(record *kadmin.ConsumerRecord) string {
+	key := record.Key
+	if key == "" {
+		key = "<null>"
+	} else if !utf8.ValidString(key) {
+		key = "0x" + hex.EncodeToString(record.RawKey)
 	}
+	return fmt.Sprintf("key: %s\ntimestamp: %s", key, record.Timestamp.Format(time.UnixDate))
 }
@@ -4,14 +4,20 @@ import (
 	"fmt"
 	"ktea/config"
 	"ktea/kadmin"
+	"ktea/kontext"
 	"ktea/serdes"
+	"ktea/sradmin"
 	"ktea/tests"
 	"ktea/ui/clipper"
 	"ktea/ui/components/statusbar"
+	"ktea/ui/editor"
+	"ktea/ui/pages/nav"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/linkedin/goavro/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,6 +64,33 @@ func TestRecordDetailsPage(t *testing.T) {
 		assert.Equal(t, mainViewFocus, m.focus)
 	})
 
+	t.Run("Headers are shown sorted while the record keeps their order", func(t *testing.T) {
+		record := &kadmin.ConsumerRecord{
+			Payload: serdes.DesData{Value: ""},
+			Headers: []kadmin.Header{
+				{Key: "h2", Value: kadmin.NewHeaderValue("v1")},
+				{Key: "h1", Value: kadmin.NewHeaderValue("v2")},
+				{Key: "h2", Value: kadmin.NewHeaderValue("v3")},
+			},
+		}
+		m := New(record,
+			"",
+			[]kadmin.ConsumerRecord{*record},
+			0,
+			clipper.NewMock(),
+			tests.NewKontext(),
+		)
+		render := m.View(tests.Kontext, tests.Renderer)
+
+		assert.Less(t, strings.Index(render, "h1"), strings.Index(render, "h2"))
+		assert.Equal(t, []string{"h2", "h1", "h2"}, []string{
+			record.Headers[0].Key,
+			record.Headers[1].Key,
+			record.Headers[2].Key,
+		})
+		assert.Equal(t, "h1", m.selectedHeader().Key)
+	})
+
 	t.Run("view schema", func(t *testing.T) {
 		t.Run("Shortcut not visible when cluster has no SchemaRegistry", func(t *testing.T) {
 			ktx := *tests.NewKontext(tests.WithConfig(&config.Config{
@@ -449,6 +482,182 @@ func TestRecordDetailsPage(t *testing.T) {
 		assert.Contains(t, render, "Copy failed: unable to access clipboard")
 	})
 
+	t.Run("Schema subject", func(t *testing.T) {
+		newModel := func(resolver sradmin.SchemaSubjectResolver) (*Model, *kontext.ProgramKtx) {
+			cfg := config.New(&config.InMemoryConfigIO{})
+			cfg.RegisterCluster(config.RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: config.AuthMethodNone,
+				SchemaRegistry: &config.SchemaRegistryDetails{
+					Url: "http://localhost:8081",
+				},
+			})
+			ktx := tests.NewKontext(tests.WithConfig(cfg))
+			records := []kadmin.ConsumerRecord{
+				{Key: "k1", Payload: serdes.DesData{Value: `{"id":1}`, Schema: `"string"`, SchemaId: 1042}},
+				{Key: "k2", Payload: serdes.DesData{Value: `{"id":2}`}},
+			}
+			m := New(&records[0], "orders", records, 0, clipper.NewMock(), ktx, WithSchemaResolver(resolver))
+			m.View(ktx, tests.Renderer)
+			return m, ktx
+		}
+		resolver := sradmin.NewMock()
+		resolver.ResolveSchemaSubjectFunc = func(schemaId int, topic string) tea.Msg {
+			return sradmin.SchemaSubjectResolvedMsg{
+				SchemaId: schemaId,
+				Subject:  sradmin.Subject{Name: topic + "-value", Versions: []int{1, 7, 8}},
+				Version:  7,
+			}
+		}
+
+		t.Run("shows the schema id until resolved", func(t *testing.T) {
+			m, ktx := newModel(resolver)
+
+			render := ansi.Strip(m.View(ktx, tests.Renderer))
+
+			assert.Contains(t, render, "schema: id 1042")
+			assert.NotContains(t, m.Shortcuts(), statusbar.Shortcut{Name: "Go To Schema Version", Keybinding: "s"})
+		})
+
+		t.Run("shows the resolved subject and version", func(t *testing.T) {
+			m, ktx := newModel(resolver)
+
+			msg := m.Init()()
+			assert.Equal(t, sradmin.SchemaSubjectResolvedMsg{
+				SchemaId: 1042,
+				Subject:  sradmin.Subject{Name: "orders-value", Versions: []int{1, 7, 8}},
+				Version:  7,
+			}, msg)
+			m.Update(msg)
+			render := ansi.Strip(m.View(ktx, tests.Renderer))
+
+			assert.Contains(t, render, "schema: orders-value v7 (id 1042)")
+			assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{Name: "Go To Schema Version", Keybinding: "s"})
+			assert.Nil(t, m.Init(), "resolved schema ids are not resolved again")
+		})
+
+		t.Run("s opens the schema version", func(t *testing.T) {
+			m, _ := newModel(resolver)
+			m.Update(m.Init()())
+
+			cmd := m.Update(tests.Key('s'))
+
+			assert.Equal(t, nav.LoadSchemaDetailsPageMsg{
+				Subject: sradmin.Subject{Name: "orders-value", Versions: []int{1, 7, 8}},
+				Version: 7,
+			}, cmd())
+		})
+
+		t.Run("nothing to resolve without a schema id", func(t *testing.T) {
+			m, ktx := newModel(resolver)
+
+			m.Update(tests.Key(tea.KeyCtrlN))
+			m.Update(NavigateToNextRecordMsg{})
+			render := ansi.Strip(m.View(ktx, tests.Renderer))
+
+			assert.Nil(t, m.Init())
+			assert.NotContains(t, render, "schema:")
+		})
+
+		t.Run("nothing to resolve without a schema registry", func(t *testing.T) {
+			m, ktx := newModel(nil)
+
+			assert.Nil(t, m.Init())
+			m.Update(tests.Key('s'))
+			assert.Contains(t, ansi.Strip(m.View(ktx, tests.Renderer)), "schema: id 1042")
+		})
+	})
+
+	t.Run("Header types", func(t *testing.T) {
+		newModel := func() (*Model, *config.Config) {
+			cfg := config.New(&config.InMemoryConfigIO{})
+			cfg.RegisterCluster(config.RegistrationDetails{
+				Name:       "prd",
+				Host:       "localhost:9092",
+				AuthMethod: config.AuthMethodNone,
+			})
+			record := &kadmin.ConsumerRecord{
+				Key:     "k1",
+				Payload: serdes.DesData{Value: `{"name":"John"}`},
+				Headers: []kadmin.Header{
+					{Key: "version", Value: kadmin.NewHeaderValue("\x00\x00\x01\x2c")},
+				},
+			}
+			ktx := tests.NewKontext(tests.WithConfig(cfg))
+			m := New(record, "orders", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), ktx)
+			m.View(ktx, tests.Renderer)
+			return m, cfg
+		}
+
+		t.Run("shows decoded and raw value", func(t *testing.T) {
+			m, cfg := newModel()
+
+			render := ansi.Strip(m.View(tests.NewKontext(tests.WithConfig(cfg)), tests.Renderer))
+
+			assert.Contains(t, render, "Header Value (auto)")
+			assert.Contains(t, render, "300")
+			assert.Contains(t, render, "Raw (4 bytes)")
+			assert.Contains(t, render, "00 00 01 2c")
+		})
+
+		t.Run("t cycles and persists the header type", func(t *testing.T) {
+			m, cfg := newModel()
+			m.Update(tests.Key('h'))
+			assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{Name: "Cycle Header Type", Keybinding: "t"})
+
+			m.Update(tests.Key('t'))
+			m.Update(tests.Key('t'))
+			render := ansi.Strip(m.View(tests.NewKontext(tests.WithConfig(cfg)), tests.Renderer))
+
+			assert.Contains(t, render, "Header Value (int32)")
+			assert.Contains(t, render, "300")
+
+			m.Update(headerTypeSettledMsg{cycle: 2})
+
+			assert.Equal(t, config.HeaderTypeInt32, cfg.ActiveCluster().HeaderType("orders", "version"))
+
+			m.Update(tests.Key('t'))
+			render = ansi.Strip(m.View(tests.NewKontext(tests.WithConfig(cfg)), tests.Renderer))
+
+			assert.Contains(t, render, "Header Value (int64)")
+			assert.Regexp(t, `Unable to decode as\s+int64: an int64 takes\s+8 bytes, got 4`, strings.ReplaceAll(render, "│", ""))
+		})
+
+		t.Run("saves the header type once the selection settled", func(t *testing.T) {
+			m, cfg := newModel()
+			m.Update(tests.Key('h'))
+
+			m.Update(tests.Key('t'))
+			m.Update(tests.Key('t'))
+			m.Update(headerTypeSettledMsg{cycle: 1})
+
+			assert.Equal(t, config.HeaderTypeAuto, cfg.ActiveCluster().HeaderType("orders", "version"))
+
+			m.Update(tests.Key('t'))
+			m.Update(tests.Key('j'))
+
+			assert.Equal(t, config.HeaderTypeInt64, cfg.ActiveCluster().HeaderType("orders", "version"))
+		})
+
+		t.Run("copies the decoded value", func(t *testing.T) {
+			var clippedText string
+			m, cfg := newModel()
+			clipMock := clipper.NewMock()
+			clipMock.WriteFunc = func(text string) error {
+				clippedText = text
+				return nil
+			}
+			m.clipWriter = clipMock
+			cfg.SetHeaderType("prd", "orders", "version", config.HeaderTypeHex)
+
+			m.Update(tests.Key('h'))
+			m.Update(tests.Key('c'))
+
+			assert.Equal(t, "0000012c", clippedText)
+		})
+	})
+
 	t.Run("Copy payload failed", func(t *testing.T) {
 		clipMock := clipper.NewMock()
 		clipMock.WriteFunc = func(text string) error {
@@ -514,6 +723,232 @@ func TestRecordDetailsPage(t *testing.T) {
 			m.Update(tests.Key(tea.KeyF2))
 		})
 	})
+
+	t.Run("Binary payloads", func(t *testing.T) {
+		binaryRecord := func() *kadmin.ConsumerRecord {
+			value := []byte{0x1f, 0x8b, 0x08, 0x00, 'a', 'b', 'c', 0xff}
+			return &kadmin.ConsumerRecord{
+				Key:      "k1",
+				Payload:  serdes.DesData{Value: string(value)},
+				RawKey:   []byte("k1"),
+				RawValue: value,
+			}
+		}
+
+		t.Run("shown as hex dump by default", func(t *testing.T) {
+			record := binaryRecord()
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "Binary")
+			assert.Contains(t, render, "Hex")
+			// the pane is too narrow for 16 bytes per line
+			assert.Contains(t, render, "          00 01 02 03 04 05 06 07")
+			assert.NotContains(t, render, "08 09")
+			assert.Contains(t, render, "Key (2 bytes)")
+			assert.Contains(t, render, "00000000  6b 31")
+			assert.Contains(t, render, "Value (8 bytes)")
+			assert.Contains(t, render, "00000000  1f 8b 08 00 61 62 63 ff")
+			assert.Contains(t, render, "|....abc.|")
+		})
+
+		t.Run("x cycles through base64 and text", func(t *testing.T) {
+			record := binaryRecord()
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(tests.Key('x'))
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "Base64")
+			assert.Contains(t, render, "azE=")
+			assert.Contains(t, render, "H4sIAGFiY/8=")
+
+			m.Update(tests.Key('x'))
+			render = ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.NotContains(t, render, "Value (8 bytes)")
+			assert.NotContains(t, render, "Base64")
+		})
+
+		t.Run("text payloads can be shown as hex dump", func(t *testing.T) {
+			record := &kadmin.ConsumerRecord{
+				Key:      "k1",
+				Payload:  serdes.DesData{Value: `{"name":"John"}`},
+				RawKey:   []byte("k1"),
+				RawValue: []byte(`{"name":"John"}`),
+			}
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+			assert.NotContains(t, render, "Value (15 bytes)")
+
+			m.Update(tests.Key('x'))
+			render = ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "Value (15 bytes)")
+			assert.Contains(t, render, `00000000  7b 22 6e 61 6d 65 22 3a  |{"name":|`)
+			assert.Contains(t, render, `00000008  22 4a 6f 68 6e 22 7d     |"John"}|`)
+		})
+
+		t.Run("copies the base64 value unwrapped", func(t *testing.T) {
+			var clippedText string
+			clipMock := clipper.NewMock()
+			clipMock.WriteFunc = func(text string) error {
+				clippedText = text
+				return nil
+			}
+			record := binaryRecord()
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipMock, tests.NewKontext())
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(tests.Key('x'))
+			m.View(tests.NewKontext(), tests.Renderer)
+			m.Update(tests.Key('c'))
+
+			assert.Equal(t, "Key (2 bytes)\nazE=\n\nValue (8 bytes)\nH4sIAGFiY/8=", clippedText)
+		})
+
+		t.Run("decoded binary formats are shown as JSON", func(t *testing.T) {
+			value := []byte{0x81, 0xa4, 'n', 'a', 'm', 'e', 0xa4, 'J', 'o', 'h', 'n'}
+			record := &kadmin.ConsumerRecord{
+				Key:      "k1",
+				Payload:  serdes.DesData{Value: `{"name":"John"}`, Format: "MessagePack"},
+				RawKey:   []byte("k1"),
+				RawValue: value,
+			}
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "[ MessagePack ]")
+			assert.Contains(t, render, `"name": "John"`)
+			assert.NotContains(t, render, "Value (11 bytes)")
+		})
+
+		t.Run("raw bytes remain available on deserialization error", func(t *testing.T) {
+			record := binaryRecord()
+			record.Err = fmt.Errorf("deserialization error")
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "Value (8 bytes)")
+			assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{Name: "Cycle Text/Hex/Base64", Keybinding: "x"})
+		})
+	})
+
+	t.Run("Avro unions", func(t *testing.T) {
+		toggle := statusbar.Shortcut{Name: "Toggle Plain/Avro JSON Unions", Keybinding: "u"}
+		ktx := tests.NewKontext(tests.WithConfig(&config.Config{
+			Clusters: []config.Cluster{
+				{Name: "local", Active: true, SchemaRegistry: &config.SchemaRegistryConfig{Url: "http://localhost:8081"}},
+			},
+		}))
+
+		t.Run("u toggles between plain and Avro JSON unions", func(t *testing.T) {
+			schema := `{"type":"record","name":"Payment","fields":[{"name":"note","type":["null","string"]}]}`
+			sra := sradmin.NewMock()
+			sra.GetSchemaByIdFunc = func(id int) tea.Msg {
+				return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{Value: schema}}
+			}
+			codec, err := goavro.NewCodec(schema)
+			assert.NoError(t, err)
+			data, err := codec.BinaryFromNative([]byte{0, 0, 0, 0, 1}, map[string]any{"note": goavro.Union("string", "paid")})
+			assert.NoError(t, err)
+			payload, err := serdes.NewAvroDeserializer(sra).Deserialize(data)
+			assert.NoError(t, err)
+			record := &kadmin.ConsumerRecord{Key: "k1", Payload: payload}
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), ktx)
+
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+			assert.Contains(t, render, `"note": "paid"`)
+			assert.NotContains(t, render, "Avro JSON")
+			assert.Contains(t, m.Shortcuts(), toggle)
+
+			m.Update(tests.Key('u'))
+			render = ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, "[ Avro JSON ]")
+			assert.Contains(t, render, `"string": "paid"`)
+
+			m.Update(tests.Key('u'))
+			render = ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.Contains(t, render, `"note": "paid"`)
+			assert.NotContains(t, render, "Avro JSON")
+		})
+
+		t.Run("toggle not available when unions render the same", func(t *testing.T) {
+			record := &kadmin.ConsumerRecord{
+				Key:     "k1",
+				Payload: serdes.DesData{Value: `{"name":"John"}`},
+			}
+			m := New(record, "", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), ktx)
+			m.View(tests.NewKontext(), tests.Renderer)
+
+			m.Update(tests.Key('u'))
+			render := ansi.Strip(m.View(tests.NewKontext(), tests.Renderer))
+
+			assert.NotContains(t, render, "Avro JSON")
+			assert.NotContains(t, m.Shortcuts(), toggle)
+		})
+	})
+
+	t.Run("p republishes the record", func(t *testing.T) {
+		record := &kadmin.ConsumerRecord{
+			Key:     "k1",
+			Payload: serdes.DesData{Value: `{"name":"John"}`},
+		}
+		m := New(record, "orders", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), tests.NewKontext())
+		m.View(tests.NewKontext(), tests.Renderer)
+
+		cmd := m.Update(tests.Key('p'))
+
+		assert.Equal(t, nav.LoadPublishPageMsg{
+			Topic:  &kadmin.ListedTopic{Name: "orders"},
+			Record: record,
+		}, cmd())
+	})
+
+	t.Run("Open in editor", func(t *testing.T) {
+		newModel := func(e editor.Editor) (*Model, *kontext.ProgramKtx) {
+			ktx := tests.NewKontext()
+			record := &kadmin.ConsumerRecord{
+				Key:     "k1",
+				Payload: serdes.DesData{Value: `{"name":"John"}`},
+			}
+			m := New(record, "orders", []kadmin.ConsumerRecord{*record}, 0, clipper.NewMock(), ktx, WithEditor(e))
+			m.View(ktx, tests.Renderer)
+			return m, ktx
+		}
+
+		t.Run("opens the pretty printed payload read-only", func(t *testing.T) {
+			var viewedContent, viewedExtension string
+			e := editor.NewMock()
+			e.ViewFunc = func(content string, extension string) tea.Cmd {
+				viewedContent = content
+				viewedExtension = extension
+				return nil
+			}
+			m, _ := newModel(e)
+
+			m.Update(tests.Key('e'))
+
+			assert.Equal(t, "{\n\t\"name\": \"John\"\n}", viewedContent)
+			assert.Equal(t, "json", viewedExtension)
+		})
+
+		t.Run("notifies when the editor could not be opened", func(t *testing.T) {
+			m, ktx := newModel(editor.NewMock())
+
+			m.Update(editor.ViewedMsg{Err: fmt.Errorf("exec: \"vi\": not found")})
+
+			render := ansi.Strip(m.View(ktx, tests.Renderer))
+			assert.Contains(t, render, "Unable to open editor")
+		})
+	})
 }
 
 func TestRecordNavigation(t *testing.T) {
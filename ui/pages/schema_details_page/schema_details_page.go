@@ -31,8 +31,12 @@ type Model struct {
 	atLeastOneSchemaDeleted bool
 	updatedSchemas          []sradmin.Schema
 	clipWriter              clipper.Writer
+	// version is opened once the schemas are listed, the latest when zero
+	version int
 }
 
+type Option func(m *Model)
+
 type SchemaCopiedMsg struct {
 }
 
@@ -136,6 +140,11 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			return m.schemas[i].Version < m.schemas[j].Version
 		})
 		m.activeSchema = m.latestSchema()
+		for _, schema := range m.schemas {
+			if schema.Version == m.version {
+				m.activeSchema = &schema
+			}
+		}
 	case sradmin.SchemaListingStarted:
 		cmds = append(cmds, msg.AwaitCompletion)
 	case sradmin.SchemaDeletionStartedMsg:
@@ -219,11 +228,19 @@ func (m *Model) latestSchema() *sradmin.Schema {
 	return &latest
 }
 
+// WithVersion opens the given version instead of the latest one
+func WithVersion(version int) Option {
+	return func(m *Model) {
+		m.version = version
+	}
+}
+
 func New(
 	schemaLister sradmin.VersionLister,
 	schemaDeleter sradmin.SchemaDeleter,
 	subject sradmin.Subject,
 	clipWriter clipper.Writer,
+	options ...Option,
 ) (*Model, tea.Cmd) {
 
 	model := &Model{
@@ -231,6 +248,9 @@ func New(
 		schemaLister: schemaLister,
 		clipWriter:   clipWriter,
 	}
+	for _, option := range options {
+		option(model)
+	}
 
 	deleteFunc := func(version int) tea.Cmd {
 		return func() tea.Msg {
@@ -73,6 +73,27 @@ func TestSchemaDetailsPage(t *testing.T) {
 		assert.Equal(t, "Subjects / subject-name / Versions / 1", title)
 	})
 
+	t.Run("Opens the requested version", func(t *testing.T) {
+		page, _ := New(&MockSchemaLister{}, &MockSchemaDeleter{}, sradmin.Subject{
+			Name:     "subject-name",
+			Versions: []int{1, 2, 3},
+		}, clipper.NewMock(), WithVersion(2))
+
+		page.Update(sradmin.SchemasListed{
+			Schemas: []sradmin.Schema{
+				{Id: "101", Value: `{"type":"string"}`, Version: 1},
+				{Id: "102", Value: `{"type":"int"}`, Version: 2},
+				{Id: "103", Value: `{"type":"long"}`, Version: 3},
+			},
+		})
+
+		render := ansi.Strip(page.View(tests.NewKontext(), tests.Renderer))
+
+		assert.Equal(t, 2, page.activeSchema.Version)
+		assert.Regexp(t, `ID\s+:\s+102`, render)
+		assert.Contains(t, render, `"int"`)
+	})
+
 	t.Run("Loading indicator", func(t *testing.T) {
 		page, _ := New(&MockSchemaLister{}, &MockSchemaDeleter{}, sradmin.Subject{}, clipper.NewMock())
 
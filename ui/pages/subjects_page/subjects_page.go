@@ -51,6 +51,8 @@ type Model struct {
 	sort            cmdbar.SortLabel
 	globalCompLevel string
 	goToTop         bool
+	// selectSubject is selected once the subjects are listed
+	selectSubject string
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -90,6 +92,14 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 		m.table.GotoTop()
 		m.goToTop = false
 	}
+	if m.selectSubject != "" && m.state == subjectsLoaded {
+		for i, row := range m.rows {
+			if row[0] == m.selectSubject {
+				m.table.SetCursor(i)
+			}
+		}
+		m.selectSubject = ""
+	}
 
 	return ui.JoinVertical(lipgloss.Top, cmdBarView, m.border.View(m.table.View()))
 }
@@ -326,6 +336,12 @@ func (m *Model) SelectedSubject() *sradmin.Subject {
 	return nil
 }
 
+// SelectSubject selects the subject with the given name, as soon as it is listed
+func (m *Model) SelectSubject(name string) {
+	m.selectSubject = name
+	m.tcb.ResetSearch()
+}
+
 func (m *Model) Title() string {
 	return "Subjects"
 }
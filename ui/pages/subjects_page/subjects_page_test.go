@@ -152,6 +152,31 @@ func TestSubjectsPage(t *testing.T) {
 		}, cmd())
 	})
 
+	t.Run("Selects the requested subject once listed", func(t *testing.T) {
+
+		subjectsPage, _ := New(sradmin.NewMock())
+
+		subjectsPage.SelectSubject("orders-value")
+		subjectsPage.View(tests.Kontext, tests.Renderer)
+		subjectsPage.Update(sradmin.SubjectsListedMsg{Subjects: []sradmin.Subject{
+			{Name: "customers-value", Versions: []int{1}},
+			{Name: "invoices-value", Versions: []int{1}},
+			{Name: "orders-value", Versions: []int{1}},
+		}})
+		subjectsPage.View(tests.Kontext, tests.Renderer)
+
+		assert.Equal(t, "orders-value", subjectsPage.SelectedSubject().Name)
+
+		// subsequent listings don't reselect the subject
+		subjectsPage.Update(sradmin.SubjectsListedMsg{Subjects: []sradmin.Subject{
+			{Name: "customers-value", Versions: []int{1}},
+			{Name: "orders-value", Versions: []int{1}},
+		}})
+		subjectsPage.View(tests.Kontext, tests.Renderer)
+
+		assert.Equal(t, "customers-value", subjectsPage.SelectedSubject().Name)
+	})
+
 	t.Run("Order subjects default by Subject Name Asc", func(t *testing.T) {
 		subjectsPage, _ := New(sradmin.NewMock())
 
@@ -3,8 +3,10 @@ package topics_page
 import (
 	"context"
 	"fmt"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/sradmin"
 	"ktea/styles"
 	"ktea/ui"
 	"ktea/ui/components/border"
@@ -37,6 +39,26 @@ const (
 	stateLoaded
 )
 
+// tableViewUnavailableMsg is published when the table view is requested for a non-compacted topic
+type tableViewUnavailableMsg struct {
+	topic string
+}
+
+// subjectsListedMsg holds the subjects listed for the subject columns, it is distinct from
+// sradmin.SubjectsListedMsg as those are routed to the Schema Registry tab.
+type subjectsListedMsg struct {
+	subjects []sradmin.Subject
+}
+
+type subjectListingErrMsg struct {
+	err error
+}
+
+// noValueSubjectMsg is published when the value subject of a topic can't be determined
+type noValueSubjectMsg struct {
+	topic string
+}
+
 type Model struct {
 	topics                    []kadmin.ListedTopic
 	table                     table.Model
@@ -53,24 +75,47 @@ type Model struct {
 	navigator                 tabs.TopicsTabNavigator
 	hiddenInternalTopicsCount int
 	showInternalTopics        bool
+	subjectLister             sradmin.SubjectLister
+	cluster                   *config.Cluster
+	showSubjects              bool
+	// subjects holds the active subjects by name, nil until listed
+	subjects map[string]sradmin.Subject
 }
 
+type Option func(m *Model)
+
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	var views []string
 	cmdBarView := m.tcb.View(ktx, renderer)
 	views = append(views, cmdBarView)
 
 	available := ktx.WindowWidth
-	nameCol := int(float64(available) * 0.6)
-	partCol := int(float64(available) * 0.1)
-	repCol := int(float64(available) * 0.1)
-	cleanCol := available - nameCol - partCol - repCol - 10
-	m.table.SetColumns([]table.Column{
-		{m.sortByCmdBar.PrefixSortIcon("Name"), nameCol},
-		{m.sortByCmdBar.PrefixSortIcon("Partitions"), partCol},
-		{m.sortByCmdBar.PrefixSortIcon("Replicas"), repCol},
-		{m.sortByCmdBar.PrefixSortIcon("Cleanup"), cleanCol},
-	})
+	if m.showSubjects {
+		nameCol := int(float64(available) * 0.3)
+		partCol := int(float64(available) * 0.1)
+		repCol := int(float64(available) * 0.1)
+		cleanCol := int(float64(available) * 0.1)
+		subjectCol := (available - nameCol - partCol - repCol - cleanCol - 14) / 2
+		m.table.SetColumns([]table.Column{
+			{m.sortByCmdBar.PrefixSortIcon("Name"), nameCol},
+			{m.sortByCmdBar.PrefixSortIcon("Partitions"), partCol},
+			{m.sortByCmdBar.PrefixSortIcon("Replicas"), repCol},
+			{m.sortByCmdBar.PrefixSortIcon("Cleanup"), cleanCol},
+			{"Key Subject", subjectCol},
+			{"Value Subject", subjectCol},
+		})
+	} else {
+		nameCol := int(float64(available) * 0.6)
+		partCol := int(float64(available) * 0.1)
+		repCol := int(float64(available) * 0.1)
+		cleanCol := available - nameCol - partCol - repCol - 10
+		m.table.SetColumns([]table.Column{
+			{m.sortByCmdBar.PrefixSortIcon("Name"), nameCol},
+			{m.sortByCmdBar.PrefixSortIcon("Partitions"), partCol},
+			{m.sortByCmdBar.PrefixSortIcon("Replicas"), repCol},
+			{m.sortByCmdBar.PrefixSortIcon("Cleanup"), cleanCol},
+		})
+	}
 	m.table.SetRows(m.rows)
 	m.table.SetWidth(ktx.WindowWidth - 2)
 	m.table.SetHeight(ktx.AvailableTableHeight())
@@ -109,14 +154,51 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 				return nil
 			}
 			return ui.PublishMsg(nav.LoadPublishPageMsg{Topic: m.SelectedTopic()})
+		case "ctrl+f":
+			if m.SelectedTopic() == nil {
+				return nil
+			}
+			return ui.PublishMsg(nav.LoadKeyLookupPageMsg{Topic: m.SelectedTopic()})
+		case "ctrl+t":
+			topic := m.SelectedTopic()
+			if topic == nil {
+				return nil
+			}
+			if !topic.IsCompacted() {
+				return ui.PublishMsg(tableViewUnavailableMsg{topic.Name})
+			}
+			return ui.PublishMsg(nav.LoadCompactedTopicPageMsg{Topic: topic})
 		case "f5":
 			m.topics = nil
 			m.state = stateRefreshing
+			if m.showSubjects {
+				m.subjects = nil
+				return tea.Batch(m.lister.ListTopics, m.listSubjects)
+			}
 			return m.lister.ListTopics
 		case "f4":
 			m.showInternalTopics = !m.showInternalTopics
 			m.rows = m.createRows()
 			return nil
+		case "f6":
+			if m.subjectLister == nil {
+				return nil
+			}
+			m.showSubjects = !m.showSubjects
+			m.rows = m.createRows()
+			if m.showSubjects && m.subjects == nil {
+				return m.listSubjects
+			}
+			return nil
+		case "S":
+			topic := m.SelectedTopic()
+			if m.subjectLister == nil || topic == nil {
+				return nil
+			}
+			if _, value := m.cluster.Subjects(topic.Name); value != "" {
+				return ui.PublishMsg(nav.LoadSubjectsPageMsg{Subject: value})
+			}
+			return ui.PublishMsg(noValueSubjectMsg{topic.Name})
 		case "L":
 			if m.SelectedTopic() == nil {
 				return nil
@@ -163,6 +245,13 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			m.topics,
 			func(t kadmin.ListedTopic) bool { return msg.TopicName == t.Name },
 		)
+	case subjectsListedMsg:
+		m.subjects = make(map[string]sradmin.Subject)
+		for _, subject := range msg.subjects {
+			if !subject.Deleted {
+				m.subjects[subject.Name] = subject
+			}
+		}
 	}
 
 	var cmd tea.Cmd
@@ -197,26 +286,10 @@ func (m *Model) createRows() []table.Row {
 		}
 		if m.tcb.GetSearchTerm() != "" {
 			if strings.Contains(strings.ToLower(topic.Name), strings.ToLower(m.tcb.GetSearchTerm())) {
-				rows = append(
-					rows,
-					table.Row{
-						topic.Name,
-						strconv.Itoa(topic.PartitionCount),
-						strconv.Itoa(topic.Replicas),
-						topic.Cleanup,
-					},
-				)
+				rows = append(rows, m.createRow(topic))
 			}
 		} else {
-			rows = append(
-				rows,
-				table.Row{
-					topic.Name,
-					strconv.Itoa(topic.PartitionCount),
-					strconv.Itoa(topic.Replicas),
-					topic.Cleanup,
-				},
-			)
+			rows = append(rows, m.createRow(topic))
 		}
 	}
 
@@ -253,6 +326,46 @@ func (m *Model) createRows() []table.Row {
 	return rows
 }
 
+func (m *Model) createRow(topic kadmin.ListedTopic) table.Row {
+	row := table.Row{
+		topic.Name,
+		strconv.Itoa(topic.PartitionCount),
+		strconv.Itoa(topic.Replicas),
+		topic.Cleanup,
+	}
+	if m.showSubjects {
+		key, value := m.cluster.Subjects(topic.Name)
+		row = append(row, m.subjectCell(key), m.subjectCell(value))
+	}
+	return row
+}
+
+// subjectCell renders the latest version and compatibility of a subject, or a dash when it doesn't exist
+func (m *Model) subjectCell(name string) string {
+	if m.subjects == nil {
+		return ""
+	}
+	subject, ok := m.subjects[name]
+	if !ok || len(subject.Versions) == 0 {
+		return "-"
+	}
+	return strings.TrimSpace(fmt.Sprintf("v%d %s", subject.LatestVersion(), subject.Compatibility))
+}
+
+func (m *Model) listSubjects() tea.Msg {
+	started, ok := m.subjectLister.ListSubjects().(sradmin.SubjectListingStartedMsg)
+	if !ok {
+		return nil
+	}
+	switch msg := started.AwaitCompletion().(type) {
+	case sradmin.SubjectsListedMsg:
+		return subjectsListedMsg{msg.Subjects}
+	case sradmin.SubjectListingErrorMsg:
+		return subjectListingErrMsg{msg.Err}
+	}
+	return nil
+}
+
 func (m *Model) SelectedTopic() *kadmin.ListedTopic {
 	selectedTopic := m.SelectedTopicName()
 	for _, t := range m.topics {
@@ -263,6 +376,16 @@ func (m *Model) SelectedTopic() *kadmin.ListedTopic {
 	return nil
 }
 
+// Topic returns the listed topic with the given name, nil when unknown
+func (m *Model) Topic(name string) *kadmin.ListedTopic {
+	for _, t := range m.topics {
+		if t.Name == name {
+			return &t
+		}
+	}
+	return nil
+}
+
 func (m *Model) SelectedTopicName() *string {
 	selectedRow := m.table.SelectedRow()
 	if selectedRow != nil {
@@ -282,9 +405,24 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 			return shortCuts
 		}
 	}
+	if m.subjectLister != nil {
+		return append(slices.Clone(m.shortcuts), []statusbar.Shortcut{
+			{"Toggle Subjects", "F6"},
+			{"Go To Value Subject", "S-s"},
+		}...)
+	}
 	return m.shortcuts
 }
 
+// WithSubjects enables the key and value subject columns,
+// the subjects of a topic are determined by the subject name strategy of the cluster.
+func WithSubjects(lister sradmin.SubjectLister, cluster *config.Cluster) Option {
+	return func(m *Model) {
+		m.subjectLister = lister
+		m.cluster = cluster
+	}
+}
+
 func (m *Model) Refresh() tea.Cmd {
 	m.topics = nil
 	return m.lister.ListTopics
@@ -293,13 +431,19 @@ func (m *Model) Refresh() tea.Cmd {
 func New(
 	ka kadmin.Kadmin,
 	navigator tabs.TopicsTabNavigator,
+	options ...Option,
 ) (*Model, tea.Cmd) {
 	var m = Model{}
 	m.navigator = navigator
+	for _, option := range options {
+		option(&m)
+	}
 	m.shortcuts = []statusbar.Shortcut{
 		{"Quick Consume", "enter"},
 		{"Granular Consume", "C-g"},
 		{"Live Consume", "S-l"},
+		{"Find by Key", "C-f"},
+		{"Table View", "C-t"},
 		{"Search", "/"},
 		{"Produce", "C-p"},
 		{"Create", "C-n"},
@@ -375,6 +519,45 @@ func New(
 		},
 	)
 
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg tableViewUnavailableMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.ShowErrorMsg(
+				"Table view unavailable",
+				fmt.Errorf("%s is not a compacted topic", msg.topic),
+			)
+			return true, m.AutoHideCmd(name)
+		},
+	)
+
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg subjectListingErrMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.ShowErrorMsg("Error listing Subjects", msg.err)
+			return true, m.AutoHideCmd(name)
+		},
+	)
+
+	cmdbar.BindNotificationHandler(
+		notifierCmdBar,
+		func(
+			msg noValueSubjectMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.ShowErrorMsg(
+				"No value subject",
+				fmt.Errorf("configure the value record of %s in topicSubjects", msg.topic),
+			)
+			return true, m.AutoHideCmd(name)
+		},
+	)
+
 	cmdbar.BindNotificationHandler(
 		notifierCmdBar,
 		func(
@@ -2,8 +2,12 @@ package topics_page
 
 import (
 	"fmt"
+	"ktea/config"
 	"ktea/kadmin"
+	"ktea/sradmin"
 	"ktea/tests"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
 	"ktea/ui/tabs"
 	"strings"
 	"testing"
@@ -408,6 +412,87 @@ func TestTopicsPage(t *testing.T) {
 		)
 	})
 
+	t.Run("C-f navigates to key lookup page", func(t *testing.T) {
+		page, _ := New(
+			kadmin.NewMockKadmin(),
+			tabs.NewMockTopicsTabNavigator(),
+		)
+
+		_ = page.Update(kadmin.TopicsListedMsg{
+			Topics: []kadmin.ListedTopic{
+				{
+					Name:           "topic1",
+					PartitionCount: 3,
+					Replicas:       1,
+				},
+			},
+		})
+
+		page.View(tests.NewKontext(), tests.Renderer)
+
+		cmd := page.Update(tests.Key(tea.KeyCtrlF))
+
+		assert.Equal(t, nav.LoadKeyLookupPageMsg{
+			Topic: &kadmin.ListedTopic{
+				Name:           "topic1",
+				PartitionCount: 3,
+				Replicas:       1,
+			},
+		}, cmd())
+	})
+
+	t.Run("C-t", func(t *testing.T) {
+		t.Run("navigates to table view of compacted topic", func(t *testing.T) {
+			page, _ := New(
+				kadmin.NewMockKadmin(),
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			_ = page.Update(kadmin.TopicsListedMsg{
+				Topics: []kadmin.ListedTopic{
+					{
+						Name:           "topic1",
+						PartitionCount: 3,
+						Replicas:       1,
+						Cleanup:        "compact,delete",
+					},
+				},
+			})
+
+			page.View(tests.NewKontext(), tests.Renderer)
+
+			cmd := page.Update(tests.Key(tea.KeyCtrlT))
+
+			assert.IsType(t, nav.LoadCompactedTopicPageMsg{}, cmd())
+		})
+
+		t.Run("shows error for non-compacted topic", func(t *testing.T) {
+			page, _ := New(
+				kadmin.NewMockKadmin(),
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			_ = page.Update(kadmin.TopicsListedMsg{
+				Topics: []kadmin.ListedTopic{
+					{
+						Name:           "topic1",
+						PartitionCount: 3,
+						Replicas:       1,
+						Cleanup:        "delete",
+					},
+				},
+			})
+
+			page.View(tests.NewKontext(), tests.Renderer)
+
+			cmd := page.Update(tests.Key(tea.KeyCtrlT))
+			page.Update(cmd())
+
+			render := page.View(tests.NewKontext(), tests.Renderer)
+			assert.Contains(t, render, "topic1 is not a compacted topic")
+		})
+	})
+
 	t.Run("hidden internal topics", func(t *testing.T) {
 		page, _ := New(
 			kadmin.NewMockKadmin(),
@@ -437,6 +522,100 @@ func TestTopicsPage(t *testing.T) {
 		assert.Equal(t, 3, page.hiddenInternalTopicsCount)
 	})
 
+	t.Run("Subject columns", func(t *testing.T) {
+		newPage := func() *Model {
+			page, _ := New(
+				kadmin.NewMockKadmin(),
+				tabs.NewMockTopicsTabNavigator(),
+				WithSubjects(sradmin.NewMock(), &config.Cluster{
+					TopicSubjects: []config.TopicSubjects{
+						{Topic: "payments", Strategy: config.RecordNameStrategy, KeyRecord: "com.PaymentKey"},
+					},
+				}),
+			)
+			_ = page.Update(kadmin.TopicsListedMsg{
+				Topics: []kadmin.ListedTopic{
+					{Name: "orders", PartitionCount: 1, Replicas: 1},
+					{Name: "payments", PartitionCount: 1, Replicas: 1},
+				},
+			})
+			return page
+		}
+
+		t.Run("hidden by default", func(t *testing.T) {
+			page := newPage()
+
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.NotContains(t, render, "Value Subject")
+		})
+
+		t.Run("F6 lists the subjects and shows their latest version", func(t *testing.T) {
+			page := newPage()
+
+			cmd := page.Update(tests.Key(tea.KeyF6))
+			assert.NotNil(t, cmd)
+
+			page.Update(subjectsListedMsg{subjects: []sradmin.Subject{
+				{Name: "orders-key", Versions: []int{1}, Compatibility: "NONE"},
+				{Name: "orders-value", Versions: []int{1, 3, 2}, Compatibility: "BACKWARD"},
+				{Name: "com.PaymentKey", Versions: []int{4}, Deleted: true},
+			}})
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "Key Subject")
+			assert.Contains(t, render, "Value Subject")
+			assert.Regexp(t, `orders\s.*v1 NONE\s+v3 BACKWARD`, render)
+			// deleted subject and an unconfigured value record
+			assert.Regexp(t, `payments\s.*-\s+-`, render)
+		})
+
+		t.Run("F6 hides the columns again without listing", func(t *testing.T) {
+			page := newPage()
+			page.Update(tests.Key(tea.KeyF6))
+			page.Update(subjectsListedMsg{})
+
+			cmd := page.Update(tests.Key(tea.KeyF6))
+			assert.Nil(t, cmd)
+
+			cmd = page.Update(tests.Key(tea.KeyF6))
+			assert.Nil(t, cmd)
+		})
+
+		t.Run("S navigates to the value subject", func(t *testing.T) {
+			page := newPage()
+			page.View(tests.NewKontext(), tests.Renderer)
+
+			cmd := page.Update(tests.Key('S'))
+
+			assert.Equal(t, nav.LoadSubjectsPageMsg{Subject: "orders-value"}, cmd())
+		})
+
+		t.Run("S shows an error when there is no value subject", func(t *testing.T) {
+			page := newPage()
+			page.View(tests.NewKontext(), tests.Renderer)
+			page.Update(tests.Key(tea.KeyDown))
+
+			msg := page.Update(tests.Key('S'))()
+			page.Update(msg)
+			render := page.View(tests.NewKontext(), tests.Renderer)
+
+			assert.Contains(t, render, "No value subject")
+		})
+
+		t.Run("not available without a schema registry", func(t *testing.T) {
+			page, _ := New(
+				kadmin.NewMockKadmin(),
+				tabs.NewMockTopicsTabNavigator(),
+			)
+
+			cmd := page.Update(tests.Key(tea.KeyF6))
+
+			assert.Nil(t, cmd)
+			assert.NotContains(t, page.Shortcuts(), statusbar.Shortcut{Name: "Toggle Subjects", Keybinding: "F6"})
+		})
+	})
+
 	t.Run("Do not show deletion cmdbar when no topic is selected", func(t *testing.T) {
 		page, _ := New(
 			kadmin.NewMockKadmin(),
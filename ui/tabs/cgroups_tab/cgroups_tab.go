@@ -14,12 +14,16 @@ import (
 )
 
 type Model struct {
-	active        pages.Page
-	statusbar     *statusbar.Model
-	offsetLister  kadmin.OffsetLister
-	cgroupLister  kadmin.CGroupLister
-	cgroupDeleter kadmin.CGroupDeleter
-	cgroupsPage   *cgroups_page.Model
+	ktx             *kontext.ProgramKtx
+	active          pages.Page
+	statusbar       *statusbar.Model
+	offsetLister    kadmin.OffsetLister
+	cgroupLister    kadmin.CGroupLister
+	cgroupDescriber kadmin.CGroupDescriber
+	cgroupDeleter   kadmin.CGroupDeleter
+	offsetsDeleter  kadmin.CGroupOffsetsDeleter
+	timeLagReader   kadmin.TimeLagReader
+	cgroupsPage     *cgroups_page.Model
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -34,14 +38,21 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
 	case nav.LoadCGroupTopicsPageMsg:
-		cgroupsTopicsPage, cmd := cgroups_topics_page.New(m.offsetLister, msg.GroupName)
+		cgroupsTopicsPage, cmd := cgroups_topics_page.New(
+			m.offsetLister,
+			m.cgroupDescriber,
+			m.offsetsDeleter,
+			m.timeLagReader,
+			msg.GroupName,
+			m.ktx.Config().LagSamplingInterval(),
+		)
 		cmds = append(cmds, cmd)
 		m.active = cgroupsTopicsPage
 		return tea.Batch(cmds...)
 	case nav.LoadCGroupsPageMsg:
 		var cmd tea.Cmd
 		if m.cgroupsPage == nil {
-			m.cgroupsPage, cmd = cgroups_page.New(m.cgroupLister, m.cgroupDeleter)
+			m.cgroupsPage, cmd = cgroups_page.New(m.cgroupLister, m.cgroupDeleter, m.timeLagReader)
 		}
 		m.active = m.cgroupsPage
 		cmds = append(cmds, cmd)
@@ -59,17 +70,25 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 }
 
 func New(
+	ktx *kontext.ProgramKtx,
 	cgroupLister kadmin.CGroupLister,
 	cgroupDeleter kadmin.CGroupDeleter,
 	consumerGroupOffsetLister kadmin.OffsetLister,
+	cgroupDescriber kadmin.CGroupDescriber,
+	offsetsDeleter kadmin.CGroupOffsetsDeleter,
+	timeLagReader kadmin.TimeLagReader,
 	statusbar *statusbar.Model,
 ) (*Model, tea.Cmd) {
-	cgroupsPage, cmd := cgroups_page.New(cgroupLister, cgroupDeleter)
+	cgroupsPage, cmd := cgroups_page.New(cgroupLister, cgroupDeleter, timeLagReader)
 
 	m := &Model{}
+	m.ktx = ktx
 	m.offsetLister = consumerGroupOffsetLister
 	m.cgroupLister = cgroupLister
+	m.cgroupDescriber = cgroupDescriber
 	m.cgroupDeleter = cgroupDeleter
+	m.offsetsDeleter = offsetsDeleter
+	m.timeLagReader = timeLagReader
 	m.cgroupsPage = cgroupsPage
 	m.active = cgroupsPage
 	m.statusbar = statusbar
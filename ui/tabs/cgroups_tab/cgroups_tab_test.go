@@ -25,6 +25,24 @@ func (m *MockConsumerGroupLister) ListCGroups() tea.Msg {
 	return nil
 }
 
+type MockConsumerGroupDescriber struct{}
+
+func (m *MockConsumerGroupDescriber) DescribeCGroup(_ string) tea.Msg {
+	return nil
+}
+
+type MockConsumerGroupOffsetsDeleter struct{}
+
+func (m *MockConsumerGroupOffsetsDeleter) DeleteCGroupOffsets(_ string, _ string, _ []int32) tea.Msg {
+	return nil
+}
+
+type MockConsumerGroupTimeLagReader struct{}
+
+func (m *MockConsumerGroupTimeLagReader) ReadTimeLag(_ string) tea.Msg {
+	return nil
+}
+
 type MockConsumerGroupDeleter struct{}
 
 func (m *MockConsumerGroupDeleter) DeleteCGroup(name string) tea.Msg {
@@ -33,7 +51,7 @@ func (m *MockConsumerGroupDeleter) DeleteCGroup(name string) tea.Msg {
 
 func TestGroupsTab(t *testing.T) {
 	t.Run("List consumer groups", func(t *testing.T) {
-		groupsTab, _ := New(&MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{}, statusbar.New())
+		groupsTab, _ := New(tests.NewKontext(), &MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{}, &MockConsumerGroupDescriber{}, &MockConsumerGroupOffsetsDeleter{}, &MockConsumerGroupTimeLagReader{}, statusbar.New())
 
 		groupsTab.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -26,6 +26,7 @@ type Origin int
 const (
 	OriginTopicsPage Origin = iota
 	OriginConsumeFormPage
+	OriginKeyLookupPage
 )
 
 type ConsumePageDetails struct {
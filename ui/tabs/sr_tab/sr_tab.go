@@ -50,10 +50,19 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			m.subjectsPage, cmd = subjects_page.New(m.srClient)
 			cmds = append(cmds, cmd)
 		}
+		if msg.Subject != "" {
+			m.subjectsPage.SelectSubject(msg.Subject)
+		}
 		m.active = m.subjectsPage
 	case nav.LoadSchemaDetailsPageMsg:
 		var cmd tea.Cmd
-		m.schemaDetailsPage, cmd = schema_details_page.New(m.srClient, m.srClient, msg.Subject, clipper.New())
+		m.schemaDetailsPage, cmd = schema_details_page.New(
+			m.srClient,
+			m.srClient,
+			msg.Subject,
+			clipper.New(),
+			schema_details_page.WithVersion(msg.Version),
+		)
 		m.active = m.schemaDetailsPage
 		cmds = append(cmds, cmd)
 	}
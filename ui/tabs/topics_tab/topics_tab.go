@@ -4,14 +4,18 @@ import (
 	"context"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/serdes"
+	"ktea/sradmin"
 	"ktea/ui"
 	"ktea/ui/clipper"
 	"ktea/ui/components/statusbar"
 	"ktea/ui/pages"
+	"ktea/ui/pages/compacted_topic_page"
 	"ktea/ui/pages/configs_page"
 	"ktea/ui/pages/consume_form_page"
 	"ktea/ui/pages/consume_page"
 	"ktea/ui/pages/create_topic_page"
+	"ktea/ui/pages/key_lookup_page"
 	"ktea/ui/pages/nav"
 	"ktea/ui/pages/publish_page"
 	"ktea/ui/pages/record_details_page"
@@ -29,10 +33,13 @@ type Model struct {
 	topicsPage        *topics_page.Model
 	statusbar         *statusbar.Model
 	ka                kadmin.Kadmin
+	sra               sradmin.Client
 	ktx               *kontext.ProgramKtx
 	consumptionPage   pages.Page
 	recordDetailsPage pages.Page
 	ctx               context.Context
+	// kaInstantiator connects to the other clusters records are republished to
+	kaInstantiator kadmin.Instantiator
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -76,18 +83,31 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.active = create_topic_page.New(m.ka)
 
 	case nav.LoadPublishPageMsg:
-		m.active = publish_page.New(m.ka, msg.Topic)
+		m.active = m.newPublishPage(msg)
+
+	case nav.LoadKeyLookupPageMsg:
+		m.active = key_lookup_page.New(msg.Topic, msg.Key, m)
+
+	case nav.LoadCompactedTopicPageMsg:
+		var cmd tea.Cmd
+		m.active, cmd = compacted_topic_page.New(m.ka, msg.Topic, m)
+		// record details navigate back to the cached consumption page
+		m.consumptionPage = m.active
+		cmds = append(cmds, cmd)
 
 	case nav.LoadCachedConsumptionPageMsg:
 		m.active = m.consumptionPage
 
+	case nav.LoadCachedRecordDetailsPageMsg:
+		m.active = m.recordDetailsPage
+
 	case nav.LoadLiveConsumePageMsg:
 		var cmd tea.Cmd
 		readDetails := kadmin.ReadDetails{
 			TopicName:       msg.Topic.Name,
 			PartitionToRead: msg.Topic.Partitions(),
 			StartPoint:      kadmin.Live,
-			Limit:           500,
+			Limit:           m.ktx.Config().LiveRecordsBufferSize(),
 			Filter:          nil,
 		}
 		m.active, cmd = consume_page.New(
@@ -112,6 +132,26 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+func (m *Model) newPublishPage(msg nav.LoadPublishPageMsg) *publish_page.Model {
+	options := []publish_page.Option{publish_page.WithTemplates(m.ktx.Config())}
+	if msg.Record == nil {
+		return publish_page.New(m.ka, msg.Topic, options...)
+	}
+
+	topic := msg.Topic
+	if listed := m.topicsPage.Topic(topic.Name); listed != nil {
+		topic = listed
+	}
+	options = append(options, publish_page.WithRecord(msg.Record))
+	if m.kaInstantiator != nil {
+		options = append(options, publish_page.WithClusters(m.ktx.Config(), m.kaInstantiator))
+	}
+	if m.sra != nil {
+		options = append(options, publish_page.WithAvroSerializer(serdes.NewAvroSerializer(serdes.NewAvroCodecCache(m.sra))))
+	}
+	return publish_page.New(m.ka, topic, options...)
+}
+
 func (m *Model) ToTopicsPage() tea.Cmd {
 	m.active = m.topicsPage
 	return nil
@@ -149,23 +189,42 @@ func (m *Model) ToConsumeFormPage(d tabs.ConsumeFormPageDetails) tea.Cmd {
 }
 
 func (m *Model) ToRecordDetailsPage(msg tabs.LoadRecordDetailPageMsg) tea.Cmd {
-	m.active = record_details_page.New(msg.Record, msg.TopicName, msg.Records, msg.Index, clipper.New(), m.ktx)
+	var options []record_details_page.Option
+	if m.sra != nil {
+		options = append(options, record_details_page.WithSchemaResolver(m.sra))
+	}
+	page := record_details_page.New(msg.Record, msg.TopicName, msg.Records, msg.Index, clipper.New(), m.ktx, options...)
+	m.active = page
 	m.recordDetailsPage = m.active
-	return nil
+	return page.Init()
 }
 
-func New(ktx *kontext.ProgramKtx, ka kadmin.Kadmin, stsBar *statusbar.Model) (*Model, tea.Cmd) {
+// New creates the topics tab, sra is nil when the cluster has no schema registry.
+func New(
+	ktx *kontext.ProgramKtx,
+	ka kadmin.Kadmin,
+	sra sradmin.Client,
+	kai kadmin.Instantiator,
+	stsBar *statusbar.Model,
+) (*Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	model := &Model{}
 	model.ka = ka
+	model.sra = sra
+	model.kaInstantiator = kai
 	model.ktx = ktx
 	model.statusbar = stsBar
 	model.statusbar.SetProvider(model.active)
 
+	var options []topics_page.Option
+	if sra != nil {
+		options = append(options, topics_page.WithSubjects(sra, ktx.Config().ActiveCluster()))
+	}
 	listTopicView, cmd := topics_page.New(
 		ka,
 		model,
+		options...,
 	)
 	model.active = listTopicView
 	model.topicsPage = listTopicView